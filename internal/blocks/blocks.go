@@ -15,6 +15,7 @@ type HeadingBlock struct {
 	ParentNumbering string // Basis-Nummerierung aus der Ordnerstruktur (z.B. "1.1_")
 	AnchorID        string // Eindeutige ID für Anchor-Links (z.B. "einführung-in-docgen")
 	ExcludeFromTOC  bool   // Wenn true, wird die Überschrift nicht im TOC angezeigt und nicht nummeriert (Syntax: !#! oder !##!)
+	File            string // Quelldatei relativ zum Content-Verzeichnis (für den Platzhalter {file})
 }
 
 func (h HeadingBlock) IsBlock() {}
@@ -38,22 +39,56 @@ type TextSegment struct {
 
 // ImageBlock repräsentiert ein Bild.
 type ImageBlock struct {
-	Path  string  // Dateipfad zum Bild
-	Alt   string  // Alternativtext
-	Title string  // Optionaler Bildtitel
-	Width float64 // Optionale Breite in mm (0 = automatisch)
-	Scale float64 // Optionaler Skalierungsfaktor (z.B. 0.8 für 80%)
+	Path     string  // Dateipfad zum Bild
+	Alt      string  // Alternativtext
+	Title    string  // Optionaler Bildtitel
+	Width    float64 // Optionale Breite in mm (0 = automatisch)
+	Scale    float64 // Optionaler Skalierungsfaktor (z.B. 0.8 für 80%)
+	Fallback string  // Optionales Rasterbild, falls ein SVG nicht gezeichnet werden kann
+
+	WidthPercent  float64 // Optionale Breite in % der Textbreite (hat Vorrang vor Width)
+	Height        float64 // Optionale Höhe in mm (0 = aus dem Seitenverhältnis)
+	HeightPercent float64 // Optionale Höhe in % der Satzspiegelhöhe (hat Vorrang vor Height)
+	Align         string  // Ausrichtung: "left", "center" (Standard) oder "right"
+	NoBorder      bool    // Bild ohne Rahmen und Container zeichnen
+	Float         string  // "left" oder "right": Der folgende Text umfließt das Bild
 }
 
 func (i ImageBlock) IsBlock() {}
 
-// MermaidBlock repräsentiert ein Mermaid-Diagramm.
-type MermaidBlock struct {
-	Content string // Mermaid-Syntax Quellcode
-	Title   string // Optionaler Titel des Diagramms
+// DiagramBlock repräsentiert ein Diagramm (Mermaid, Graphviz, PlantUML, D2), das vor dem
+// PDF-Rendering vom passenden Diagramm-Renderer in ein Bild umgewandelt wird.
+type DiagramBlock struct {
+	Language   string            // Fence-Sprache (z.B. "mermaid", "dot", "plantuml", "d2")
+	Content    string            // Quellcode des Diagramms
+	Title      string            // Optionaler Titel des Diagramms
+	Width      float64           // Breite in mm für dieses Diagramm (0 = Standard aus der Konfiguration)
+	Scale      float64           // Skalierung für dieses Diagramm (0 = Standard aus der Konfiguration)
+	Theme      string            // Theme für dieses Diagramm (leer = Projekt-Theme)
+	Attributes map[string]string // Alle Attribute aus dem Info-String (z.B. layout=neato)
 }
 
-func (m MermaidBlock) IsBlock() {}
+func (d DiagramBlock) IsBlock() {}
+
+// ChartBlock repräsentiert ein Diagramm aus Messwerten (Balken, Linien, Torte, gestapelt),
+// das vom PDF-Generator direkt als Vektorgrafik gezeichnet wird.
+type ChartBlock struct {
+	Type   string        // Diagrammtyp: "bar", "line", "pie" oder "stacked"
+	Title  string        // Optionaler Titel des Diagramms
+	Labels []string      // Kategorien (X-Achse bzw. Tortenstücke)
+	Series []ChartSeries // Datenreihen, jeweils ein Wert pro Kategorie
+	Unit   string        // Optionale Einheit für die Werteachse (z.B. "ms")
+	Height float64       // Höhe der Zeichenfläche in mm (0 = Standard)
+	Colors []string      // Optionale eigene Farben der Datenreihen (Hex)
+}
+
+func (c ChartBlock) IsBlock() {}
+
+// ChartSeries ist eine benannte Datenreihe eines ChartBlocks.
+type ChartSeries struct {
+	Name   string    // Name der Reihe (erscheint in der Legende)
+	Values []float64 // Werte in der Reihenfolge der Labels
+}
 
 // CodeBlock repräsentiert einen mehrzeiligen Code-Abschnitt.
 type CodeBlock struct {
@@ -84,6 +119,40 @@ type PageBreakBlock struct{}
 
 func (p PageBreakBlock) IsBlock() {}
 
+// OrientationBlock wechselt die Seitenausrichtung für die folgenden Inhalte
+// (Markdown-Direktiven <!-- landscape --> und <!-- portrait -->).
+type OrientationBlock struct {
+	Orientation string // "landscape" oder "portrait"
+}
+
+func (o OrientationBlock) IsBlock() {}
+
+// ColumnsBlock beginnt einen mehrspaltigen Abschnitt (Markdown-Direktive <!-- columns 2 -->).
+// Count = 1 beendet den Abschnitt (<!-- /columns -->).
+type ColumnsBlock struct {
+	Count int     // Anzahl der Spalten
+	Gap   float64 // Spaltenabstand in mm (0 = aus der Konfiguration)
+	Rule  *bool   // Trennlinie zwischen den Spalten (nil = aus der Konfiguration)
+}
+
+func (c ColumnsBlock) IsBlock() {}
+
+// MatterBlock beginnt einen Dokumentteil mit eigener Seitennummerierung
+// (Markdown-Direktiven <!-- frontmatter -->, <!-- mainmatter --> und <!-- appendix -->).
+type MatterBlock struct {
+	Matter string // "front", "main" oder "appendix"
+}
+
+func (m MatterBlock) IsBlock() {}
+
+// KeepBlock steuert Seitenumbrüche zwischen den folgenden Blöcken (Markdown-Direktiven
+// <!-- keep-with-next -->, <!-- keep-together --> und <!-- /keep-together -->).
+type KeepBlock struct {
+	Keep string // "next", "together" oder "end"
+}
+
+func (k KeepBlock) IsBlock() {}
+
 // BlockquoteBlock repräsentiert ein Zitat (Blockquote).
 type BlockquoteBlock struct {
 	Content []DocBlock // Inhalt des Zitats (kann Paragraphen, Listen etc. enthalten)
@@ -95,6 +164,7 @@ func (b BlockquoteBlock) IsBlock() {}
 type TableBlock struct {
 	Rows       [][]TableRow // Zweidimensionale Liste der Tabellenzellen
 	Alignments []Align      // Ausrichtung der Spalten
+	Options    TableOptions // Darstellung aus <!-- table ... --> vor der Tabelle
 }
 
 type Align int
@@ -103,12 +173,51 @@ const (
 	AlignLeft Align = iota
 	AlignCenter
 	AlignRight
+	AlignDecimal // Rechtsbündig, Zahlen am Dezimaltrennzeichen ausgerichtet
 )
 
-// TableRow repräsentiert eine Zelle in einer Tabellenzeile.
+// TableOptions beschreibt die Darstellung einer einzelnen Tabelle (Direktive <!-- table ... -->).
+// Leere Werte übernehmen die Vorgaben aus tables in docgen.yml.
+type TableOptions struct {
+	Widths   []TableWidth // Spaltenbreiten (fehlende Einträge = automatisch)
+	Align    []Align      // Ausrichtung je Spalte, ersetzt die aus dem Markdown
+	Style    string       // "default", "compact" oder "bordered"
+	Zebra    *bool        // Zebra-Streifen
+	Stretch  *bool        // Tabelle auf die volle Textbreite strecken
+	FontSize float64      // Schriftgröße in pt
+	Padding  float64      // Innenabstand der Zellen in mm
+	Header   string       // Hintergrundfarbe der Kopfzeilen (Hex)
+}
+
+// TableWidth ist die Breite einer Tabellenspalte in mm oder in Prozent der Textbreite.
+type TableWidth struct {
+	Value   float64 // 0 = automatisch
+	Percent bool
+}
+
+// TableRow repräsentiert eine Zelle in einer Tabellenzeile. Eine Zeile enthält nur die Zellen,
+// die in ihr beginnen; Plätze, die eine Zelle aus einer Zeile darüber überspannt, fehlen.
 type TableRow struct {
 	Content []TextSegment
-	Header  bool // Wahr, wenn die Zelle als Kopfzeile formatiert werden soll
+	Header  bool       // Wahr, wenn die Zelle als Kopfzeile formatiert werden soll
+	Blocks  []DocBlock // Blockinhalt (Listen, Code, mehrere Absätze), ersetzt Content
+	ColSpan int        // Anzahl überspannter Spalten (0 oder 1 = eine)
+	RowSpan int        // Anzahl überspannter Zeilen (0 oder 1 = eine)
 }
 
 func (t TableBlock) IsBlock() {}
+
+// DataTableBlock ist eine Tabelle aus einer Datendatei (```table Block mit src=).
+// Der Builder liest die Datei bei jedem Build und ersetzt den Block durch einen TableBlock.
+type DataTableBlock struct {
+	Src     string            // Pfad der CSV-, TSV- oder JSON-Datei (relativ zum Projekt)
+	Columns []string          // Angezeigte Spalten in dieser Reihenfolge (leer = alle)
+	Headers map[string]string // Spaltenname -> angezeigte Überschrift
+	Sort    []string          // Sortierspalten, ein "-" vor dem Namen sortiert absteigend
+	Filter  []string          // Bedingungen wie "status=stable" oder "since>=2", alle müssen zutreffen
+	Format  map[string]string // Spaltenname -> Zahlenformat wie "#,##0.00"
+	Options TableOptions      // Darstellung der erzeugten Tabelle
+	Error   string            // Fehler in den Angaben; statt der Tabelle erscheint ein Hinweis
+}
+
+func (d DataTableBlock) IsBlock() {}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"godocgen/internal/engine/mermaid"
 
 	"github.com/spf13/cobra"
 )
@@ -13,6 +14,7 @@ var versionCmd = &cobra.Command{
 	Short: "Gibt die aktuelle Version von goDocGen aus",
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Printf("goDocGen version %s\n", version)
+		fmt.Printf("Mermaid JS %s\n", mermaid.EmbeddedVersion())
 	},
 }
 
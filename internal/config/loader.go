@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"godocgen/internal/i18n"
 	"os"
+	"runtime"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
@@ -30,6 +32,9 @@ func LoadConfig(path string) (*Config, error) {
 	if err := validate.Struct(cfg); err != nil {
 		return nil, fmt.Errorf("Validierungsfehler: %w", err)
 	}
+	if _, _, err := cfg.Layout.Page.Dimensions(); err != nil {
+		return nil, fmt.Errorf("Validierungsfehler: %w", err)
+	}
 
 	return cfg, nil
 }
@@ -255,6 +260,21 @@ func setDefaults(cfg *Config) {
 	// HeaderNumbering: Standardwert ist false (keine automatische Nummerierung)
 	// Der Wert aus der YAML-Datei wird nicht überschrieben
 
+	if cfg.Layout.Page.Size == "" {
+		cfg.Layout.Page.Size = "A4"
+	}
+	if cfg.Layout.Page.Orientation == "" {
+		cfg.Layout.Page.Orientation = "portrait"
+	}
+	if cfg.PageNumbers.Style == "" {
+		cfg.PageNumbers.Style = "arabic"
+	}
+	if cfg.PageNumbers.FrontMatter == "" {
+		cfg.PageNumbers.FrontMatter = "roman"
+	}
+	if cfg.PageNumbers.Appendix == "" {
+		cfg.PageNumbers.Appendix = "letter"
+	}
 	if cfg.Layout.Margins.Left == 0 {
 		cfg.Layout.Margins.Left = 25
 	}
@@ -267,6 +287,30 @@ func setDefaults(cfg *Config) {
 	if cfg.Layout.Margins.Bottom == 0 {
 		cfg.Layout.Margins.Bottom = 10
 	}
+	if cfg.Layout.Columns.Gap == 0 {
+		cfg.Layout.Columns.Gap = 8
+	}
+	if cfg.Layout.Hyphenation.MinWord == 0 {
+		cfg.Layout.Hyphenation.MinWord = 5
+	}
+	if cfg.Layout.Breaks.Widows == 0 {
+		cfg.Layout.Breaks.Widows = 2
+	}
+	if cfg.Layout.Breaks.Orphans == 0 {
+		cfg.Layout.Breaks.Orphans = 2
+	}
+	if cfg.Tables.Style == "" {
+		cfg.Tables.Style = "default"
+	}
+	if cfg.Tables.MaxStretch == 0 {
+		cfg.Tables.MaxStretch = 1.5
+	}
+	if cfg.Layout.Margins.Inner == 0 {
+		cfg.Layout.Margins.Inner = cfg.Layout.Margins.Left
+	}
+	if cfg.Layout.Margins.Outer == 0 {
+		cfg.Layout.Margins.Outer = cfg.Layout.Margins.Right
+	}
 	if cfg.Gradient.Orientation == "" {
 		cfg.Gradient.Orientation = "vertical"
 	}
@@ -306,10 +350,47 @@ func setDefaults(cfg *Config) {
 		cfg.TOC.Indent = 6.0 // Kleinere Einrückung für kompakteres TOC
 	}
 
+	// Terminal Defaults (dunkler Container wie in gängigen Terminal-Emulatoren)
+	if cfg.Code.Terminal.Background == "" {
+		cfg.Code.Terminal.Background = "#1e1e1e"
+	}
+	if cfg.Code.Terminal.Foreground == "" {
+		cfg.Code.Terminal.Foreground = "#d4d4d4"
+	}
+	if cfg.Code.Terminal.PromptColor == "" {
+		cfg.Code.Terminal.PromptColor = "#23d18b"
+	}
+
 	// Mermaid Defaults
 	if cfg.Mermaid.Scale == 0 {
 		cfg.Mermaid.Scale = 1.0 // Standardskalierung 100%
 	}
+	if cfg.Mermaid.Workers <= 0 {
+		// Jeder Worker belegt einen Chrome-Tab bzw. einen mmdc-Prozess, daher begrenzen
+		cfg.Mermaid.Workers = runtime.NumCPU()
+		if cfg.Mermaid.Workers > 4 {
+			cfg.Mermaid.Workers = 4
+		}
+	}
+	if cfg.Mermaid.Theme == "" {
+		cfg.Mermaid.Theme = "auto" // Farben aus dem Dokument-Theme ableiten
+	}
+
+	// Sprache und Header- und Footer-Zeilen
+	if cfg.Language == "" {
+		cfg.Language = i18n.DefaultLanguage
+	}
+	if cfg.DateFormat == "" {
+		cfg.DateFormat = i18n.DefaultDateFormat(cfg.Language)
+	}
+	for _, zones := range []*PageZones{&cfg.Header.PageZones, &cfg.Footer.PageZones} {
+		if zones.Height == 0 {
+			zones.Height = 10
+		}
+		if zones.FontSize == 0 {
+			zones.FontSize = 8
+		}
+	}
 
 	// Footer Defaults
 	if cfg.Footer.Left == "" && cfg.Footer.Center == "" && cfg.Footer.Right == "" {
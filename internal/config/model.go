@@ -25,9 +25,23 @@ type Config struct {
 	CodeTheme   string      `yaml:"code_theme"`                         // Theme für Code-Highlighting
 	Code        Code        `yaml:"code"`                               // Code-Block-Einstellungen
 	Mermaid     Mermaid     `yaml:"mermaid"`                            // Mermaid-Diagramm-Konfiguration
+	Diagrams    Diagrams    `yaml:"diagrams"`                           // Weitere Diagramm-Renderer (Graphviz, PlantUML, D2)
+	Images      Images      `yaml:"images"`                             // Optimierung eingebetteter Rasterbilder
+	Tables      Tables      `yaml:"tables"`                             // Darstellung von Tabellen
 	TOC         TOC         `yaml:"toc"`                                // Inhaltsverzeichnis-Einstellungen
+	Cover       Cover       `yaml:"cover"`                              // Eigenes Deckblatt mit Umschlagseiten
+	Language    string      `yaml:"language"`                           // Sprache der eingebauten Texte und Formate (de, en, fr, es, ...)
+	Messages    Messages    `yaml:"translations"`                       // Eigene Übersetzungen der eingebauten Texte (z.B. toc.title)
+	DateFormat  string      `yaml:"date_format"`                        // Datumsformat als Go-Layout (Standard: je nach Sprache, z.B. 02.01.2006)
+	Vars        Variables   `yaml:"vars"`                               // Eigene Variablen für Header und Footer ({{.Vars.name}})
 }
 
+// Variables enthält eigene Werte, die in Header- und Footer-Vorlagen als {{.Vars.name}} verfügbar sind.
+type Variables map[string]string
+
+// Messages ersetzt eingebaute Texte des Dokuments, Schlüssel wie "toc.title" oder "cover.author".
+type Messages map[string]string
+
 // TOC definiert Einstellungen für das Inhaltsverzeichnis.
 type TOC struct {
 	Enabled      bool    `yaml:"enabled"`       // Inhaltsverzeichnis anzeigen
@@ -59,17 +73,73 @@ type Gradient struct {
 
 // Header definiert den Text oder das Bild im oberen Bereich jeder Seite.
 type Header struct {
-	Text  string `yaml:"text"`  // Text im Header
-	Image string `yaml:"image"` // Pfad zu einer Bilddatei für den Header
+	Text      string `yaml:"text"`  // Text im Header (ohne Zonen: linke Zone)
+	Image     string `yaml:"image"` // Pfad zu einer Bilddatei für den Header
+	PageZones `yaml:",inline"`
 }
 
 // Footer definiert den Text oder das Bild im unteren Bereich jeder Seite.
 type Footer struct {
-	Text   string `yaml:"text"`   // Veraltet: Text im Footer (nutze Left/Center/Right)
-	Image  string `yaml:"image"`  // Pfad zu einer Bilddatei für den Footer
-	Left   string `yaml:"left"`   // Inhalt linke Zone
-	Center string `yaml:"center"` // Inhalt mittlere Zone
-	Right  string `yaml:"right"`  // Inhalt rechte Zone
+	Text      string `yaml:"text"`  // Veraltet: Text im Footer (nutze Left/Center/Right)
+	Image     string `yaml:"image"` // Pfad zu einer Bilddatei für den Footer
+	PageZones `yaml:",inline"`
+}
+
+// PageZones beschreibt die drei Zonen von Header oder Footer. Die Inhalte sind Go-Templates
+// (text/template), z.B. "{{.Chapter}}", "{{.Git.ShortCommit}}" oder "{{image \"logo.png\"}}".
+type PageZones struct {
+	Left     string  `yaml:"left"`                       // Inhalt linke Zone
+	Center   string  `yaml:"center"`                     // Inhalt mittlere Zone
+	Right    string  `yaml:"right"`                      // Inhalt rechte Zone
+	Height   float64 `yaml:"height" validate:"gte=0"`    // Höhe der Zeile in mm (Standard: 10)
+	FontSize float64 `yaml:"font_size" validate:"gte=0"` // Schriftgröße (Standard: 8)
+	Rule     bool    `yaml:"rule"`                       // Trennlinie zum Inhalt
+}
+
+// Cover beschreibt ein frei gestaltetes Deckblatt sowie optionale Umschlagseiten.
+// Ohne Felder, Bilder und Hintergrund wird das Standard-Deckblatt gesetzt.
+type Cover struct {
+	CoverPage      `yaml:",inline"`
+	Version        string     `yaml:"version"`        // Dokumentversion ({{.Version}})
+	Classification string     `yaml:"classification"` // Einstufung, z.B. "Vertraulich" ({{.Classification}})
+	Inside         *CoverPage `yaml:"inside"`         // Innenseite des Umschlags direkt nach dem Deckblatt
+	Back           *CoverPage `yaml:"back"`           // Rückseite des Umschlags am Ende des Dokuments
+}
+
+// CoverPage beschreibt den Aufbau einer Umschlagseite.
+type CoverPage struct {
+	Background string       `yaml:"background"`             // Bild, das die ganze Seite füllt
+	Color      string       `yaml:"color"`                  // Hintergrundfarbe (Hex)
+	Images     []CoverImage `yaml:"images" validate:"dive"` // Positionierte Bilder, z.B. ein Logo
+	Fields     []CoverField `yaml:"fields" validate:"dive"` // Positionierte Textfelder
+}
+
+// IsEmpty prüft, ob die Seite weder Hintergrund noch Bilder oder Textfelder enthält.
+func (p CoverPage) IsEmpty() bool {
+	return p.Background == "" && p.Color == "" && len(p.Images) == 0 && len(p.Fields) == 0
+}
+
+// CoverImage platziert ein Bild auf einer Umschlagseite. Positionen beziehen sich in mm auf die
+// linke obere Ecke, negative Werte zählen vom rechten bzw. unteren Seitenrand.
+type CoverImage struct {
+	Path   string   `yaml:"path" validate:"required"` // Pfad relativ zum Projekt- oder assets-Verzeichnis
+	X      *float64 `yaml:"x"`                        // Abstand vom linken Rand (Standard: linker Seitenrand, negativ: vom rechten Rand)
+	Y      float64  `yaml:"y"`                        // Abstand vom oberen Rand (negativ: vom unteren Rand)
+	Width  float64  `yaml:"width" validate:"gte=0"`   // Breite in mm (0 = aus der Höhe berechnet)
+	Height float64  `yaml:"height" validate:"gte=0"`  // Höhe in mm (0 = aus der Breite berechnet)
+}
+
+// CoverField setzt einen Text auf eine Umschlagseite. Der Inhalt ist ein Go-Template mit
+// denselben Feldern wie Header und Footer, z.B. "{{.Title}}" oder "Version {{.Version}}".
+type CoverField struct {
+	Text  string   `yaml:"text"`                                                       // Inhalt des Feldes
+	X     *float64 `yaml:"x"`                                                          // Abstand vom linken Rand (Standard: linker Seitenrand, negativ: vom rechten Rand)
+	Y     float64  `yaml:"y"`                                                          // Abstand vom oberen Rand (negativ: vom unteren Rand)
+	Width float64  `yaml:"width" validate:"gte=0"`                                     // Breite in mm (0 = bis zum rechten Seitenrand)
+	Size  float64  `yaml:"size" validate:"gte=0"`                                      // Schriftgröße (Standard: font_size)
+	Style string   `yaml:"style" validate:"omitempty,oneof=B I BI"`                    // Schriftschnitt: B (fett), I (kursiv), BI
+	Color string   `yaml:"color"`                                                      // Textfarbe (Standard: colors.title)
+	Align string   `yaml:"align" validate:"omitempty,oneof=left center right justify"` // Ausrichtung (Standard: layout.startpage)
 }
 
 // Colors definiert die im Dokument verwendeten Farben.
@@ -93,7 +163,10 @@ type Fonts struct {
 
 // PageNumbers steuert die Anzeige von Seitenzahlen.
 type PageNumbers struct {
-	StartPage int `yaml:"start_page"` // Ab welcher Seite die Zählung beginnt
+	StartPage   int    `yaml:"start_page"`                                                        // Ab welcher Seite die arabische Zählung beginnt (0 = nach dem Inhaltsverzeichnis)
+	Style       string `yaml:"style" validate:"oneof=arabic roman Roman alpha Alpha"`             // Zahlenstil des Hauptteils
+	FrontMatter string `yaml:"front_matter" validate:"oneof=arabic roman Roman alpha Alpha none"` // Zahlenstil von Inhaltsverzeichnis und Vorspann
+	Appendix    string `yaml:"appendix" validate:"oneof=letter continue"`                         // Anhänge mit Buchstaben (A-1) oder fortlaufend
 }
 
 // Layout definiert die räumliche Anordnung der Elemente.
@@ -104,6 +177,37 @@ type Layout struct {
 	HeaderNumbering bool    `yaml:"header_numbering"`                                     // Automatische Nummerierung von Überschriften
 	LineSpacing     float64 `yaml:"line_spacing" validate:"omitempty,gt=0"`               // Zeilenabstand (z.B. 1.5)
 	FooterStyle     string  `yaml:"footer_style" validate:"omitempty,oneof=fixed inline"` // "fixed" (unten) oder "inline" (nach Content)
+	Page            Page    `yaml:"page"`                                                 // Seitenformat und Ausrichtung
+	Duplex          bool    `yaml:"duplex"`                                               // Doppelseitiges Buchlayout (gespiegelte Ränder, Kapitel auf rechten Seiten)
+	Columns         Columns `yaml:"columns"`                                              // Standardwerte für mehrspaltige Abschnitte
+	Hyphenation     Hyphens `yaml:"hyphenation"`                                          // Silbentrennung für Fließtext
+	Breaks          Breaks  `yaml:"breaks"`                                               // Regeln für Seiten- und Spaltenumbrüche
+}
+
+// Breaks legt fest, wie viele Zeilen eines Absatzes bei einem Seitenumbruch mindestens zusammenbleiben.
+type Breaks struct {
+	Widows  int `yaml:"widows" validate:"gte=0"`  // Mindestzeilen am Anfang der neuen Seite (Hurenkinder, Standard: 2)
+	Orphans int `yaml:"orphans" validate:"gte=0"` // Mindestzeilen am Ende der alten Seite (Schusterjungen, Standard: 2)
+}
+
+// Hyphens konfiguriert die Silbentrennung nach TeX-Trennmustern.
+type Hyphens struct {
+	Enabled    bool     `yaml:"enabled"`                   // Silbentrennung aktivieren
+	Patterns   string   `yaml:"patterns"`                  // Eigene Musterdatei im TeX-Format (relativ zum Projekt), sonst eingebettete Muster der Dokumentsprache
+	Exceptions []string `yaml:"exceptions"`                // Wörter mit festen Trennstellen, z.B. "Ur-in-stinkt"
+	MinWord    int      `yaml:"min_word" validate:"gte=0"` // Mindestlänge getrennter Wörter (Standard: 5)
+}
+
+// Columns definiert die Standardwerte für mehrspaltige Abschnitte (<!-- columns 2 -->).
+type Columns struct {
+	Gap  float64 `yaml:"gap" validate:"gte=0"` // Spaltenabstand in mm
+	Rule bool    `yaml:"rule"`                 // Trennlinie zwischen den Spalten
+}
+
+// Page definiert Seitenformat und Ausrichtung des Dokuments.
+type Page struct {
+	Size        string `yaml:"size"`                                            // "A4", "A5", "A3", "Letter", "Legal" oder eigenes Format in mm ("170x240")
+	Orientation string `yaml:"orientation" validate:"oneof=portrait landscape"` // "portrait" (Hochformat) oder "landscape" (Querformat)
 }
 
 // Margins definiert die Seitenränder in Millimetern.
@@ -112,6 +216,20 @@ type Margins struct {
 	Right  float64 `yaml:"right"`
 	Top    float64 `yaml:"top"`
 	Bottom float64 `yaml:"bottom"`
+	Inner  float64 `yaml:"inner"` // Innenrand (Bundsteg) im Duplex-Modus (Standard: left)
+	Outer  float64 `yaml:"outer"` // Außenrand im Duplex-Modus (Standard: right)
+}
+
+// Horizontal liefert linken und rechten Rand einer Seite. Im Duplex-Modus liegt der
+// Innenrand auf rechten (ungeraden) Seiten links und auf linken (geraden) Seiten rechts.
+func (m Margins) Horizontal(duplex bool, page int) (float64, float64) {
+	if !duplex {
+		return m.Left, m.Right
+	}
+	if page%2 == 0 {
+		return m.Outer, m.Inner
+	}
+	return m.Inner, m.Outer
 }
 
 // Mermaid definiert Einstellungen für die Diagramm-Generierung.
@@ -119,13 +237,63 @@ type Mermaid struct {
 	Renderer string  `yaml:"renderer"` // Renderer-Typ ("mmdc" oder leer für Chrome-Fallback)
 	Width    float64 `yaml:"width"`    // Breite der Diagramme in mm (0 = automatisch)
 	Scale    float64 `yaml:"scale"`    // Skalierungsfaktor für Diagramme (z.B. 0.8 für 80%)
+	Script   string  `yaml:"script"`   // Projektlokale mermaid.min.js (überschreibt die eingebettete Version)
+	Theme    string  `yaml:"theme"`    // Mermaid-Theme ("auto" = aus den Dokumentfarben, sonst default, neutral, dark, forest, base)
+	Config   string  `yaml:"config"`   // Pfad zu einer mermaid-config.json (Standard: mermaid-config.json im Projekt, falls vorhanden)
+	Workers  int     `yaml:"workers"`  // Anzahl parallel gerenderter Diagramme (0 = automatisch)
+}
+
+// Diagrams definiert die externen Werkzeuge der Diagramm-Renderer neben Mermaid.
+type Diagrams struct {
+	Graphviz    string `yaml:"graphviz"`     // Pfad zum Graphviz-Befehl "dot" (leer = aus PATH)
+	PlantUMLJar string `yaml:"plantuml_jar"` // Pfad zur plantuml.jar (leer = Umgebungsvariable PLANTUML_JAR)
+	Java        string `yaml:"java"`         // Java-Befehl für PlantUML (leer = "java" aus PATH)
+	D2          string `yaml:"d2"`           // Pfad zum D2-Befehl (leer = aus PATH)
+}
+
+// Images definiert die Optimierung von Rasterbildern vor dem Einbetten in das PDF.
+type Images struct {
+	MaxDPI      float64 `yaml:"max_dpi" validate:"gte=0"`              // Maximale Auflösung bezogen auf die platzierte Breite (0 = aus)
+	JPEGQuality int     `yaml:"jpeg_quality" validate:"gte=0,lte=100"` // JPEG-Qualität für deckende Bilder (0 = keine Neukodierung)
+}
+
+// Tables definiert Einstellungen für Tabellen. Style bis Header sind die Vorgaben, die einzelne
+// Tabellen mit <!-- table ... --> überschreiben.
+type Tables struct {
+	Continued  bool    `yaml:"continued"`                                                 // Hinweis "(Fortsetzung)" über Tabellen, die auf einer neuen Seite weiterlaufen
+	Style      string  `yaml:"style" validate:"omitempty,oneof=default compact bordered"` // Darstellung: default, compact (wenig Innenabstand) oder bordered (kräftige Rahmen)
+	Zebra      *bool   `yaml:"zebra"`                                                     // Zebra-Streifen in Datenzeilen (Standard: true)
+	Stretch    *bool   `yaml:"stretch"`                                                   // Tabellen auf die volle Textbreite strecken (Standard: true)
+	MaxStretch float64 `yaml:"max_stretch" validate:"gte=0"`                              // Höchstens so stark werden schmale Tabellen gestreckt (Standard: 1.5)
+	FontSize   float64 `yaml:"font_size" validate:"gte=0"`                                // Schriftgröße in pt (Standard: font_size des Dokuments)
+	Padding    float64 `yaml:"padding" validate:"gte=0"`                                  // Innenabstand der Zellen in mm (Standard: 4, compact: 1.5)
+	Header     string  `yaml:"header"`                                                    // Hintergrund der Kopfzeilen (Standard: colors.accent)
+}
+
+// ZebraEnabled prüft, ob Datenzeilen abwechselnd eingefärbt werden.
+func (t Tables) ZebraEnabled() bool {
+	return t.Zebra == nil || *t.Zebra
+}
+
+// StretchEnabled prüft, ob Tabellen auf die volle Textbreite gestreckt werden.
+func (t Tables) StretchEnabled() bool {
+	return t.Stretch == nil || *t.Stretch
 }
 
 // Code definiert Einstellungen für Code-Blöcke.
 type Code struct {
-	FontSize    float64 `yaml:"font_size"`     // Standard-Schriftgröße für Code (0 = nutzt globale FontSize)
-	MinFontSize float64 `yaml:"min_font_size"` // Minimale Schriftgröße bei AutoScale (Standard: 6)
-	AutoScale   bool    `yaml:"auto_scale"`    // Automatische Schriftgrößenanpassung für große Code-Blöcke
-	MaxLines    int     `yaml:"max_lines"`     // Ab dieser Zeilenanzahl wird skaliert (Standard: 30)
-	MaxLineLen  int     `yaml:"max_line_len"`  // Ab dieser Zeilenlänge wird skaliert (Standard: 80)
+	FontSize    float64  `yaml:"font_size"`     // Standard-Schriftgröße für Code (0 = nutzt globale FontSize)
+	MinFontSize float64  `yaml:"min_font_size"` // Minimale Schriftgröße bei AutoScale (Standard: 6)
+	AutoScale   bool     `yaml:"auto_scale"`    // Automatische Schriftgrößenanpassung für große Code-Blöcke
+	MaxLines    int      `yaml:"max_lines"`     // Ab dieser Zeilenanzahl wird skaliert (Standard: 30)
+	MaxLineLen  int      `yaml:"max_line_len"`  // Ab dieser Zeilenlänge wird skaliert (Standard: 80)
+	Terminal    Terminal `yaml:"terminal"`      // Darstellung von ansi/console-Blöcken
+}
+
+// Terminal definiert das Aussehen von Terminal-Ausgaben (```ansi / ```console).
+type Terminal struct {
+	Background  string `yaml:"background"`   // Hintergrundfarbe des Terminal-Containers
+	Foreground  string `yaml:"foreground"`   // Standard-Textfarbe ohne ANSI-Farbe
+	Prompt      string `yaml:"prompt"`       // Prompt-Präfix, das hervorgehoben wird (z.B. "$ ", leer = aus)
+	PromptColor string `yaml:"prompt_color"` // Farbe des hervorgehobenen Prompts
 }
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pageSizes enthält die unterstützten Seitenformate in mm (Hochformat).
+var pageSizes = map[string][2]float64{
+	"a3":     {297, 420},
+	"a4":     {210, 297},
+	"a5":     {148, 210},
+	"letter": {215.9, 279.4},
+	"legal":  {215.9, 355.6},
+}
+
+// Dimensions liefert Breite und Höhe des Seitenformats in mm im Hochformat.
+// Eigene Formate werden als "BreitexHöhe" angegeben (z.B. "170x240").
+func (p Page) Dimensions() (float64, float64, error) {
+	size := strings.ToLower(strings.TrimSpace(p.Size))
+	if size == "" {
+		size = "a4"
+	}
+	if dims, ok := pageSizes[size]; ok {
+		return dims[0], dims[1], nil
+	}
+
+	parts := strings.Split(strings.TrimSuffix(size, "mm"), "x")
+	if len(parts) == 2 {
+		w, errW := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		h, errH := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errW == nil && errH == nil && w >= 50 && h >= 50 {
+			// Eigene Formate immer im Hochformat ablegen, die Ausrichtung bestimmt orientation
+			if w > h {
+				w, h = h, w
+			}
+			return w, h, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("ungültiges Seitenformat %q (erlaubt: A3, A4, A5, Letter, Legal oder BreitexHöhe in mm, mindestens 50x50)", p.Size)
+}
+
+// Landscape prüft, ob das Dokument im Querformat gesetzt wird.
+func (p Page) Landscape() bool {
+	return p.Orientation == "landscape"
+}
@@ -5,16 +5,21 @@ import (
 	"godocgen/internal/blocks"
 	"godocgen/internal/config"
 	"godocgen/internal/engine/code"
+	"godocgen/internal/engine/datatable"
 	"godocgen/internal/engine/fonts"
+	"godocgen/internal/engine/images"
 	"godocgen/internal/engine/markdown"
 	"godocgen/internal/engine/mermaid"
 	"godocgen/internal/engine/pdf"
+	"godocgen/internal/i18n"
+	"godocgen/internal/util"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 )
 
 type numberedFile struct {
@@ -91,48 +96,28 @@ func (b *Builder) Build() (string, error) {
 		if err != nil {
 			return "", err
 		}
+		b.prepareImages(cfg, blks, nf.path)
+		b.loadDataTables(cfg, blks, nf.path)
+		markHeadingSource(blks, contentDir, nf.path)
 		allBlocks = append(allBlocks, blks...)
 	}
 
 	// 4. Blöcke vorverarbeiten (Mermaid & Code-Highlighting)
-	for i, block := range allBlocks {
-		switch blk := block.(type) {
-		case blocks.MermaidBlock:
-			svgPath, pngPath, err := mermaid.Render(blk.Content, b.CacheDir)
-			if err != nil {
-				fmt.Printf("Warnung: Mermaid-Diagramm konnte nicht gerendert werden: %v\n", err)
-				allBlocks[i] = blocks.ParagraphBlock{
-					Content: []blocks.TextSegment{
-						{Text: "[Diagramm konnte nicht gerendert werden - mmdc fehlt]", Italic: true},
-					},
-				}
-			} else {
-				// Mermaid-Konfiguration für Größe anwenden
-				allBlocks[i] = blocks.ImageBlock{
-					Path:  pngPath,
-					Alt:   "Mermaid Diagram (SVG Quelle: " + svgPath + ")",
-					Title: blk.Title,
-					Width: cfg.Mermaid.Width, // Konfigurierbare Breite
-					Scale: cfg.Mermaid.Scale, // Konfigurierbare Skalierung
-				}
-			}
-		case blocks.CodeBlock:
-			segments, bg, err := code.GetSegments(blk.Content, blk.Language, cfg.CodeTheme)
-			if err != nil {
-				return "", err
-			}
-			blk.Segments = segments
-			blk.BgColor = bg
-			allBlocks[i] = blk
-		case blocks.ImageBlock:
-			// Relative Pfade auflösen
-			if !filepath.IsAbs(blk.Path) {
-				blk.Path = filepath.Join(b.ProjectDir, "assets", blk.Path)
-				allBlocks[i] = blk
-			}
-		}
+	mermaidOpts, err := b.mermaidOptions(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	if err := b.highlightCode(cfg, allBlocks); err != nil {
+		return "", err
 	}
 
+	// Diagramme parallel rendern (eine Chrome-Instanz pro Build)
+	b.renderDiagrams(cfg, allBlocks, mermaidOpts)
+
+	// Rasterbilder auf die Ziel-DPI verkleinern
+	b.optimizeImages(cfg, allBlocks)
+
 	// 5. PDF mit Versionierung generieren
 	baseName := cfg.Title
 	if baseName == "" {
@@ -156,9 +141,250 @@ func (b *Builder) Build() (string, error) {
 	}
 
 	gen := pdf.NewGenerator(cfg, allBlocks, fontDir)
+	gen.SetBuildInfo(pdf.BuildInfo{
+		Time:       time.Now(),
+		Version:    version,
+		Git:        util.ReadGitInfo(b.ProjectDir),
+		ProjectDir: b.ProjectDir,
+	})
 	return outputPath, gen.Generate(outputPath)
 }
 
+// mermaidOptions stellt die Mermaid-Optionen (Bibliothek und Theme-Konfiguration) für das Projekt zusammen.
+func (b *Builder) mermaidOptions(cfg *config.Config) (mermaid.Options, error) {
+	opts := mermaid.Options{}
+	if cfg.Mermaid.Script != "" {
+		opts.ScriptPath = b.resolveProjectPath(cfg.Mermaid.Script)
+	}
+
+	configPath := ""
+	if cfg.Mermaid.Config != "" {
+		configPath = b.resolveProjectPath(cfg.Mermaid.Config)
+	} else if _, err := os.Stat(filepath.Join(b.ProjectDir, "mermaid-config.json")); err == nil {
+		configPath = filepath.Join(b.ProjectDir, "mermaid-config.json")
+	}
+
+	mermaidCfg, err := mermaid.BuildConfig(cfg.Colors, cfg.Mermaid.Theme, configPath)
+	if err != nil {
+		return opts, err
+	}
+	opts.Config = mermaidCfg
+	return opts, nil
+}
+
+// highlightCode versieht Code-Blöcke mit farbigen Segmenten, auch in Tabellenzellen.
+// Terminal-Ausgaben werden stattdessen nach ANSI-Sequenzen eingefärbt.
+func (b *Builder) highlightCode(cfg *config.Config, blks []blocks.DocBlock) error {
+	for i, block := range blks {
+		switch blk := block.(type) {
+		case blocks.CodeBlock:
+			if code.IsTerminalLanguage(blk.Language) {
+				// Terminal-Ausgabe: ANSI-Sequenzen statt Syntax-Highlighting auswerten
+				blk.Segments = code.ParseANSI(blk.Content)
+				blk.BgColor = cfg.Code.Terminal.Background
+				blks[i] = blk
+				continue
+			}
+			segments, bg, err := code.GetSegments(blk.Content, blk.Language, cfg.CodeTheme)
+			if err != nil {
+				return err
+			}
+			blk.Segments = segments
+			blk.BgColor = bg
+			blks[i] = blk
+		case blocks.TableBlock:
+			// Code in Zellen von Grid-Tabellen
+			for _, row := range blk.Rows {
+				for _, cell := range row {
+					if err := b.highlightCode(cfg, cell.Blocks); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// markHeadingSource hinterlegt an den Überschriften die Quelldatei für Kolumnentitel.
+func markHeadingSource(blks []blocks.DocBlock, contentDir, source string) {
+	rel, err := filepath.Rel(contentDir, source)
+	if err != nil {
+		rel = filepath.Base(source)
+	}
+	for i, block := range blks {
+		if h, ok := block.(blocks.HeadingBlock); ok {
+			h.File = filepath.ToSlash(rel)
+			blks[i] = h
+		}
+	}
+}
+
+// prepareImages löst die Bildpfade einer Markdown-Datei auf und wandelt Bilder, die gofpdf
+// nicht direkt einbetten kann (WebP, BMP, TIFF, 16-Bit-PNG, CMYK-JPEG), in den Cache um.
+// Nicht lesbare Bilder werden durch einen Hinweis ersetzt, die Warnung nennt die Markdown-Datei.
+func (b *Builder) prepareImages(cfg *config.Config, blks []blocks.DocBlock, source string) {
+	for i, block := range blks {
+		if t, ok := block.(blocks.TableBlock); ok {
+			// Bilder in Zellen von Grid-Tabellen
+			for _, row := range t.Rows {
+				for _, cell := range row {
+					b.prepareImages(cfg, cell.Blocks, source)
+				}
+			}
+			continue
+		}
+		img, ok := block.(blocks.ImageBlock)
+		if !ok {
+			continue
+		}
+		// Relative Pfade auflösen
+		if !filepath.IsAbs(img.Path) {
+			img.Path = filepath.Join(b.ProjectDir, "assets", img.Path)
+		}
+
+		path, err := images.Normalize(img.Path, b.CacheDir)
+		if err != nil {
+			rel, relErr := filepath.Rel(b.ProjectDir, source)
+			if relErr != nil {
+				rel = source
+			}
+			fmt.Printf("Warnung: %s: Bild %s kann nicht gelesen werden: %v\n", rel, filepath.Base(img.Path), err)
+			blks[i] = blocks.ParagraphBlock{
+				Content: []blocks.TextSegment{
+					{Text: i18n.New(cfg.Language, cfg.Messages).T(i18n.ImageUnreadable, filepath.Base(img.Path)), Italic: true},
+				},
+			}
+			continue
+		}
+		img.Path = path
+		blks[i] = img
+	}
+}
+
+// loadDataTables ersetzt die Datentabellen einer Markdown-Datei durch Tabellen aus ihren
+// Datendateien (relativ zum Projektverzeichnis). Die Dateien werden bei jedem Build neu gelesen,
+// Änderungen an den Daten erscheinen also ohne Anpassung des Markdowns im nächsten PDF.
+// Nicht lesbare Dateien werden wie Bilder durch einen Hinweis ersetzt.
+func (b *Builder) loadDataTables(cfg *config.Config, blks []blocks.DocBlock, source string) {
+	cat := i18n.New(cfg.Language, cfg.Messages)
+	for i, block := range blks {
+		dt, ok := block.(blocks.DataTableBlock)
+		if !ok {
+			continue
+		}
+		if dt.Error != "" {
+			blks[i] = blocks.ParagraphBlock{
+				Content: []blocks.TextSegment{{Text: cat.T(i18n.TableInvalid, dt.Error), Italic: true}},
+			}
+			continue
+		}
+		table, err := datatable.Build(dt, b.resolveProjectPath(dt.Src), cat)
+		if err != nil {
+			rel, relErr := filepath.Rel(b.ProjectDir, source)
+			if relErr != nil {
+				rel = source
+			}
+			fmt.Printf("Warnung: %s: Tabelle %s kann nicht gelesen werden: %v\n", rel, dt.Src, err)
+			blks[i] = blocks.ParagraphBlock{
+				Content: []blocks.TextSegment{{Text: cat.T(i18n.TableUnreadable, dt.Src), Italic: true}},
+			}
+			continue
+		}
+		blks[i] = table
+	}
+}
+
+// optimizeImages verkleinert Rasterbilder auf die Auflösung, die sich aus ihrer platzierten
+// Breite und images.max_dpi ergibt, und kodiert sie optional als JPEG neu (images.jpeg_quality).
+// Wird ein Bild mehrfach verwendet, zählt die größte Breite. Am Ende wird die Ersparnis ausgegeben.
+func (b *Builder) optimizeImages(cfg *config.Config, allBlocks []blocks.DocBlock) {
+	opts := images.OptimizeOptions{MaxDPI: cfg.Images.MaxDPI, JPEGQuality: cfg.Images.JPEGQuality}
+	if !opts.Enabled() {
+		return
+	}
+
+	// Größte platzierte Breite je Bild ermitteln
+	widths := map[string]float64{}
+	var order []string
+	landscape := cfg.Layout.Page.Landscape()
+	for _, block := range allBlocks {
+		if o, ok := block.(blocks.OrientationBlock); ok {
+			landscape = o.Orientation == "landscape"
+			continue
+		}
+		img, ok := block.(blocks.ImageBlock)
+		if !ok || strings.EqualFold(filepath.Ext(img.Path), ".svg") {
+			continue
+		}
+		srcW, srcH, err := images.Dimensions(img.Path)
+		if err != nil {
+			continue
+		}
+		w, _ := pdf.PlacedImageSize(cfg, img, float64(srcW), float64(srcH), landscape)
+		if _, seen := widths[img.Path]; !seen {
+			order = append(order, img.Path)
+		}
+		if w > widths[img.Path] {
+			widths[img.Path] = w
+		}
+	}
+
+	optimized := map[string]string{}
+	var results []images.OptimizeResult
+	for _, path := range order {
+		res, err := images.Optimize(path, b.CacheDir, widths[path], opts)
+		if err != nil {
+			fmt.Printf("Warnung: Bild %s konnte nicht optimiert werden: %v\n", filepath.Base(path), err)
+			continue
+		}
+		if res.Changed() {
+			optimized[path] = res.Path
+			results = append(results, res)
+		}
+	}
+	if len(results) == 0 {
+		return
+	}
+
+	for i, block := range allBlocks {
+		if img, ok := block.(blocks.ImageBlock); ok {
+			if path, ok := optimized[img.Path]; ok {
+				img.Path = path
+				allBlocks[i] = img
+			}
+		}
+	}
+
+	// Build-Bericht
+	var before, after int64
+	fmt.Println("Bildoptimierung:")
+	for _, r := range results {
+		before += r.OrigBytes
+		after += r.NewBytes
+		fmt.Printf("  %s: %dx%d → %dx%d px, %s → %s (-%d%%)\n", filepath.Base(r.Source),
+			r.OrigW, r.OrigH, r.NewW, r.NewH,
+			images.FormatBytes(r.OrigBytes), images.FormatBytes(r.NewBytes), savedPercent(r.OrigBytes, r.NewBytes))
+	}
+	fmt.Printf("  Gesamt: %s → %s (-%d%%)\n", images.FormatBytes(before), images.FormatBytes(after), savedPercent(before, after))
+}
+
+// savedPercent liefert die Ersparnis in Prozent.
+func savedPercent(before, after int64) int64 {
+	if before <= 0 {
+		return 0
+	}
+	return (before - after) * 100 / before
+}
+
+// resolveProjectPath löst einen Pfad relativ zum Projektverzeichnis auf.
+func (b *Builder) resolveProjectPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(b.ProjectDir, path)
+}
+
 // scanAndSortContent durchläuft das Verzeichnis, extrahiert Header-Nummern und sortiert danach.
 func (b *Builder) scanAndSortContent(dir string) ([]numberedFile, error) {
 	var files []numberedFile
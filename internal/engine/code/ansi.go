@@ -0,0 +1,234 @@
+package code
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ansiPalette enthält die 16 Standardfarben eines Terminals (VS Code Dark Palette).
+var ansiPalette = [16]string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// textualEscapes sind ausgeschriebene Escape-Sequenzen, die in Markdown-Dateien häufig
+// anstelle des echten ESC-Bytes verwendet werden.
+var textualEscapes = strings.NewReplacer(
+	`\x1b[`, "\x1b[",
+	`\x1B[`, "\x1b[",
+	`\u001b[`, "\x1b[",
+	`\u001B[`, "\x1b[",
+	`\033[`, "\x1b[",
+	`\e[`, "\x1b[",
+)
+
+// IsTerminalLanguage prüft, ob ein Code-Block als Terminal-Ausgabe (ANSI) gerendert werden soll.
+func IsTerminalLanguage(lang string) bool {
+	switch strings.ToLower(lang) {
+	case "ansi", "console", "terminal":
+		return true
+	}
+	return false
+}
+
+// ansiState hält die aktuell aktiven SGR-Attribute während des Parsens.
+type ansiState struct {
+	fg        string
+	bg        string
+	bold      bool
+	underline bool
+	reverse   bool
+}
+
+// segment erzeugt ein Segment mit den aktuellen Attributen.
+func (s ansiState) segment(text string) Segment {
+	fg, bg := s.fg, s.bg
+	if s.reverse {
+		fg, bg = bg, fg
+	}
+	return Segment{
+		Text:       text,
+		Color:      fg,
+		Background: bg,
+		Bold:       s.bold,
+		Underline:  s.underline,
+	}
+}
+
+// ParseANSI zerlegt Terminal-Ausgabe mit ANSI-Escape-Sequenzen in formatierte Segmente.
+// Unterstützt werden 16, 256 und Truecolor-Farben für Vorder- und Hintergrund sowie Fett
+// und Unterstrichen. Andere Steuersequenzen (Cursor, Löschen, OSC) werden entfernt.
+func ParseANSI(content string) []Segment {
+	content = textualEscapes.Replace(content)
+
+	var segments []Segment
+	var state ansiState
+	var current strings.Builder
+
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, state.segment(current.String()))
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c != 0x1b {
+			if c == '\r' {
+				continue
+			}
+			current.WriteByte(c)
+			continue
+		}
+
+		if i+1 >= len(content) {
+			break
+		}
+
+		switch content[i+1] {
+		case '[':
+			// CSI: Parameter bis zum finalen Byte (0x40-0x7E) sammeln
+			j := i + 2
+			for j < len(content) && (content[j] < 0x40 || content[j] > 0x7e) {
+				j++
+			}
+			if j >= len(content) {
+				i = len(content)
+				break
+			}
+			if content[j] == 'm' {
+				flush()
+				state.apply(content[i+2 : j])
+			}
+			i = j
+		case ']':
+			// OSC: bis BEL oder ST (ESC \) überspringen
+			j := i + 2
+			for j < len(content) {
+				if content[j] == 0x07 {
+					break
+				}
+				if content[j] == 0x1b && j+1 < len(content) && content[j+1] == '\\' {
+					j++
+					break
+				}
+				j++
+			}
+			i = j
+		default:
+			// Zwei-Byte-Sequenz ignorieren
+			i++
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// apply wendet eine SGR-Parameterliste (z.B. "1;38;5;208") auf den Zustand an.
+func (s *ansiState) apply(params string) {
+	if params == "" {
+		*s = ansiState{}
+		return
+	}
+
+	parts := strings.Split(params, ";")
+	codes := make([]int, len(parts))
+	for i, p := range parts {
+		codes[i], _ = strconv.Atoi(p)
+	}
+
+	for i := 0; i < len(codes); i++ {
+		n := codes[i]
+		switch {
+		case n == 0:
+			*s = ansiState{}
+		case n == 1:
+			s.bold = true
+		case n == 4:
+			s.underline = true
+		case n == 7:
+			s.reverse = true
+		case n == 22:
+			s.bold = false
+		case n == 24:
+			s.underline = false
+		case n == 27:
+			s.reverse = false
+		case n >= 30 && n <= 37:
+			s.fg = ansiPalette[n-30]
+		case n >= 90 && n <= 97:
+			s.fg = ansiPalette[n-90+8]
+		case n == 39:
+			s.fg = ""
+		case n >= 40 && n <= 47:
+			s.bg = ansiPalette[n-40]
+		case n >= 100 && n <= 107:
+			s.bg = ansiPalette[n-100+8]
+		case n == 49:
+			s.bg = ""
+		case n == 38 || n == 48:
+			color, consumed := extendedColor(codes[i+1:])
+			if color != "" {
+				if n == 38 {
+					s.fg = color
+				} else {
+					s.bg = color
+				}
+			}
+			i += consumed
+		}
+	}
+}
+
+// extendedColor wertet die Parameter nach 38/48 aus (5;n für 256 Farben, 2;r;g;b für Truecolor).
+// Gibt die Farbe als Hex-Code und die Anzahl der verbrauchten Parameter zurück.
+func extendedColor(args []int) (string, int) {
+	if len(args) == 0 {
+		return "", 0
+	}
+	switch args[0] {
+	case 5:
+		if len(args) < 2 {
+			return "", len(args)
+		}
+		return xterm256(args[1]), 2
+	case 2:
+		if len(args) < 4 {
+			return "", len(args)
+		}
+		return fmt.Sprintf("#%02x%02x%02x", clampByte(args[1]), clampByte(args[2]), clampByte(args[3])), 4
+	}
+	return "", 1
+}
+
+// xterm256 berechnet den Hex-Code einer Farbe aus der xterm-256-Palette.
+func xterm256(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		// 6x6x6 Farbwürfel
+		n -= 16
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[(n/6)%6], levels[n%6])
+	default:
+		// Graustufen
+		v := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", v, v, v)
+	}
+}
+
+func clampByte(v int) int {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return v
+}
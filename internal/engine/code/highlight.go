@@ -152,9 +152,11 @@ func loadCustomTheme(path string) (*chroma.Style, error) {
 
 // Segment repräsentiert einen Teil des Codes mit spezifischer Formatierung.
 type Segment struct {
-	Text  string // Der Textinhalt
-	Color string // Hex-Farbcode
-	Bold  bool   // Fettgedruckt
+	Text       string // Der Textinhalt
+	Color      string // Hex-Farbcode
+	Background string // Optionale Hintergrundfarbe (Hex-Code, z.B. bei ANSI-Ausgabe)
+	Bold       bool   // Fettgedruckt
+	Underline  bool   // Unterstrichen
 }
 
 // GetSegments zerlegt den Code in farbige Segmente basierend auf der Sprache und dem gewählten Theme.
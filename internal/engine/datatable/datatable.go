@@ -0,0 +1,418 @@
+// Package datatable liest Tabellen aus CSV-, TSV- und JSON-Dateien und bereitet sie als
+// TableBlock auf: Zeilen filtern und sortieren, Spalten auswählen, Zahlen formatieren und
+// Überschriften umbenennen.
+package datatable
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/i18n"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// table ist der Inhalt einer Datendatei: Spaltennamen und Zeilen mit je einem Wert pro Spalte.
+type table struct {
+	columns []string
+	rows    [][]string
+}
+
+// Build liest die Datendatei unter path und wandelt sie nach den Angaben des Blocks in einen
+// TableBlock um. Die Reihenfolge ist Filtern, Sortieren, Spaltenauswahl, Formatieren, Umbenennen.
+func Build(blk blocks.DataTableBlock, path string, cat *i18n.Catalog) (blocks.TableBlock, error) {
+	t, err := read(path)
+	if err != nil {
+		return blocks.TableBlock{}, err
+	}
+	if err := t.filter(blk.Filter); err != nil {
+		return blocks.TableBlock{}, err
+	}
+	if err := t.sort(blk.Sort); err != nil {
+		return blocks.TableBlock{}, err
+	}
+
+	columns := blk.Columns
+	if len(columns) == 0 {
+		columns = t.columns
+	}
+	indices := make([]int, len(columns))
+	for i, name := range columns {
+		if indices[i], err = t.index(name); err != nil {
+			return blocks.TableBlock{}, err
+		}
+	}
+	for name := range blk.Headers {
+		if _, err := t.index(name); err != nil {
+			return blocks.TableBlock{}, fmt.Errorf("headers: %w", err)
+		}
+	}
+
+	formats := make([]*numberFormat, len(columns))
+	for name, pattern := range blk.Format {
+		col, err := t.index(name)
+		if err != nil {
+			return blocks.TableBlock{}, fmt.Errorf("format: %w", err)
+		}
+		f, err := parseFormat(pattern)
+		if err != nil {
+			return blocks.TableBlock{}, fmt.Errorf("format %s: %w", name, err)
+		}
+		for i, idx := range indices {
+			if idx == col {
+				formats[i] = f
+			}
+		}
+	}
+
+	result := blocks.TableBlock{Alignments: make([]blocks.Align, len(columns)), Options: blk.Options}
+	header := make([]blocks.TableRow, len(columns))
+	for i, name := range columns {
+		if renamed, ok := blk.Headers[name]; ok {
+			name = renamed
+		}
+		header[i] = cell(name, true)
+		if formats[i] != nil || t.numeric(indices[i]) {
+			result.Alignments[i] = blocks.AlignRight
+		}
+	}
+	result.Rows = append(result.Rows, header)
+
+	for _, row := range t.rows {
+		cells := make([]blocks.TableRow, len(columns))
+		for i, idx := range indices {
+			value := row[idx]
+			if formats[i] != nil {
+				value = formats[i].format(value, cat)
+			}
+			cells[i] = cell(value, false)
+		}
+		result.Rows = append(result.Rows, cells)
+	}
+	return result, nil
+}
+
+// cell erzeugt eine Tabellenzelle mit einfachem Text.
+func cell(text string, header bool) blocks.TableRow {
+	return blocks.TableRow{Content: []blocks.TextSegment{{Text: text}}, Header: header}
+}
+
+// read liest eine Datendatei. Das Format ergibt sich aus der Endung: .json, .tsv/.tab oder CSV,
+// dessen Trennzeichen (Komma, Semikolon, Tabulator) aus der Kopfzeile erkannt wird.
+func read(path string) (*table, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	var t *table
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		t, err = readJSON(data)
+	case ".tsv", ".tab":
+		t, err = readCSV(data, '\t')
+	default:
+		firstLine, _, _ := strings.Cut(string(data), "\n")
+		comma := ','
+		switch {
+		case strings.Contains(firstLine, "\t"):
+			comma = '\t'
+		case strings.Contains(firstLine, ";"):
+			comma = ';'
+		}
+		t, err = readCSV(data, comma)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(t.columns) == 0 {
+		return nil, fmt.Errorf("Datei enthält keine Spalten")
+	}
+	return t, nil
+}
+
+// readCSV liest CSV-Daten mit Kopfzeile. Fehlende Werte am Zeilenende bleiben leer.
+func readCSV(data []byte, comma rune) (*table, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("ungültige CSV-Datei: %w", err)
+	}
+	if len(records) == 0 {
+		return &table{}, nil
+	}
+
+	t := &table{}
+	for _, name := range records[0] {
+		t.columns = append(t.columns, strings.TrimSpace(name))
+	}
+	for _, record := range records[1:] {
+		row := make([]string, len(t.columns))
+		for i := range row {
+			if i < len(record) {
+				row[i] = strings.TrimSpace(record[i])
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// readJSON liest ein Array von Objekten (Spalten in der Reihenfolge ihres ersten Auftretens)
+// oder ein Array von Arrays, dessen erstes Element die Kopfzeile ist.
+func readJSON(data []byte) (*table, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("JSON-Datei muss ein Array enthalten")
+	}
+
+	t := &table{}
+	index := map[string]int{}
+	var records []map[int]string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("ungültige JSON-Datei: %w", err)
+		}
+		record := map[int]string{}
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, fmt.Errorf("ungültige JSON-Datei: %w", err)
+				}
+				value, err := jsonValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				name := key.(string)
+				col, ok := index[name]
+				if !ok {
+					col = len(t.columns)
+					index[name] = col
+					t.columns = append(t.columns, name)
+				}
+				record[col] = value
+			}
+		case json.Delim('['):
+			for col := 0; dec.More(); col++ {
+				value, err := jsonValue(dec)
+				if err != nil {
+					return nil, err
+				}
+				record[col] = value
+			}
+			if t.columns == nil {
+				for col := 0; col < len(record); col++ {
+					t.columns = append(t.columns, record[col])
+				}
+				if _, err := dec.Token(); err != nil {
+					return nil, fmt.Errorf("ungültige JSON-Datei: %w", err)
+				}
+				continue
+			}
+		default:
+			return nil, fmt.Errorf("JSON-Zeilen müssen Objekte oder Arrays sein")
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, fmt.Errorf("ungültige JSON-Datei: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	for _, record := range records {
+		row := make([]string, len(t.columns))
+		for col, value := range record {
+			if col < len(row) {
+				row[col] = value
+			}
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+// jsonValue liest den nächsten JSON-Wert als Text. Verschachtelte Werte bleiben JSON.
+func jsonValue(dec *json.Decoder) (string, error) {
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return "", fmt.Errorf("ungültige JSON-Datei: %w", err)
+	}
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		nested, err := json.Marshal(v)
+		return string(nested), err
+	}
+}
+
+// index liefert die Position einer Spalte.
+func (t *table) index(name string) (int, error) {
+	for i, col := range t.columns {
+		if col == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unbekannte Spalte %q (vorhanden: %s)", name, strings.Join(t.columns, ", "))
+}
+
+// numeric prüft, ob eine Spalte nur Zahlen (oder leere Werte) enthält.
+func (t *table) numeric(col int) bool {
+	found := false
+	for _, row := range t.rows {
+		if row[col] == "" {
+			continue
+		}
+		if _, ok := parseNumber(row[col]); !ok {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// filter behält nur die Zeilen, auf die alle Bedingungen zutreffen.
+func (t *table) filter(conditions []string) error {
+	for _, condition := range conditions {
+		name, op, want, err := parseCondition(condition)
+		if err != nil {
+			return err
+		}
+		col, err := t.index(name)
+		if err != nil {
+			return fmt.Errorf("filter: %w", err)
+		}
+		kept := t.rows[:0]
+		for _, row := range t.rows {
+			if matches(row[col], op, want) {
+				kept = append(kept, row)
+			}
+		}
+		t.rows = kept
+	}
+	return nil
+}
+
+// operators sind die Vergleiche in Filterbedingungen, zweistellige vor einstelligen.
+var operators = []string{">=", "<=", "!=", "==", "=", ">", "<", "~"}
+
+// parseCondition zerlegt eine Bedingung wie "since>=2" in Spalte, Operator und Wert.
+func parseCondition(condition string) (name, op, value string, err error) {
+	pos := -1
+	for _, candidate := range operators {
+		if i := strings.Index(condition, candidate); i > 0 && (pos < 0 || i < pos) {
+			pos, op = i, candidate
+		}
+	}
+	if pos < 0 {
+		return "", "", "", fmt.Errorf("filter: %q enthält keinen Vergleich (=, !=, <, <=, >, >=, ~)", condition)
+	}
+	name = strings.TrimSpace(condition[:pos])
+	value = strings.Trim(strings.TrimSpace(condition[pos+len(op):]), `"'`)
+	return name, op, value, nil
+}
+
+// matches wertet einen Vergleich aus. Sind beide Seiten Zahlen, wird numerisch verglichen,
+// ~ prüft, ob der Wert den Text ohne Beachtung der Groß-/Kleinschreibung enthält.
+func matches(value, op, want string) bool {
+	if op == "~" {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(want))
+	}
+	c := compare(value, want)
+	switch op {
+	case "=", "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// sort sortiert die Zeilen stabil nach den Spalten. Ein "-" vor dem Namen sortiert absteigend.
+func (t *table) sort(keys []string) error {
+	type key struct {
+		col  int
+		desc bool
+	}
+	var order []key
+	for _, name := range keys {
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "-"), "+")
+		col, err := t.index(strings.TrimSpace(name))
+		if err != nil {
+			return fmt.Errorf("sort: %w", err)
+		}
+		order = append(order, key{col: col, desc: desc})
+	}
+	if len(order) == 0 {
+		return nil
+	}
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		for _, k := range order {
+			c := compare(t.rows[i][k.col], t.rows[j][k.col])
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return nil
+}
+
+// compare vergleicht zwei Werte numerisch, wenn beide Zahlen sind, sonst als Text ohne
+// Beachtung der Groß-/Kleinschreibung.
+func compare(a, b string) int {
+	x, okA := parseNumber(a)
+	y, okB := parseNumber(b)
+	if okA && okB {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// parseNumber liest eine Zahl. Wie bei Charts ist ein Komma als Dezimaltrennzeichen erlaubt.
+func parseNumber(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	// "Inf" und "NaN" sind Text, keine Zahlen
+	return v, err == nil && !math.IsInf(v, 0) && !math.IsNaN(v)
+}
@@ -0,0 +1,55 @@
+package datatable
+
+import (
+	"fmt"
+	"godocgen/internal/i18n"
+	"strings"
+)
+
+// numberFormat ist ein Zahlenformat wie "#,##0.00 €" oder "0.0%".
+type numberFormat struct {
+	prefix   string // Text vor der Zahl
+	suffix   string // Text nach der Zahl
+	decimals int    // Nachkommastellen
+	grouping bool   // Tausendertrennzeichen
+	percent  bool   // Wert mit 100 multiplizieren
+}
+
+// parseFormat liest ein Zahlenformat. Das Muster aus 0, # und Trennzeichen bestimmt die
+// Nachkommastellen (Ziffern nach dem Punkt) und ob Tausender gruppiert werden (Komma).
+// Komma und Punkt stehen immer für Tausender- und Dezimaltrennzeichen, ausgegeben werden
+// die der Dokumentsprache. Text davor und danach bleibt erhalten, % rechnet in Prozent um.
+func parseFormat(pattern string) (*numberFormat, error) {
+	start := strings.IndexAny(pattern, "0#")
+	if start < 0 {
+		return nil, fmt.Errorf("%q enthält keine Ziffernplätze (0 oder #)", pattern)
+	}
+	end := start
+	for end < len(pattern) && strings.IndexByte("0#,.", pattern[end]) >= 0 {
+		end++
+	}
+
+	digits := pattern[start:end]
+	f := &numberFormat{
+		prefix:   pattern[:start],
+		suffix:   pattern[end:],
+		grouping: strings.Contains(digits, ","),
+	}
+	if _, frac, ok := strings.Cut(digits, "."); ok {
+		f.decimals = strings.Count(frac, "0") + strings.Count(frac, "#")
+	}
+	f.percent = strings.Contains(f.prefix+f.suffix, "%")
+	return f, nil
+}
+
+// format formatiert einen Wert. Werte, die keine Zahlen sind, bleiben unverändert.
+func (f *numberFormat) format(value string, cat *i18n.Catalog) string {
+	v, ok := parseNumber(value)
+	if !ok {
+		return value
+	}
+	if f.percent {
+		v *= 100
+	}
+	return f.prefix + cat.FormatNumber(v, f.decimals, f.grouping) + f.suffix
+}
@@ -0,0 +1,60 @@
+package diagram
+
+import (
+	"fmt"
+	"godocgen/internal/util"
+	"os"
+	"os/exec"
+)
+
+// D2 rendert ```d2 Blöcke mit der lokalen D2-Installation.
+type D2 struct {
+	binary string
+	cache  *Cache
+}
+
+// NewD2 erstellt einen D2-Renderer. binary ist der Pfad zu "d2" (leer = aus PATH).
+func NewD2(cacheDir, binary string) *D2 {
+	if binary == "" {
+		binary = "d2"
+	}
+	return &D2{binary: binary, cache: NewCache(cacheDir, "d2")}
+}
+
+// Tool gibt den Namen des benötigten Werkzeugs zurück.
+func (d *D2) Tool() string { return "d2" }
+
+// Close hat für D2 nichts freizugeben.
+func (d *D2) Close() {}
+
+// Render rendert ein D2-Diagramm. Unterstützt die Attribute theme (D2-Theme-ID) und layout (dagre, elk).
+func (d *D2) Render(req Request) (string, string, error) {
+	layout := req.Attributes["layout"]
+	return d.cache.Get(cacheKey(req.Content, req.Theme, layout), func(svgPath, pngPath string) (string, string, error) {
+		binary, err := exec.LookPath(d.binary)
+		if err != nil {
+			return "", "", fmt.Errorf("D2 ist nicht installiert: %w", err)
+		}
+
+		src, err := WriteTempFile("*.d2", req.Content)
+		if err != nil {
+			return "", "", err
+		}
+		defer os.Remove(src)
+
+		args := []string{}
+		if req.Theme != "" {
+			args = append(args, "--theme="+req.Theme)
+		}
+		if layout != "" {
+			args = append(args, "--layout="+layout)
+		}
+		if _, err := util.RunCommand(binary, append(args, src, svgPath)...); err != nil {
+			return "", "", err
+		}
+		if _, err := util.RunCommand(binary, append(args, src, pngPath)...); err != nil {
+			return "", "", err
+		}
+		return svgPath, pngPath, nil
+	})
+}
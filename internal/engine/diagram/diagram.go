@@ -0,0 +1,167 @@
+// Package diagram definiert die gemeinsame Schnittstelle für Diagramm-Renderer
+// (Mermaid, Graphviz, PlantUML, D2) sowie deren Registry und Cache.
+package diagram
+
+import (
+	"fmt"
+	"godocgen/internal/util"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// languages bildet Fence-Sprachen auf den kanonischen Namen ihres Renderers ab.
+var languages = map[string]string{
+	"mermaid":  "mermaid",
+	"dot":      "graphviz",
+	"graphviz": "graphviz",
+	"plantuml": "plantuml",
+	"puml":     "plantuml",
+	"d2":       "d2",
+}
+
+// Canonical liefert den kanonischen Renderer-Namen für eine Fence-Sprache
+// und false, wenn die Sprache kein Diagramm beschreibt.
+func Canonical(lang string) (string, bool) {
+	name, ok := languages[strings.ToLower(lang)]
+	return name, ok
+}
+
+// Request beschreibt ein zu renderndes Diagramm.
+type Request struct {
+	Content    string            // Diagramm-Quelltext
+	Theme      string            // Optionales Theme für dieses Diagramm
+	Attributes map[string]string // Weitere Attribute aus dem Info-String (z.B. layout=neato)
+}
+
+// Renderer ist die Schnittstelle aller Diagramm-Backends.
+type Renderer interface {
+	// Tool gibt den Namen des benötigten externen Werkzeugs zurück (für Warnungen).
+	Tool() string
+	// Render rendert das Diagramm und gibt die Pfade zu SVG und PNG zurück.
+	Render(req Request) (string, string, error)
+	// Close gibt vom Renderer gehaltene Ressourcen frei.
+	Close()
+}
+
+// Registry verwaltet die Renderer je kanonischem Diagrammtyp.
+type Registry struct {
+	renderers map[string]Renderer
+}
+
+// NewRegistry erstellt eine leere Registry.
+func NewRegistry() *Registry {
+	return &Registry{renderers: make(map[string]Renderer)}
+}
+
+// Register registriert einen Renderer für einen kanonischen Diagrammtyp (z.B. "graphviz").
+func (r *Registry) Register(name string, renderer Renderer) {
+	r.renderers[name] = renderer
+}
+
+// Lookup sucht den Renderer für eine Fence-Sprache (z.B. "dot" oder "puml").
+func (r *Registry) Lookup(lang string) (Renderer, bool) {
+	name, ok := Canonical(lang)
+	if !ok {
+		return nil, false
+	}
+	renderer, ok := r.renderers[name]
+	return renderer, ok
+}
+
+// Close schließt alle registrierten Renderer.
+func (r *Registry) Close() {
+	for _, renderer := range r.renderers {
+		renderer.Close()
+	}
+}
+
+// Cache legt gerenderte Diagramme inhaltsadressiert unter <cacheDir>/<kind> ab.
+// Identische Diagramme, die gleichzeitig angefordert werden, werden nur einmal gerendert.
+type Cache struct {
+	dir      string
+	mu       sync.Mutex
+	inflight map[string]*call
+}
+
+// call hält das Ergebnis eines laufenden Render-Vorgangs.
+type call struct {
+	done             chan struct{}
+	svgPath, pngPath string
+	err              error
+}
+
+// NewCache erstellt einen Cache für einen Diagrammtyp.
+func NewCache(cacheDir, kind string) *Cache {
+	return &Cache{
+		dir:      filepath.Join(cacheDir, kind),
+		inflight: make(map[string]*call),
+	}
+}
+
+// RenderFunc erzeugt die Dateien svgPath und pngPath und gibt die tatsächlich
+// erzeugten Pfade zurück (z.B. PNG für beide, wenn kein SVG erzeugt werden kann).
+type RenderFunc func(svgPath, pngPath string) (string, string, error)
+
+// Get liefert das gecachte Diagramm für key oder rendert es mit render.
+func (c *Cache) Get(key string, render RenderFunc) (string, string, error) {
+	hash := util.HashString(key)
+
+	c.mu.Lock()
+	if running, ok := c.inflight[hash]; ok {
+		c.mu.Unlock()
+		<-running.done
+		return running.svgPath, running.pngPath, running.err
+	}
+	current := &call{done: make(chan struct{})}
+	c.inflight[hash] = current
+	c.mu.Unlock()
+
+	current.svgPath, current.pngPath, current.err = c.lookupOrRender(hash, render)
+	close(current.done)
+
+	c.mu.Lock()
+	delete(c.inflight, hash)
+	c.mu.Unlock()
+
+	return current.svgPath, current.pngPath, current.err
+}
+
+// lookupOrRender prüft den Cache und rendert bei Bedarf.
+func (c *Cache) lookupOrRender(hash string, render RenderFunc) (string, string, error) {
+	svgPath := filepath.Join(c.dir, hash+".svg")
+	pngPath := filepath.Join(c.dir, hash+".png")
+
+	// Cache-Prüfung (ohne SVG stammt das PNG aus einem reinen PNG-Fallback)
+	if _, err := os.Stat(pngPath); err == nil {
+		if _, err := os.Stat(svgPath); err == nil {
+			return svgPath, pngPath, nil
+		}
+		return pngPath, pngPath, nil
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return "", "", fmt.Errorf("Diagramm-Cache konnte nicht erstellt werden: %w", err)
+	}
+	return render(svgPath, pngPath)
+}
+
+// WriteTempFile schreibt content in eine eindeutige temporäre Datei und gibt deren Pfad zurück.
+func WriteTempFile(pattern, content string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(content); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// cacheKey bildet den Cache-Schlüssel aus dem Quelltext und allen renderrelevanten Optionen.
+func cacheKey(content string, options ...string) string {
+	return strings.Join(append([]string{content}, options...), "\x00")
+}
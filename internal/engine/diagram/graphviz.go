@@ -0,0 +1,57 @@
+package diagram
+
+import (
+	"fmt"
+	"godocgen/internal/util"
+	"os"
+	"os/exec"
+)
+
+// Graphviz rendert ```dot / ```graphviz Blöcke mit der lokalen Graphviz-Installation.
+type Graphviz struct {
+	binary string
+	cache  *Cache
+}
+
+// NewGraphviz erstellt einen Graphviz-Renderer. binary ist der Pfad zu "dot" (leer = aus PATH).
+func NewGraphviz(cacheDir, binary string) *Graphviz {
+	if binary == "" {
+		binary = "dot"
+	}
+	return &Graphviz{binary: binary, cache: NewCache(cacheDir, "graphviz")}
+}
+
+// Tool gibt den Namen des benötigten Werkzeugs zurück.
+func (g *Graphviz) Tool() string { return "dot" }
+
+// Close hat für Graphviz nichts freizugeben.
+func (g *Graphviz) Close() {}
+
+// Render rendert ein Graphviz-Diagramm. Das Attribut layout wählt die Layout-Engine (z.B. neato).
+func (g *Graphviz) Render(req Request) (string, string, error) {
+	layout := req.Attributes["layout"]
+	return g.cache.Get(cacheKey(req.Content, layout), func(svgPath, pngPath string) (string, string, error) {
+		binary, err := exec.LookPath(g.binary)
+		if err != nil {
+			return "", "", fmt.Errorf("Graphviz (dot) ist nicht installiert: %w", err)
+		}
+
+		src, err := WriteTempFile("*.dot", req.Content)
+		if err != nil {
+			return "", "", err
+		}
+		defer os.Remove(src)
+
+		args := []string{}
+		if layout != "" {
+			args = append(args, "-K"+layout)
+		}
+		if _, err := util.RunCommand(binary, append(args, "-Tsvg", "-o", svgPath, src)...); err != nil {
+			return "", "", err
+		}
+		if _, err := util.RunCommand(binary, append(args, "-Tpng", "-Gdpi=300", "-o", pngPath, src)...); err != nil {
+			return "", "", err
+		}
+		return svgPath, pngPath, nil
+	})
+}
@@ -0,0 +1,72 @@
+package diagram
+
+import (
+	"fmt"
+	"godocgen/internal/util"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PlantUML rendert ```plantuml / ```puml Blöcke mit einer lokalen plantuml.jar.
+type PlantUML struct {
+	java  string
+	jar   string
+	cache *Cache
+}
+
+// NewPlantUML erstellt einen PlantUML-Renderer.
+// jar ist der Pfad zur plantuml.jar (leer = Umgebungsvariable PLANTUML_JAR), java der Java-Befehl.
+func NewPlantUML(cacheDir, java, jar string) *PlantUML {
+	if java == "" {
+		java = "java"
+	}
+	if jar == "" {
+		jar = os.Getenv("PLANTUML_JAR")
+	}
+	return &PlantUML{java: java, jar: jar, cache: NewCache(cacheDir, "plantuml")}
+}
+
+// Tool gibt den Namen des benötigten Werkzeugs zurück.
+func (p *PlantUML) Tool() string { return "plantuml.jar" }
+
+// Close hat für PlantUML nichts freizugeben.
+func (p *PlantUML) Close() {}
+
+// Render rendert ein PlantUML-Diagramm. Fehlende @startuml/@enduml-Klammern werden ergänzt.
+func (p *PlantUML) Render(req Request) (string, string, error) {
+	content := req.Content
+	if !strings.Contains(content, "@start") {
+		content = "@startuml\n" + content + "\n@enduml\n"
+	}
+
+	return p.cache.Get(cacheKey(content), func(svgPath, pngPath string) (string, string, error) {
+		if p.jar == "" {
+			return "", "", fmt.Errorf("plantuml.jar ist nicht konfiguriert (diagrams.plantuml_jar oder PLANTUML_JAR setzen)")
+		}
+		if _, err := os.Stat(p.jar); err != nil {
+			return "", "", fmt.Errorf("plantuml.jar wurde nicht gefunden: %w", err)
+		}
+		java, err := exec.LookPath(p.java)
+		if err != nil {
+			return "", "", fmt.Errorf("Java ist nicht installiert: %w", err)
+		}
+
+		svg, err := util.RunCommandWithInput(content, java, "-Djava.awt.headless=true", "-jar", p.jar, "-pipe", "-tsvg", "-charset", "UTF-8")
+		if err != nil {
+			return "", "", err
+		}
+		if err := os.WriteFile(svgPath, []byte(svg), 0644); err != nil {
+			return "", "", err
+		}
+
+		png, err := util.RunCommandWithInput(content, java, "-Djava.awt.headless=true", "-jar", p.jar, "-pipe", "-tpng", "-charset", "UTF-8", "-Sdpi=300")
+		if err != nil {
+			return "", "", err
+		}
+		if err := os.WriteFile(pngPath, []byte(png), 0644); err != nil {
+			return "", "", err
+		}
+		return svgPath, pngPath, nil
+	})
+}
@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/diagram"
+	"godocgen/internal/engine/mermaid"
+	"godocgen/internal/i18n"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// diagramJob beschreibt ein zu renderndes Diagramm und seine Position in der Blockliste.
+type diagramJob struct {
+	index int
+	block blocks.DiagramBlock
+}
+
+// diagramResult ist das Ergebnis eines Render-Vorgangs.
+type diagramResult struct {
+	block blocks.DocBlock
+	err   error
+}
+
+// newDiagramRegistry registriert alle verfügbaren Diagramm-Renderer für einen Build.
+func (b *Builder) newDiagramRegistry(cfg *config.Config, workers int, mermaidOpts mermaid.Options) *diagram.Registry {
+	registry := diagram.NewRegistry()
+	registry.Register("mermaid", mermaid.NewRenderer(b.CacheDir, workers, mermaidOpts))
+	registry.Register("graphviz", diagram.NewGraphviz(b.CacheDir, cfg.Diagrams.Graphviz))
+
+	plantUMLJar := cfg.Diagrams.PlantUMLJar
+	if plantUMLJar != "" {
+		plantUMLJar = b.resolveProjectPath(plantUMLJar)
+	}
+	registry.Register("plantuml", diagram.NewPlantUML(b.CacheDir, cfg.Diagrams.Java, plantUMLJar))
+	registry.Register("d2", diagram.NewD2(b.CacheDir, cfg.Diagrams.D2))
+	return registry
+}
+
+// renderDiagrams rendert alle Diagramm-Blöcke in einem begrenzten Worker-Pool und ersetzt sie
+// in allBlocks durch Bild-Blöcke (bzw. einen Hinweis-Absatz, falls das Rendern fehlschlägt).
+// Alle Mermaid-Diagramme teilen sich eine headless Chrome-Instanz mit je einem Tab pro Diagramm.
+func (b *Builder) renderDiagrams(cfg *config.Config, allBlocks []blocks.DocBlock, mermaidOpts mermaid.Options) {
+	var jobs []diagramJob
+	for i, block := range allBlocks {
+		if blk, ok := block.(blocks.DiagramBlock); ok {
+			jobs = append(jobs, diagramJob{index: i, block: blk})
+		}
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	workers := cfg.Mermaid.Workers
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	registry := b.newDiagramRegistry(cfg, workers, mermaidOpts)
+	defer registry.Close()
+	b.renderDiagramJobs(cfg, registry, allBlocks, jobs, workers)
+}
+
+// renderDiagramJobs rendert die Jobs mit workers parallelen Workern und schreibt die Ergebnisse
+// an die Position des jeweiligen Diagramms in allBlocks.
+func (b *Builder) renderDiagramJobs(cfg *config.Config, registry *diagram.Registry, allBlocks []blocks.DocBlock, jobs []diagramJob, workers int) {
+	// Die Worker erhalten Positionen im Job-Slice und schreiben ihr Ergebnis an dieselbe Stelle
+	next := make(chan int)
+	results := make([]diagramResult, len(jobs))
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range next {
+				results[n] = b.renderDiagram(cfg, registry, jobs[n].block)
+			}
+		}()
+	}
+	for n := range jobs {
+		next <- n
+	}
+	close(next)
+	wg.Wait()
+
+	// Ergebnisse in Dokumentreihenfolge übernehmen, damit Warnungen deterministisch sind
+	for n, res := range results {
+		if res.err != nil {
+			fmt.Printf("Warnung: %s-Diagramm konnte nicht gerendert werden: %v\n", jobs[n].block.Language, res.err)
+		}
+		allBlocks[jobs[n].index] = res.block
+	}
+}
+
+// renderDiagram rendert ein einzelnes Diagramm mit dem passenden Renderer und wandelt es in einen ImageBlock um.
+func (b *Builder) renderDiagram(cfg *config.Config, registry *diagram.Registry, blk blocks.DiagramBlock) diagramResult {
+	renderer, ok := registry.Lookup(blk.Language)
+	if !ok {
+		return diagramResult{
+			err:   fmt.Errorf("kein Renderer für %q registriert", blk.Language),
+			block: diagramPlaceholder(cfg, blk.Language),
+		}
+	}
+
+	svgPath, pngPath, err := renderer.Render(diagram.Request{
+		Content:    blk.Content,
+		Theme:      blk.Theme,
+		Attributes: blk.Attributes,
+	})
+	if err != nil {
+		return diagramResult{err: err, block: diagramPlaceholder(cfg, renderer.Tool())}
+	}
+
+	// Mermaid-Konfiguration für Größe anwenden, Diagramm-Attribute haben Vorrang
+	width, scale := 0.0, 1.0
+	if name, _ := diagram.Canonical(blk.Language); name == "mermaid" {
+		width, scale = cfg.Mermaid.Width, cfg.Mermaid.Scale
+	}
+	if blk.Width > 0 {
+		width = blk.Width
+	}
+	if blk.Scale > 0 {
+		scale = blk.Scale
+	}
+
+	// Das SVG wird als Vektorgrafik eingebettet, das PNG dient nur noch als Fallback
+	image := blocks.ImageBlock{
+		Path:  pngPath,
+		Alt:   "Diagram (SVG Quelle: " + svgPath + ")",
+		Title: blk.Title,
+		Width: width,
+		Scale: scale,
+	}
+	if svgPath != pngPath && strings.EqualFold(filepath.Ext(svgPath), ".svg") {
+		image.Path, image.Fallback = svgPath, pngPath
+	}
+	return diagramResult{block: image}
+}
+
+// diagramPlaceholder erzeugt den Hinweis-Absatz für ein nicht renderbares Diagramm.
+func diagramPlaceholder(cfg *config.Config, tool string) blocks.DocBlock {
+	return blocks.ParagraphBlock{
+		Content: []blocks.TextSegment{
+			{Text: i18n.New(cfg.Language, cfg.Messages).T(i18n.DiagramMissingTool, tool), Italic: true},
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package engine
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/diagram"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubRenderer zählt Render-Vorgänge und die höchste Zahl gleichzeitiger Aufrufe.
+type stubRenderer struct {
+	cache   *diagram.Cache
+	mu      sync.Mutex
+	active  int
+	peak    int
+	renders map[string]int
+}
+
+func (s *stubRenderer) Tool() string { return "stub" }
+
+func (s *stubRenderer) Close() {}
+
+func (s *stubRenderer) Render(req diagram.Request) (string, string, error) {
+	return s.cache.Get(req.Content, func(svgPath, pngPath string) (string, string, error) {
+		s.mu.Lock()
+		s.active++
+		s.peak = max(s.peak, s.active)
+		s.renders[req.Content]++
+		s.mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+		if err := os.WriteFile(pngPath, []byte(req.Content), 0644); err != nil {
+			return "", "", err
+		}
+		return pngPath, pngPath, nil
+	})
+}
+
+func TestRenderDiagramJobs(t *testing.T) {
+	stub := &stubRenderer{cache: diagram.NewCache(t.TempDir(), "stub"), renders: map[string]int{}}
+	registry := diagram.NewRegistry()
+	registry.Register("mermaid", stub)
+
+	// Acht Diagramme, darunter zweimal je dasselbe, zwischen gewöhnlichen Absätzen
+	var allBlocks []blocks.DocBlock
+	var jobs []diagramJob
+	for i := 0; i < 8; i++ {
+		allBlocks = append(allBlocks, blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: fmt.Sprint("Absatz ", i)}}})
+		blk := blocks.DiagramBlock{Language: "mermaid", Content: fmt.Sprintf("graph TD; A%d-->B;", i%6)}
+		jobs = append(jobs, diagramJob{index: len(allBlocks), block: blk})
+		allBlocks = append(allBlocks, blk)
+	}
+
+	b := &Builder{}
+	b.renderDiagramJobs(&config.Config{}, registry, allBlocks, jobs, 3)
+
+	if stub.peak > 3 {
+		t.Errorf("%d gleichzeitige Render-Vorgänge, erlaubt sind 3", stub.peak)
+	}
+	if stub.peak < 2 {
+		t.Errorf("Diagramme wurden nicht parallel gerendert (höchstens %d gleichzeitig)", stub.peak)
+	}
+	if len(stub.renders) != 6 {
+		t.Errorf("%d verschiedene Diagramme gerendert, erwartet 6", len(stub.renders))
+	}
+	for content, n := range stub.renders {
+		if n != 1 {
+			t.Errorf("%q wurde %d-mal gerendert", content, n)
+		}
+	}
+
+	// Jedes Diagramm steht als Bild an seiner ursprünglichen Position
+	for i, job := range jobs {
+		img, ok := allBlocks[job.index].(blocks.ImageBlock)
+		if !ok {
+			t.Fatalf("Block %d ist %T, erwartet ImageBlock", job.index, allBlocks[job.index])
+		}
+		data, err := os.ReadFile(img.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != job.block.Content {
+			t.Errorf("Diagramm %d zeigt %q, erwartet %q", i, data, job.block.Content)
+		}
+		if _, ok := allBlocks[job.index-1].(blocks.ParagraphBlock); !ok {
+			t.Errorf("Block %d ist %T, erwartet ParagraphBlock", job.index-1, allBlocks[job.index-1])
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Package hyphen implementiert die Silbentrennung nach Liang mit TeX-Trennmustern.
+// Für Deutsch und Englisch sind Muster eingebettet, weitere Sprachen lassen sich
+// über eigene Musterdateien im TeX-Format (\patterns{...}, \hyphenation{...}) laden.
+package hyphen
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SoftHyphen ist das weiche Trennzeichen (&shy;), an dem Zeilen umbrochen werden dürfen.
+const SoftHyphen = "­"
+
+//go:embed patterns/*.tex
+var embedded embed.FS
+
+// minimale Zeichenzahl vor und nach einer Trennstelle je Sprache (\lefthyphenmin, \righthyphenmin)
+var hyphenMins = map[string][2]int{
+	"de": {2, 2},
+	"en": {2, 3},
+}
+
+// Hyphenator trennt Wörter anhand von Liang-Mustern und einer Ausnahmeliste.
+type Hyphenator struct {
+	patterns   map[string][]uint8 // Buchstabenfolge (mit "." als Wortgrenze) -> Trennwerte
+	maxLen     int                // Länge des längsten Musters in Zeichen
+	exceptions map[string][]int   // Kleingeschriebenes Wort -> Trennstellen
+	LeftMin    int                // Mindestanzahl Zeichen vor der ersten Trennstelle
+	RightMin   int                // Mindestanzahl Zeichen nach der letzten Trennstelle
+}
+
+// Languages liefert die Sprachen mit eingebetteten Trennmustern.
+func Languages() []string {
+	entries, _ := embedded.ReadDir("patterns")
+	langs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		langs = append(langs, strings.TrimSuffix(e.Name(), ".tex"))
+	}
+	return langs
+}
+
+// ForLanguage lädt die eingebetteten Trennmuster einer Sprache ("de", "en-US", ...).
+// Für Sprachen ohne eingebettete Muster liefert es nil.
+func ForLanguage(lang string) (*Hyphenator, error) {
+	lang = strings.ToLower(lang)
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	f, err := embedded.Open("patterns/" + lang + ".tex")
+	if err != nil {
+		return nil, nil
+	}
+	defer f.Close()
+	h, err := Parse(f)
+	if err != nil {
+		return nil, err
+	}
+	if mins, ok := hyphenMins[lang]; ok {
+		h.LeftMin, h.RightMin = mins[0], mins[1]
+	}
+	return h, nil
+}
+
+// Parse liest Trennmuster im TeX-Format. Muster stehen in \patterns{...}, Ausnahmen mit
+// ihren Trennstellen in \hyphenation{...}. Dateien ohne diese Befehle gelten als reine Musterlisten.
+// Kommentare beginnen mit %.
+func Parse(r io.Reader) (*Hyphenator, error) {
+	h := &Hyphenator{
+		patterns:   make(map[string][]uint8),
+		exceptions: make(map[string][]int),
+		LeftMin:    2,
+		RightMin:   2,
+	}
+
+	section := "patterns"
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.Index(text, "%"); i >= 0 {
+			text = text[:i]
+		}
+		for _, token := range strings.Fields(text) {
+			switch {
+			case strings.HasPrefix(token, `\patterns{`):
+				section, token = "patterns", strings.TrimPrefix(token, `\patterns{`)
+			case strings.HasPrefix(token, `\hyphenation{`):
+				section, token = "hyphenation", strings.TrimPrefix(token, `\hyphenation{`)
+			}
+			token = strings.TrimSuffix(token, "}")
+			if token == "" {
+				continue
+			}
+			var err error
+			if section == "hyphenation" {
+				h.AddException(token)
+			} else {
+				err = h.addPattern(token)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("Zeile %d: %w", line, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(h.patterns) == 0 {
+		return nil, fmt.Errorf("keine Trennmuster gefunden")
+	}
+	return h, nil
+}
+
+// addPattern übernimmt ein Muster wie ".ab3l" oder "4tion".
+func (h *Hyphenator) addPattern(pattern string) error {
+	var letters []rune
+	values := []uint8{0}
+	for _, r := range strings.ToLower(pattern) {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = uint8(r - '0')
+			continue
+		}
+		if r != '.' && !unicode.IsLetter(r) && r != '\'' && r != '’' {
+			return fmt.Errorf("ungültiges Trennmuster %q", pattern)
+		}
+		letters = append(letters, r)
+		values = append(values, 0)
+	}
+	if len(letters) == 0 {
+		return fmt.Errorf("ungültiges Trennmuster %q", pattern)
+	}
+	h.patterns[string(letters)] = values
+	if len(letters) > h.maxLen {
+		h.maxLen = len(letters)
+	}
+	return nil
+}
+
+// AddException legt die Trennstellen eines Wortes fest, z.B. "Ur-in-stinkt".
+func (h *Hyphenator) AddException(word string) {
+	var points []int
+	n := 0
+	for _, r := range word {
+		if r == '-' {
+			points = append(points, n)
+			continue
+		}
+		n++
+	}
+	h.exceptions[strings.ToLower(strings.ReplaceAll(word, "-", ""))] = points
+}
+
+// Points liefert die Trennstellen eines Wortes als Zeichenindizes: Ein Wert i erlaubt
+// die Trennung vor dem i-ten Zeichen (ab 0 gezählt).
+func (h *Hyphenator) Points(word string) []int {
+	lower := strings.ToLower(word)
+	if points, ok := h.exceptions[lower]; ok {
+		return points
+	}
+	runes := []rune(lower)
+	n := len(runes)
+	if n < h.LeftMin+h.RightMin {
+		return nil
+	}
+
+	// Wort mit Wortgrenzen: ".wort."
+	w := make([]rune, 0, n+2)
+	w = append(w, '.')
+	w = append(w, runes...)
+	w = append(w, '.')
+	values := make([]uint8, len(w)+1)
+	for i := range w {
+		for j := i + 1; j <= len(w) && j-i <= h.maxLen; j++ {
+			pattern, ok := h.patterns[string(w[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pattern {
+				if v > values[i+k] {
+					values[i+k] = v
+				}
+			}
+		}
+	}
+
+	var points []int
+	for i := h.LeftMin; i <= n-h.RightMin; i++ {
+		// values[i+1] steht vor dem i-ten Zeichen des Wortes (nach dem führenden ".")
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+	return points
+}
+
+// Hyphenate fügt weiche Trennzeichen an allen Trennstellen eines Wortes ein.
+func (h *Hyphenator) Hyphenate(word string) string {
+	points := h.Points(word)
+	if len(points) == 0 {
+		return word
+	}
+	var sb strings.Builder
+	next := 0
+	i := 0
+	for _, r := range word {
+		if next < len(points) && points[next] == i {
+			sb.WriteString(SoftHyphen)
+			next++
+		}
+		sb.WriteRune(r)
+		i++
+	}
+	return sb.String()
+}
+
+// HyphenateText fügt weiche Trennzeichen in alle Wörter eines Textes ein, die mindestens
+// minWord Buchstaben lang sind. Wörter mit manuellen Trennstellen (&shy;), Abkürzungen in
+// Großbuchstaben sowie URLs und E-Mail-Adressen bleiben unverändert.
+func (h *Hyphenator) HyphenateText(text string, minWord int) string {
+	var sb strings.Builder
+	for len(text) > 0 {
+		// Leerraum übernehmen
+		i := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsSpace(r) })
+		if i < 0 {
+			sb.WriteString(text)
+			break
+		}
+		sb.WriteString(text[:i])
+		text = text[i:]
+
+		end := strings.IndexFunc(text, unicode.IsSpace)
+		if end < 0 {
+			end = len(text)
+		}
+		token := text[:end]
+		text = text[end:]
+		if strings.Contains(token, SoftHyphen) || isLinkLike(token) {
+			sb.WriteString(token)
+			continue
+		}
+		sb.WriteString(h.hyphenateToken(token, minWord))
+	}
+	return sb.String()
+}
+
+// hyphenateToken trennt die Buchstabenfolgen eines Wortes, z.B. in "(Donaudampfschiff)".
+func (h *Hyphenator) hyphenateToken(token string, minWord int) string {
+	var sb strings.Builder
+	for len(token) > 0 {
+		start := strings.IndexFunc(token, unicode.IsLetter)
+		if start < 0 {
+			sb.WriteString(token)
+			break
+		}
+		sb.WriteString(token[:start])
+		token = token[start:]
+		end := strings.IndexFunc(token, func(r rune) bool { return !unicode.IsLetter(r) })
+		if end < 0 {
+			end = len(token)
+		}
+		word := token[:end]
+		token = token[end:]
+		if utf8.RuneCountInString(word) < minWord || strings.ToUpper(word) == word {
+			sb.WriteString(word)
+			continue
+		}
+		sb.WriteString(h.Hyphenate(word))
+	}
+	return sb.String()
+}
+
+// isLinkLike erkennt URLs, E-Mail-Adressen und Pfade, die nicht getrennt werden dürfen.
+func isLinkLike(token string) bool {
+	return strings.Contains(token, "://") || strings.Contains(token, "@") ||
+		strings.HasPrefix(token, "www.") || strings.Contains(token, "/")
+}
@@ -0,0 +1,149 @@
+// Package images bereitet Bilddateien für die Einbettung in das PDF vor.
+//
+// gofpdf unterstützt nur PNG (8 Bit, ohne Interlacing), JPEG (RGB/Graustufen) und GIF.
+// Andere Formate (WebP, BMP, TIFF) sowie 16-Bit-PNGs und CMYK-JPEGs werden mit reinem Go
+// dekodiert, auf 8 Bit normalisiert und im Cache abgelegt.
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"godocgen/internal/util"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "image/gif"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// jpegQuality ist die Qualität für neu kodierte JPEGs (z.B. aus CMYK umgewandelt).
+const jpegQuality = 92
+
+// Normalize prüft, ob gofpdf ein Bild direkt einbetten kann, und wandelt es andernfalls
+// in ein 8-Bit-PNG bzw. RGB-JPEG um. Umgewandelte Dateien werden unter cacheDir/images
+// nach Inhalts-Hash abgelegt und bei weiteren Builds wiederverwendet.
+// SVG-Dateien werden unverändert zurückgegeben, sie zeichnet der PDF-Generator als Vektorgrafik.
+func Normalize(path, cacheDir string) (string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".svg") {
+		return path, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("unbekanntes oder beschädigtes Bildformat: %w", err)
+	}
+	if supportedAsIs(data, format, cfg) {
+		return path, nil
+	}
+
+	// JPEG-Quellen bleiben JPEG (Fotos), alle anderen werden verlustfrei zu PNG
+	ext := ".png"
+	if format == "jpeg" {
+		ext = ".jpg"
+	}
+	target := filepath.Join(cacheDir, "images", util.HashString(string(data))+ext)
+	if _, err := os.Stat(target); err == nil {
+		return target, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("%s-Bild kann nicht dekodiert werden: %w", strings.ToUpper(format), err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", err
+	}
+	if err := writeImage(target, to8Bit(img), ext, jpegQuality); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// supportedAsIs prüft, ob gofpdf das Bild ohne Umwandlung verarbeiten kann.
+func supportedAsIs(data []byte, format string, cfg image.Config) bool {
+	switch format {
+	case "gif":
+		return true
+	case "jpeg":
+		// gofpdf bettet CMYK-JPEGs ohne Farbumkehr ein (Adobe-Inversion), daher umwandeln
+		return cfg.ColorModel != color.CMYKModel
+	case "png":
+		// IHDR: Bittiefe an Byte 24, Interlace-Methode an Byte 28
+		if len(data) < 29 || binary.BigEndian.Uint32(data[8:12]) != 13 {
+			return false
+		}
+		return data[24] <= 8 && data[28] == 0
+	}
+	return false
+}
+
+// to8Bit wandelt ein Bild in ein 8-Bit-RGB(A)-Bild um. Graustufen bleiben erhalten.
+func to8Bit(img image.Image) image.Image {
+	switch img.(type) {
+	case *image.Gray, *image.RGBA, *image.NRGBA, *image.Paletted:
+		return img
+	}
+
+	bounds := img.Bounds()
+	if isOpaque(img) {
+		switch img.ColorModel() {
+		case color.GrayModel, color.Gray16Model:
+			gray := image.NewGray(bounds)
+			draw.Draw(gray, bounds, img, bounds.Min, draw.Src)
+			return gray
+		}
+		rgba := image.NewRGBA(bounds)
+		draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+		return rgba
+	}
+	nrgba := image.NewNRGBA(bounds)
+	draw.Draw(nrgba, bounds, img, bounds.Min, draw.Src)
+	return nrgba
+}
+
+// isOpaque prüft, ob ein Bild keine Transparenz enthält.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+// writeImage speichert ein Bild als PNG oder JPEG (mit der angegebenen Qualität). Es wird zunächst in eine temporäre
+// Datei geschrieben, damit parallele Builds keine halb geschriebenen Dateien lesen.
+func writeImage(target string, img image.Image, ext string, quality int) error {
+	tmp, err := os.CreateTemp(filepath.Dir(target), "*"+ext)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if ext == ".jpg" || ext == ".jpeg" {
+		err = jpeg.Encode(tmp, img, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(tmp, img)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Bild konnte nicht gespeichert werden: %w", err)
+	}
+	return os.Rename(tmp.Name(), target)
+}
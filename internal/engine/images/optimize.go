@@ -0,0 +1,163 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"godocgen/internal/util"
+	"image"
+	"image/draw"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+// OptimizeOptions steuert die Verkleinerung von Rasterbildern.
+type OptimizeOptions struct {
+	MaxDPI      float64 // Maximale Auflösung bezogen auf die platzierte Breite (0 = nicht verkleinern)
+	JPEGQuality int     // JPEG-Qualität für deckende Bilder (0 = keine Neukodierung)
+}
+
+// Enabled prüft, ob überhaupt eine Optimierung stattfinden soll.
+func (o OptimizeOptions) Enabled() bool {
+	return o.MaxDPI > 0 || o.JPEGQuality > 0
+}
+
+// OptimizeResult beschreibt das Ergebnis der Optimierung eines Bildes.
+type OptimizeResult struct {
+	Path      string // Pfad des einzubettenden Bildes (Original, falls nicht kleiner)
+	Source    string // Ursprünglicher Pfad
+	OrigW     int    // Ursprüngliche Breite in px
+	OrigH     int    // Ursprüngliche Höhe in px
+	NewW      int    // Neue Breite in px
+	NewH      int    // Neue Höhe in px
+	OrigBytes int64  // Ursprüngliche Dateigröße
+	NewBytes  int64  // Dateigröße nach der Optimierung
+}
+
+// Changed prüft, ob ein optimiertes Bild statt des Originals verwendet wird.
+func (r OptimizeResult) Changed() bool {
+	return r.Path != r.Source
+}
+
+// Dimensions liefert die Pixelmaße eines Rasterbildes.
+func Dimensions(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cfg.Width, cfg.Height, nil
+}
+
+// Optimize verkleinert ein Rasterbild auf die Auflösung, die sich aus der platzierten Breite
+// (widthMM) und opts.MaxDPI ergibt, und kodiert deckende Bilder optional als JPEG neu.
+// Das Ergebnis wird unter cacheDir/images abgelegt und nur verwendet, wenn es kleiner ist.
+func Optimize(path, cacheDir string, widthMM float64, opts OptimizeOptions) (OptimizeResult, error) {
+	res := OptimizeResult{Path: path, Source: path}
+	if !opts.Enabled() || strings.EqualFold(filepath.Ext(path), ".svg") {
+		return res, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return res, err
+	}
+	res.OrigBytes, res.NewBytes = int64(len(data)), int64(len(data))
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return res, fmt.Errorf("Bild kann nicht dekodiert werden: %w", err)
+	}
+	bounds := img.Bounds()
+	res.OrigW, res.OrigH = bounds.Dx(), bounds.Dy()
+	res.NewW, res.NewH = res.OrigW, res.OrigH
+
+	// Zielbreite in Pixeln aus platzierter Breite und maximaler DPI
+	targetW := res.OrigW
+	if opts.MaxDPI > 0 && widthMM > 0 {
+		if w := int(math.Ceil(widthMM / 25.4 * opts.MaxDPI)); w < res.OrigW {
+			targetW = w
+		}
+	}
+	// Kleine Abweichungen lohnen das Neuberechnen nicht
+	resize := float64(targetW) < float64(res.OrigW)*0.95
+	recompress := opts.JPEGQuality > 0 && isOpaque(img)
+	if !resize && !recompress {
+		return res, nil
+	}
+
+	ext := filepath.Ext(path)
+	if recompress {
+		ext = ".jpg"
+	} else if format != "jpeg" {
+		ext = ".png"
+	}
+	key := fmt.Sprintf("%s\x00%d\x00%d", data, targetW, opts.JPEGQuality)
+	target := filepath.Join(cacheDir, "images", "opt-"+util.HashString(key)+strings.ToLower(ext))
+
+	if resize {
+		res.NewW = targetW
+		res.NewH = int(math.Max(1, math.Round(float64(res.OrigH)*float64(targetW)/float64(res.OrigW))))
+	}
+
+	// Bereits optimierte Bilder aus früheren Builds wiederverwenden
+	if _, err := os.Stat(target); err != nil {
+		if resize {
+			img = resample(img, res.NewW, res.NewH)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return res, err
+		}
+		quality := jpegQuality
+		if recompress {
+			quality = opts.JPEGQuality
+		}
+		if err := writeImage(target, img, ext, quality); err != nil {
+			return res, err
+		}
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return res, err
+	}
+	if info.Size() >= res.OrigBytes {
+		// Keine Ersparnis: Original behalten
+		res.NewW, res.NewH = res.OrigW, res.OrigH
+		return res, nil
+	}
+	res.Path = target
+	res.NewBytes = info.Size()
+	return res, nil
+}
+
+// resample skaliert ein Bild mit Catmull-Rom-Interpolation auf w x h Pixel.
+func resample(img image.Image, w, h int) image.Image {
+	rect := image.Rect(0, 0, w, h)
+	var dst draw.Image
+	if isOpaque(img) {
+		dst = image.NewRGBA(rect)
+	} else {
+		dst = image.NewNRGBA(rect)
+	}
+	xdraw.CatmullRom.Scale(dst, rect, img, img.Bounds(), xdraw.Src, nil)
+	return dst
+}
+
+// FormatBytes formatiert eine Dateigröße für den Build-Bericht (z.B. "1,4 MB").
+func FormatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return strings.Replace(fmt.Sprintf("%.1f MB", float64(n)/(1<<20)), ".", ",", 1)
+	case n >= 1<<10:
+		return fmt.Sprintf("%d KB", (n+512)>>10)
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,170 @@
+package markdown
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// extractBraces liefert den Inhalt zwischen der ersten "{" und der letzten "}" eines Info-Strings.
+func extractBraces(info string) (string, bool) {
+	start := strings.Index(info, "{")
+	end := strings.LastIndex(info, "}")
+	if start == -1 || end == -1 || end <= start {
+		return "", false
+	}
+	return info[start+1 : end], true
+}
+
+// parseAttributes zerlegt eine Attributliste wie `width=120 scale=0.7 title="Mein Diagramm"`.
+// Werte können in einfache oder doppelte Anführungszeichen gesetzt werden.
+// Gibt false zurück, wenn der Text keine reine key=value-Liste ist (z.B. ein einfacher Titel).
+func parseAttributes(s string) (map[string]string, bool) {
+	attrs := map[string]string{}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return attrs, false
+	}
+
+	i := 0
+	for i < len(s) {
+		// Leerzeichen überspringen
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+
+		// Schlüssel lesen
+		keyStart := i
+		for i < len(s) && isAttributeKeyChar(s[i]) {
+			i++
+		}
+		if i == keyStart || i >= len(s) || s[i] != '=' {
+			return nil, false
+		}
+		key := strings.ToLower(s[keyStart:i])
+		i++ // '='
+
+		// Wert lesen (optional in Anführungszeichen)
+		var value string
+		if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+			quote := s[i]
+			end := strings.IndexByte(s[i+1:], quote)
+			if end == -1 {
+				return nil, false
+			}
+			value = s[i+1 : i+1+end]
+			i += end + 2
+		} else {
+			valueStart := i
+			for i < len(s) && s[i] != ' ' && s[i] != '\t' {
+				i++
+			}
+			value = s[valueStart:i]
+		}
+		attrs[key] = value
+	}
+
+	return attrs, len(attrs) > 0
+}
+
+// isAttributeKeyChar prüft, ob ein Zeichen in einem Attributnamen erlaubt ist.
+func isAttributeKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// attributeFloat liest einen numerischen Attributwert (eine Einheit "mm" wird ignoriert).
+func attributeFloat(attrs map[string]string, key string) float64 {
+	v, ok := attrs[key]
+	if !ok {
+		return 0
+	}
+	v = strings.TrimSuffix(strings.TrimSpace(v), "mm")
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// attributeLength liest eine Längenangabe in mm ("40", "40mm") oder Prozent ("40%").
+func attributeLength(v string) (float64, bool, error) {
+	v = strings.TrimSpace(v)
+	percent := strings.HasSuffix(v, "%")
+	num := strings.TrimSuffix(strings.TrimSuffix(v, "%"), "mm")
+	f, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil || f <= 0 {
+		return 0, false, fmt.Errorf("ungültige Länge %q", v)
+	}
+	return f, percent, nil
+}
+
+// applyImageAttributes überträgt Bildattribute wie `{width=40% align=right float=left border=false}`
+// auf einen ImageBlock. Unbekannte Attribute und ungültige Werte werden gemeldet und ignoriert.
+func applyImageAttributes(img *blocks.ImageBlock, attrs map[string]string) []error {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		value := strings.TrimSpace(attrs[key])
+		switch key {
+		case "width", "height":
+			f, percent, err := attributeLength(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			if percent && f > 100 {
+				f = 100
+			}
+			switch {
+			case key == "width" && percent:
+				img.WidthPercent = f
+			case key == "width":
+				img.Width = f
+			case percent:
+				img.HeightPercent = f
+			default:
+				img.Height = f
+			}
+		case "align":
+			switch strings.ToLower(value) {
+			case "left", "center", "right":
+				img.Align = strings.ToLower(value)
+			default:
+				errs = append(errs, fmt.Errorf("align: %q (erlaubt: left, center, right)", value))
+			}
+		case "float":
+			switch strings.ToLower(value) {
+			case "left", "right":
+				img.Float = strings.ToLower(value)
+			case "none":
+				img.Float = ""
+			default:
+				errs = append(errs, fmt.Errorf("float: %q (erlaubt: left, right, none)", value))
+			}
+		case "border", "frame":
+			switch strings.ToLower(value) {
+			case "true", "on", "yes", "1":
+				img.NoBorder = false
+			case "false", "off", "no", "none", "0":
+				img.NoBorder = true
+			default:
+				errs = append(errs, fmt.Errorf("%s: %q (erlaubt: true, false)", key, value))
+			}
+		case "title":
+			img.Title = value
+		default:
+			errs = append(errs, fmt.Errorf("unbekanntes Attribut %q", key))
+		}
+	}
+	return errs
+}
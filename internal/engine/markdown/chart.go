@@ -0,0 +1,192 @@
+package markdown
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"godocgen/internal/blocks"
+
+	"gopkg.in/yaml.v3"
+)
+
+// chartSpec ist die YAML-Form eines ```chart Blocks.
+type chartSpec struct {
+	Type   string   `yaml:"type"`
+	Title  string   `yaml:"title"`
+	Unit   string   `yaml:"unit"`
+	Height float64  `yaml:"height"`
+	Colors []string `yaml:"colors"`
+	Labels []string `yaml:"labels"`
+	Series []struct {
+		Name   string    `yaml:"name"`
+		Values []float64 `yaml:"values"`
+	} `yaml:"series"`
+	Data string `yaml:"data"` // Alternativ: Werte als CSV
+}
+
+// chartTypes sind die unterstützten Diagrammtypen inklusive Aliasse.
+var chartTypes = map[string]string{
+	"bar":         "bar",
+	"column":      "bar",
+	"line":        "line",
+	"pie":         "pie",
+	"stacked":     "stacked",
+	"stacked-bar": "stacked",
+}
+
+// parseChart wandelt den Inhalt eines ```chart Blocks in einen ChartBlock um.
+// Der Inhalt ist entweder YAML (type, title, labels, series bzw. data) oder reines CSV,
+// dessen erste Zeile die Namen der Datenreihen enthält. Attribute im Info-String
+// ({type=line title="..." unit=ms height=60}) überschreiben die Werte aus dem Inhalt.
+func parseChart(content string, attrs map[string]string) (blocks.ChartBlock, error) {
+	chart := blocks.ChartBlock{Type: "bar"}
+
+	var spec chartSpec
+	var raw map[string]any
+	if err := yaml.Unmarshal([]byte(content), &raw); err == nil && raw != nil {
+		// YAML-Mapping
+		if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+			return chart, fmt.Errorf("ungültiges Chart-YAML: %w", err)
+		}
+		if spec.Type != "" {
+			chart.Type = spec.Type
+		}
+		chart.Title = spec.Title
+		chart.Unit = spec.Unit
+		chart.Height = spec.Height
+		chart.Colors = spec.Colors
+		chart.Labels = spec.Labels
+		for _, s := range spec.Series {
+			chart.Series = append(chart.Series, blocks.ChartSeries{Name: s.Name, Values: s.Values})
+		}
+		if spec.Data != "" {
+			labels, series, err := parseChartCSV(spec.Data)
+			if err != nil {
+				return chart, err
+			}
+			chart.Labels, chart.Series = labels, series
+		}
+	} else {
+		labels, series, err := parseChartCSV(content)
+		if err != nil {
+			return chart, err
+		}
+		chart.Labels, chart.Series = labels, series
+	}
+
+	if v, ok := attrs["type"]; ok {
+		chart.Type = v
+	}
+	if v, ok := attrs["title"]; ok {
+		chart.Title = v
+	}
+	if v, ok := attrs["unit"]; ok {
+		chart.Unit = v
+	}
+	if h := attributeFloat(attrs, "height"); h > 0 {
+		chart.Height = h
+	}
+
+	typ, ok := chartTypes[strings.ToLower(chart.Type)]
+	if !ok {
+		return chart, fmt.Errorf("unbekannter Chart-Typ %q (erlaubt: bar, line, pie, stacked)", chart.Type)
+	}
+	chart.Type = typ
+
+	if len(chart.Series) == 0 {
+		return chart, fmt.Errorf("Chart enthält keine Datenreihen")
+	}
+
+	// Fehlende Labels auffüllen, damit jede Kategorie beschriftet ist
+	count := 0
+	for _, s := range chart.Series {
+		if len(s.Values) > count {
+			count = len(s.Values)
+		}
+	}
+	for len(chart.Labels) < count {
+		chart.Labels = append(chart.Labels, strconv.Itoa(len(chart.Labels)+1))
+	}
+
+	return chart, nil
+}
+
+// parseChartCSV liest Chart-Daten im CSV-Format. Die erste Spalte enthält die Labels,
+// jede weitere Spalte eine Datenreihe. Als Trennzeichen werden Komma, Semikolon und Tab erkannt.
+func parseChartCSV(data string) ([]string, []blocks.ChartSeries, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, nil, fmt.Errorf("Chart enthält keine Daten")
+	}
+
+	firstLine := strings.SplitN(data, "\n", 2)[0]
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	switch {
+	case strings.Contains(firstLine, "\t"):
+		reader.Comma = '\t'
+	case strings.Contains(firstLine, ";"):
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ungültige Chart-CSV: %w", err)
+	}
+	if len(records) < 2 || len(records[0]) < 2 {
+		return nil, nil, fmt.Errorf("Chart-CSV benötigt eine Kopfzeile und mindestens eine Datenzeile mit zwei Spalten")
+	}
+
+	header := records[0]
+	series := make([]blocks.ChartSeries, len(header)-1)
+	for i := range series {
+		series[i].Name = strings.TrimSpace(header[i+1])
+	}
+
+	var labels []string
+	for line, record := range records[1:] {
+		labels = append(labels, strings.TrimSpace(record[0]))
+		for i := range series {
+			value := 0.0
+			if i+1 < len(record) {
+				field := strings.TrimSpace(record[i+1])
+				if field != "" {
+					v, err := parseChartNumber(field)
+					if err != nil {
+						return nil, nil, fmt.Errorf("ungültiger Wert %q in Chart-Zeile %d, Spalte %q", field, line+2, series[i].Name)
+					}
+					value = v
+				}
+			}
+			series[i].Values = append(series[i].Values, value)
+		}
+	}
+
+	return labels, series, nil
+}
+
+// parseChartNumber liest einen Wert mit Punkt oder Komma als Dezimaltrennzeichen und entfernt
+// Tausendertrennzeichen ("1.234,5", "1,234.5", "1 234,5"): Kommen Punkt und Komma vor, ist das
+// letzte das Dezimaltrennzeichen, ein mehrfach vorkommendes Zeichen trennt immer Tausender.
+func parseChartNumber(field string) (float64, error) {
+	s := strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "").Replace(field)
+	dot, comma := strings.LastIndex(s, "."), strings.LastIndex(s, ",")
+	switch {
+	case dot >= 0 && comma >= 0:
+		if dot > comma {
+			s = strings.ReplaceAll(s, ",", "")
+		} else {
+			s = strings.Replace(strings.ReplaceAll(s, ".", ""), ",", ".", 1)
+		}
+	case strings.Count(s, ",") > 1:
+		s = strings.ReplaceAll(s, ",", "")
+	case strings.Count(s, ".") > 1:
+		s = strings.ReplaceAll(s, ".", "")
+	case comma >= 0:
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
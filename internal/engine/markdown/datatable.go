@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"fmt"
+	"strings"
+
+	"godocgen/internal/blocks"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tableSpec ist die YAML-Form eines ```table Blocks.
+type tableSpec struct {
+	Src     string            `yaml:"src"`
+	Columns stringList        `yaml:"columns"`
+	Headers map[string]string `yaml:"headers"`
+	Sort    stringList        `yaml:"sort"`
+	Filter  stringList        `yaml:"filter"`
+	Format  map[string]string `yaml:"format"`
+}
+
+// stringList nimmt in YAML einen einzelnen Wert oder eine Liste an.
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = stringList{value.Value}
+		return nil
+	}
+	var list []string
+	if err := value.Decode(&list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// parseDataTable wandelt einen ```table Block in einen DataTableBlock um. Der Inhalt ist leer
+// oder YAML (src, columns, headers, sort, filter, format). Attribute im Info-String überschreiben
+// ihn: {src=data/api.csv columns=name,type headers="name:Feld,type:Typ" sort=-since
+// filter="status=stable; since>=2" format="price:#,##0.00 €"}.
+func parseDataTable(content string, attrs map[string]string) (blocks.DataTableBlock, error) {
+	var table blocks.DataTableBlock
+
+	if strings.TrimSpace(content) != "" {
+		var spec tableSpec
+		if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+			return table, fmt.Errorf("ungültiges Tabellen-YAML: %w", err)
+		}
+		table = blocks.DataTableBlock{
+			Src:     spec.Src,
+			Columns: spec.Columns,
+			Headers: spec.Headers,
+			Sort:    spec.Sort,
+			Filter:  spec.Filter,
+			Format:  spec.Format,
+		}
+	}
+
+	for key, value := range attrs {
+		var err error
+		switch key {
+		case "src":
+			table.Src = value
+		case "columns":
+			table.Columns = splitList(value, ",")
+		case "sort":
+			table.Sort = splitList(value, ",")
+		case "filter":
+			table.Filter = splitList(value, ";")
+		case "headers":
+			table.Headers, err = splitPairs(value, ",")
+		case "format":
+			table.Format, err = splitPairs(value, ";")
+		default:
+			err = fmt.Errorf("unbekanntes Attribut %q", key)
+		}
+		if err != nil {
+			return table, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if table.Src == "" {
+		return table, fmt.Errorf("Tabelle ohne Datendatei (src=)")
+	}
+	return table, nil
+}
+
+// splitList zerlegt eine Attributliste und entfernt Leerraum und leere Einträge.
+func splitList(value, sep string) []string {
+	var list []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// splitPairs zerlegt eine Attributliste aus Paaren "spalte:wert".
+func splitPairs(value, sep string) (map[string]string, error) {
+	pairs := map[string]string{}
+	for _, item := range splitList(value, sep) {
+		key, val, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q ist kein Paar spalte:wert", item)
+		}
+		pairs[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return pairs, nil
+}
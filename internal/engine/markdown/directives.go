@@ -0,0 +1,99 @@
+package markdown
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// parseDirective erkennt Direktiven in HTML-Kommentaren:
+//
+//	<!-- landscape -->                      folgende Inhalte im Querformat
+//	<!-- portrait -->                       zurück ins Hochformat
+//	<!-- columns 2 gap=8 rule=true -->      mehrspaltiger Abschnitt
+//	<!-- /columns -->                       Ende des mehrspaltigen Abschnitts
+//	<!-- frontmatter -->                    Vorspann (z.B. Vorwort) mit römischen Seitenzahlen
+//	<!-- mainmatter -->                     Hauptteil, arabische Zählung beginnt bei 1
+//	<!-- appendix -->                       Anhänge, jedes Kapitel zählt eigene Seiten (A-1, B-1)
+//	<!-- keep-with-next -->                 folgender Block bleibt mit dem nächsten auf einer Seite
+//	<!-- keep-together -->                  folgende Blöcke möglichst ohne Seitenumbruch setzen
+//	<!-- /keep-together -->                 Ende der zusammenhängenden Blöcke
+//	<!-- table style=compact -->            Darstellung der folgenden Tabelle (Breiten, Stil, ...)
+func parseDirective(n *ast.HTMLBlock, source []byte) (blocks.DocBlock, bool) {
+	var text strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		text.Write(line.Value(source))
+	}
+	if n.HasClosure() {
+		text.Write(n.ClosureLine.Value(source))
+	}
+	comment := strings.TrimSpace(text.String())
+	if !strings.HasPrefix(comment, "<!--") || !strings.HasSuffix(comment, "-->") {
+		return nil, false
+	}
+	directive := strings.TrimSpace(comment[4 : len(comment)-3])
+	name, args, _ := strings.Cut(directive, " ")
+
+	switch strings.ToLower(name) {
+	case "landscape":
+		return blocks.OrientationBlock{Orientation: "landscape"}, true
+	case "portrait":
+		return blocks.OrientationBlock{Orientation: "portrait"}, true
+	case "columns":
+		return parseColumnsDirective(args), true
+	case "/columns", "end-columns":
+		return blocks.ColumnsBlock{Count: 1}, true
+	case "frontmatter":
+		return blocks.MatterBlock{Matter: "front"}, true
+	case "mainmatter":
+		return blocks.MatterBlock{Matter: "main"}, true
+	case "appendix":
+		return blocks.MatterBlock{Matter: "appendix"}, true
+	case "keep-with-next":
+		return blocks.KeepBlock{Keep: "next"}, true
+	case "keep-together":
+		return blocks.KeepBlock{Keep: "together"}, true
+	case "/keep-together", "end-keep-together":
+		return blocks.KeepBlock{Keep: "end"}, true
+	case "table":
+		return parseTableDirective(args), true
+	}
+	return nil, false
+}
+
+// parseColumnsDirective liest die Argumente von <!-- columns 2 gap=8 rule=true -->.
+// Die Spaltenzahl kann auch als count=2 angegeben werden.
+func parseColumnsDirective(args string) blocks.ColumnsBlock {
+	col := blocks.ColumnsBlock{Count: 2}
+	args = strings.TrimSpace(args)
+	if first, rest, _ := strings.Cut(args, " "); first != "" && !strings.Contains(first, "=") {
+		if n, err := strconv.Atoi(first); err == nil {
+			col.Count = n
+		} else {
+			fmt.Printf("Warnung: Spaltenanzahl %q ungültig, verwende 2 Spalten\n", first)
+		}
+		args = rest
+	}
+
+	attrs, _ := parseAttributes(args)
+	if v, ok := attrs["count"]; ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			col.Count = n
+		}
+	}
+	col.Gap = attributeFloat(attrs, "gap")
+	if v, ok := attrs["rule"]; ok {
+		rule := v == "true" || v == "on" || v == "yes" || v == "1"
+		col.Rule = &rule
+	}
+
+	if col.Count < 1 || col.Count > 4 {
+		fmt.Printf("Warnung: Spaltenanzahl %d nicht unterstützt (1-4), verwende 2 Spalten\n", col.Count)
+		col.Count = 2
+	}
+	return col
+}
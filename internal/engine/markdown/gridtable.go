@@ -0,0 +1,302 @@
+package markdown
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"godocgen/internal/blocks"
+)
+
+// Grid-Tabellen im Stil von pandoc: Zellen sind mit +, - und | umrahmt, eine Linie aus =
+// trennt die Kopfzeilen ab. Zellen können mehrere Zeilen, Listen und Code enthalten; fehlt eine
+// Trennlinie, überspannt die Zelle mehrere Spalten oder Zeilen. Doppelpunkte in der Kopftrenn-
+// linie (bzw. ohne Kopf in der obersten Linie) richten die Spalten aus.
+//
+//	+----------+-----------------+
+//	| Feld     | Beschreibung    |
+//	+==========+=================+
+//	| name     | - Pflichtfeld   |
+//	|          | - eindeutig     |
+//	+----------+-----------------+
+//	| Hinweis über beide Spalten |
+//	+----------------------------+
+
+// gridTableFence ist die Fence-Sprache, in die preprocessGridTables Grid-Tabellen einschließt.
+const gridTableFence = "gridtable"
+
+// isGridBorder prüft, ob eine Zeile eine vollständige Rahmenlinie wie "+---+:==:+" ist.
+func isGridBorder(line string) bool {
+	line = strings.TrimRight(line, " \t")
+	if len(line) < 3 || line[0] != '+' || line[len(line)-1] != '+' {
+		return false
+	}
+	return strings.Trim(line, "+-=:") == "" && strings.ContainsAny(line, "-=")
+}
+
+// preprocessGridTables schließt Grid-Tabellen in einen ```gridtable Block ein, damit goldmark sie
+// unverändert weitergibt. Tabellen in Code-Blöcken bleiben unberührt.
+func preprocessGridTables(content []byte) []byte {
+	lines := strings.Split(string(content), "\n")
+	var result []string
+	fence := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimLeft(line, " ")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(strings.TrimSpace(trimmed), fence[:1]) == "" {
+				fence = ""
+			}
+			result = append(result, line)
+			continue
+		}
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			result = append(result, line)
+			continue
+		}
+
+		if !isGridBorder(line) {
+			result = append(result, line)
+			continue
+		}
+		// Die Tabelle reicht bis zur letzten Rahmenlinie vor der ersten Zeile ohne + oder |
+		end := i
+		for j := i + 1; j < len(lines) && (strings.HasPrefix(lines[j], "+") || strings.HasPrefix(lines[j], "|")); j++ {
+			if isGridBorder(lines[j]) {
+				end = j
+			}
+		}
+		if end == i {
+			result = append(result, line)
+			continue
+		}
+		result = append(result, "```"+gridTableFence)
+		result = append(result, lines[i:end+1]...)
+		result = append(result, "```")
+		i = end
+	}
+	return []byte(strings.Join(result, "\n"))
+}
+
+// fenceMarker liefert die Zeichenfolge, mit der ein Code-Block beginnt (``` oder ~~~), sonst "".
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+// gridCell ist eine Zelle einer Grid-Tabelle mit ihren Rahmenkoordinaten (Zeile, Zeichen).
+type gridCell struct {
+	top, left, bottom, right int
+}
+
+// parseGridTable wandelt den Text einer Grid-Tabelle in einen TableBlock um. Die Zellen werden
+// wie bei docutils von ihren linken oberen Ecken aus entlang der Rahmen abgetastet.
+func parseGridTable(source string) (blocks.TableBlock, error) {
+	lines := strings.Split(strings.TrimRight(source, "\n"), "\n")
+	width := 0
+	grid := make([][]rune, len(lines))
+	for i, line := range lines {
+		grid[i] = []rune(strings.TrimRight(line, " \t"))
+		width = max(width, len(grid[i]))
+	}
+	for i := range grid {
+		for len(grid[i]) < width {
+			grid[i] = append(grid[i], ' ')
+		}
+	}
+	height := len(grid)
+	if height < 3 || width < 3 {
+		return blocks.TableBlock{}, fmt.Errorf("Grid-Tabelle ist zu klein")
+	}
+
+	// Zellen von ihren Ecken aus suchen
+	var cells []gridCell
+	done := map[[2]int]bool{}
+	corners := [][2]int{{0, 0}}
+	area := 0
+	for len(corners) > 0 {
+		corner := corners[0]
+		corners = corners[1:]
+		if done[corner] {
+			continue
+		}
+		done[corner] = true
+		cell, ok := scanGridCell(grid, corner[0], corner[1])
+		if !ok {
+			continue
+		}
+		cells = append(cells, cell)
+		area += (cell.bottom - cell.top) * (cell.right - cell.left)
+		if cell.right < width-1 {
+			corners = append(corners, [2]int{cell.top, cell.right})
+		}
+		if cell.bottom < height-1 {
+			corners = append(corners, [2]int{cell.bottom, cell.left})
+		}
+	}
+	if len(cells) == 0 || area != (height-1)*(width-1) {
+		return blocks.TableBlock{}, fmt.Errorf("Grid-Tabelle hat keinen geschlossenen Rahmen")
+	}
+
+	// Raster aus den Zellkanten: jede Kante ist eine Zeilen- bzw. Spaltengrenze
+	rowBounds := gridBounds(cells, func(c gridCell) (int, int) { return c.top, c.bottom })
+	colBounds := gridBounds(cells, func(c gridCell) (int, int) { return c.left, c.right })
+
+	// Kopftrennlinie aus =
+	headerLine := -1
+	for y, row := range grid {
+		if y > 0 && row[0] == '+' && strings.ContainsRune(string(row), '=') {
+			if headerLine >= 0 {
+				return blocks.TableBlock{}, fmt.Errorf("Grid-Tabelle hat mehr als eine Kopftrennlinie")
+			}
+			headerLine = y
+		}
+	}
+
+	// Ausrichtung aus den Doppelpunkten der Kopftrennlinie bzw. der obersten Linie
+	spec := grid[0]
+	if headerLine >= 0 {
+		spec = grid[headerLine]
+	}
+	table := blocks.TableBlock{Alignments: make([]blocks.Align, len(colBounds)-1)}
+	for i := range table.Alignments {
+		l, r := colBounds[i]+1, colBounds[i+1]-1
+		switch {
+		case spec[l] == ':' && spec[r] == ':':
+			table.Alignments[i] = blocks.AlignCenter
+		case spec[r] == ':':
+			table.Alignments[i] = blocks.AlignRight
+		}
+	}
+
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].top != cells[j].top {
+			return cells[i].top < cells[j].top
+		}
+		return cells[i].left < cells[j].left
+	})
+	table.Rows = make([][]blocks.TableRow, len(rowBounds)-1)
+	for _, c := range cells {
+		row := sort.SearchInts(rowBounds, c.top)
+		cell := gridCellContent(grid, c)
+		cell.Header = headerLine >= 0 && c.top < headerLine
+		if span := sort.SearchInts(rowBounds, c.bottom) - row; span > 1 {
+			cell.RowSpan = span
+		}
+		if span := sort.SearchInts(colBounds, c.right) - sort.SearchInts(colBounds, c.left); span > 1 {
+			cell.ColSpan = span
+		}
+		table.Rows[row] = append(table.Rows[row], cell)
+	}
+	return table, nil
+}
+
+// scanGridCell sucht die Zelle mit der linken oberen Ecke (top, left): nach rechts bis zu einer
+// Ecke, von dort nach unten, dann zurück nach links und hinauf. Ecken, an denen der Rahmen nicht
+// geschlossen ist, gehören zu Nachbarzellen; die Suche läuft dann weiter.
+func scanGridCell(grid [][]rune, top, left int) (gridCell, bool) {
+	if grid[top][left] != '+' {
+		return gridCell{}, false
+	}
+	horizontal := func(r rune) bool { return r == '-' || r == '=' || r == ':' || r == '+' }
+	for right := left + 1; right < len(grid[top]); right++ {
+		r := grid[top][right]
+		if !horizontal(r) {
+			return gridCell{}, false
+		}
+		if r != '+' {
+			continue
+		}
+		for bottom := top + 1; bottom < len(grid); bottom++ {
+			v := grid[bottom][right]
+			if v != '|' && v != '+' {
+				break
+			}
+			if v != '+' || grid[bottom][left] != '+' {
+				continue
+			}
+			closed := true
+			for x := left + 1; x < right && closed; x++ {
+				closed = horizontal(grid[bottom][x])
+			}
+			for y := top + 1; y < bottom && closed; y++ {
+				closed = grid[y][left] == '|' || grid[y][left] == '+'
+			}
+			if closed {
+				return gridCell{top: top, left: left, bottom: bottom, right: right}, true
+			}
+		}
+	}
+	return gridCell{}, false
+}
+
+// gridBounds liefert die sortierten, eindeutigen Kanten der Zellen.
+func gridBounds(cells []gridCell, edges func(gridCell) (int, int)) []int {
+	seen := map[int]bool{}
+	var bounds []int
+	for _, c := range cells {
+		a, b := edges(c)
+		for _, v := range []int{a, b} {
+			if !seen[v] {
+				seen[v] = true
+				bounds = append(bounds, v)
+			}
+		}
+	}
+	sort.Ints(bounds)
+	return bounds
+}
+
+// gridCellContent parst den Text innerhalb des Rahmens einer Zelle als Markdown. Ein einzelner
+// Absatz wird zu Fließtext der Zelle, alles andere zu Blockinhalt.
+func gridCellContent(grid [][]rune, c gridCell) blocks.TableRow {
+	var lines []string
+	indent := -1
+	for y := c.top + 1; y < c.bottom; y++ {
+		line := strings.TrimRight(string(grid[y][c.left+1:c.right]), " ")
+		lines = append(lines, line)
+		if trimmed := strings.TrimLeft(line, " "); trimmed != "" {
+			if n := len(line) - len(trimmed); indent < 0 || n < indent {
+				indent = n
+			}
+		}
+	}
+	if indent < 0 {
+		return blocks.TableRow{}
+	}
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		}
+	}
+
+	parsed, err := Parse([]byte(strings.Join(lines, "\n")), "")
+	if err != nil {
+		return blocks.TableRow{Content: []blocks.TextSegment{{Text: strings.Join(lines, " ")}}}
+	}
+	if len(parsed) == 1 {
+		if p, ok := parsed[0].(blocks.ParagraphBlock); ok {
+			return blocks.TableRow{Content: p.Content}
+		}
+	}
+
+	var content []blocks.DocBlock
+	for _, block := range parsed {
+		switch b := block.(type) {
+		case blocks.HeadingBlock:
+			// Überschriften in Zellen erscheinen nicht im Inhaltsverzeichnis
+			content = append(content, blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: b.Text, Bold: true}}})
+		case blocks.ParagraphBlock, blocks.ListBlock, blocks.CodeBlock, blocks.ImageBlock, blocks.BlockquoteBlock, blocks.TableBlock, blocks.ChartBlock:
+			content = append(content, block)
+		default:
+			fmt.Printf("Warnung: Tabellenzelle: %T wird in Zellen nicht unterstützt\n", block)
+		}
+	}
+	return blocks.TableRow{Blocks: content}
+}
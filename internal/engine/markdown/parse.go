@@ -8,6 +8,8 @@ import (
 	"unicode"
 
 	"godocgen/internal/blocks"
+	"godocgen/internal/engine/diagram"
+	"godocgen/internal/engine/hyphen"
 	"godocgen/internal/util"
 
 	"github.com/yuin/goldmark"
@@ -21,6 +23,9 @@ import (
 // nicht im Inhaltsverzeichnis erscheinen und nicht nummeriert werden soll.
 const excludeFromTOCMarker = "\x00EXCLUDE_TOC\x00"
 
+// softHyphens ersetzt manuelle Trennstellen (&shy;) im Fließtext durch weiche Trennzeichen.
+var softHyphens = strings.NewReplacer("&shy;", hyphen.SoftHyphen, "&#173;", hyphen.SoftHyphen, "&#xad;", hyphen.SoftHyphen)
+
 // preprocessExcludedHeadings wandelt die !#! Syntax in normale Headings um und fügt einen Marker hinzu.
 // Beispiel: "!#! Überschrift" -> "# \x00EXCLUDE_TOC\x00Überschrift"
 // Beispiel: "!###!Blackboxtesting" -> "### \x00EXCLUDE_TOC\x00Blackboxtesting"
@@ -47,6 +52,45 @@ func preprocessExcludedHeadings(content []byte) []byte {
 	return []byte(strings.Join(result, "\n"))
 }
 
+// standaloneImage liefert das Bild eines Absatzes, der außer Leerraum nur ein Bild enthält,
+// sowie den Inhalt einer direkt folgenden Attributliste (`![Logo](logo.png){width=40%}`).
+func standaloneImage(p *ast.Paragraph, source []byte) (*ast.Image, string) {
+	var img *ast.Image
+	var trailing strings.Builder
+	for c := p.FirstChild(); c != nil; c = c.NextSibling() {
+		switch n := c.(type) {
+		case *ast.Image:
+			if img != nil {
+				return nil, ""
+			}
+			img = n
+		case *ast.Text:
+			text := string(n.Segment.Value(source))
+			if img == nil {
+				if strings.TrimSpace(text) != "" {
+					return nil, ""
+				}
+				continue
+			}
+			trailing.WriteString(text)
+		default:
+			return nil, ""
+		}
+	}
+	if img == nil {
+		return nil, ""
+	}
+
+	rest := strings.TrimSpace(trailing.String())
+	if rest == "" {
+		return img, ""
+	}
+	if !strings.HasPrefix(rest, "{") || !strings.HasSuffix(rest, "}") {
+		return nil, ""
+	}
+	return img, rest[1 : len(rest)-1]
+}
+
 // generateAnchorID erstellt eine URL-freundliche ID aus einem Überschriftentext.
 // Beispiel: "Einführung in DocGen" -> "einführung-in-docgen"
 func generateAnchorID(text string) string {
@@ -89,6 +133,8 @@ func generateAnchorID(text string) string {
 func Parse(content []byte, parentNumbering string) ([]blocks.DocBlock, error) {
 	// Vorverarbeitung: !#! Syntax in normale Headings mit Marker umwandeln
 	processedContent := preprocessExcludedHeadings(content)
+	// Grid-Tabellen kennt goldmark nicht, sie werden als eigener Fence-Block weitergereicht
+	processedContent = preprocessGridTables(processedContent)
 
 	md := goldmark.New(
 		goldmark.WithExtensions(
@@ -128,6 +174,25 @@ func Parse(content []byte, parentNumbering string) ([]blocks.DocBlock, error) {
 			})
 			return ast.WalkSkipChildren, nil
 		case *ast.Paragraph:
+			// Ein Absatz, der nur aus einem Bild besteht, wird als eigenständiges Bild gerendert
+			if img, attrText := standaloneImage(node, processedContent); img != nil {
+				imageBlock := blocks.ImageBlock{
+					Path:  string(img.Destination),
+					Alt:   string(img.Text(processedContent)),
+					Title: string(img.Title),
+				}
+				if attrText != "" {
+					if attrs, isAttrs := parseAttributes(attrText); isAttrs {
+						for _, err := range applyImageAttributes(&imageBlock, attrs) {
+							fmt.Printf("Warnung: Bild %s: Attribut ignoriert: %v\n", imageBlock.Path, err)
+						}
+					} else {
+						fmt.Printf("Warnung: Bild %s: ungültige Attributliste {%s}\n", imageBlock.Path, attrText)
+					}
+				}
+				docBlocks = append(docBlocks, imageBlock)
+				return ast.WalkSkipChildren, nil
+			}
 			docBlocks = append(docBlocks, blocks.ParagraphBlock{
 				Content: parseTextSegments(node, processedContent),
 			})
@@ -139,20 +204,73 @@ func Parse(content []byte, parentNumbering string) ([]blocks.DocBlock, error) {
 				line := node.Lines().At(i)
 				codeContent += string(line.Value(processedContent))
 			}
-			if lang == "mermaid" {
-				title := ""
+			if lang == "chart" {
+				attrs := map[string]string{}
+				if node.Info != nil {
+					if inner, ok := extractBraces(string(node.Info.Text(processedContent))); ok {
+						if parsed, isAttrs := parseAttributes(inner); isAttrs {
+							attrs = parsed
+						} else {
+							attrs["title"] = inner
+						}
+					}
+				}
+				chart, err := parseChart(codeContent, attrs)
+				if err != nil {
+					// Ungültige Daten sollen den Build nicht abbrechen, der Hinweis erscheint im PDF
+					fmt.Printf("Warnung: Chart konnte nicht gelesen werden: %v\n", err)
+					docBlocks = append(docBlocks, blocks.ParagraphBlock{
+						Content: []blocks.TextSegment{{Text: fmt.Sprintf("[Chart ungültig: %v]", err), Italic: true}},
+					})
+				} else {
+					docBlocks = append(docBlocks, chart)
+				}
+			} else if lang == "table" {
+				attrs := map[string]string{}
+				if node.Info != nil {
+					if inner, ok := extractBraces(string(node.Info.Text(processedContent))); ok {
+						if parsed, isAttrs := parseAttributes(inner); isAttrs {
+							attrs = parsed
+						} else {
+							fmt.Printf("Warnung: Tabelle: ungültige Attributliste {%s}\n", inner)
+						}
+					}
+				}
+				table, err := parseDataTable(codeContent, attrs)
+				if err != nil {
+					// Wie bei Charts bricht eine fehlerhafte Angabe den Build nicht ab. Den übersetzten
+					// Hinweis setzt der Builder (table.invalid).
+					fmt.Printf("Warnung: Tabelle konnte nicht gelesen werden: %v\n", err)
+					table = blocks.DataTableBlock{Error: err.Error()}
+				}
+				docBlocks = append(docBlocks, table)
+			} else if lang == gridTableFence {
+				table, err := parseGridTable(codeContent)
+				if err != nil {
+					// Nicht lesbare Grid-Tabellen bleiben als Text erhalten
+					fmt.Printf("Warnung: %v, sie wird als Text gesetzt\n", err)
+					docBlocks = append(docBlocks, blocks.CodeBlock{Content: codeContent})
+				} else {
+					docBlocks = append(docBlocks, table)
+				}
+			} else if _, isDiagram := diagram.Canonical(lang); isDiagram {
+				diagramBlock := blocks.DiagramBlock{Language: lang, Content: codeContent}
 				if node.Info != nil {
 					info := string(node.Info.Text(processedContent))
-					start := strings.Index(info, "{")
-					end := strings.Index(info, "}")
-					if start != -1 && end != -1 && end > start {
-						title = info[start+1 : end]
+					if inner, ok := extractBraces(info); ok {
+						// {width=120 scale=0.7 theme=neutral title="..."} oder einfacher Titel {Mein Diagramm}
+						if attrs, isAttrs := parseAttributes(inner); isAttrs {
+							diagramBlock.Title = attrs["title"]
+							diagramBlock.Width = attributeFloat(attrs, "width")
+							diagramBlock.Scale = attributeFloat(attrs, "scale")
+							diagramBlock.Theme = attrs["theme"]
+							diagramBlock.Attributes = attrs
+						} else {
+							diagramBlock.Title = inner
+						}
 					}
 				}
-				docBlocks = append(docBlocks, blocks.MermaidBlock{
-					Content: codeContent,
-					Title:   title,
-				})
+				docBlocks = append(docBlocks, diagramBlock)
 			} else {
 				docBlocks = append(docBlocks, blocks.CodeBlock{
 					Language: lang,
@@ -173,6 +291,12 @@ func Parse(content []byte, parentNumbering string) ([]blocks.DocBlock, error) {
 		case *ast.ThematicBreak:
 			docBlocks = append(docBlocks, blocks.PageBreakBlock{})
 			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			// Direktiven wie <!-- landscape --> als HTML-Kommentar
+			if directive, ok := parseDirective(node, processedContent); ok {
+				docBlocks = append(docBlocks, directive)
+			}
+			return ast.WalkSkipChildren, nil
 		case *extAst.Table:
 			table := blocks.TableBlock{}
 			// Alignments extrahieren
@@ -245,7 +369,7 @@ func Parse(content []byte, parentNumbering string) ([]blocks.DocBlock, error) {
 		return nil, fmt.Errorf("Fehler beim Traversieren des Markdown AST: %w", err)
 	}
 
-	return docBlocks, nil
+	return attachTableOptions(docBlocks), nil
 }
 
 // parseTextSegments extrahiert Textsegmente mit Formatierungen (fett, kursiv, durchgestrichen, code) aus einem AST-Knoten.
@@ -266,6 +390,8 @@ func parseTextSegments(n ast.Node, source []byte) []blocks.TextSegment {
 					content += " "
 				}
 				content = util.FixPunctuationSpacing(content)
+				// Manuelle Trennstellen als weiche Trennzeichen übernehmen
+				content = softHyphens.Replace(content)
 				segments = append(segments, blocks.TextSegment{
 					Text:          content,
 					Bold:          isBold,
@@ -0,0 +1,157 @@
+package markdown
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"godocgen/internal/blocks"
+)
+
+// tableDirective ist eine <!-- table ... --> Direktive. Sie erscheint nicht im Dokument, sondern
+// überträgt ihre Angaben auf die direkt folgende Tabelle (attachTableOptions).
+type tableDirective struct {
+	options blocks.TableOptions
+}
+
+func (d tableDirective) IsBlock() {}
+
+// parseTableDirective liest die Argumente von
+// <!-- table widths="30%,*,25" align="l,l,d" style=compact zebra=false font-size=9 stretch=false -->.
+// Ungültige Angaben werden gemeldet und ignoriert.
+func parseTableDirective(args string) tableDirective {
+	var d tableDirective
+	attrs, ok := parseAttributes(args)
+	if !ok && strings.TrimSpace(args) != "" {
+		fmt.Printf("Warnung: Tabelle: ungültige Attributliste %q\n", args)
+		return d
+	}
+	for _, err := range applyTableAttributes(&d.options, attrs) {
+		fmt.Printf("Warnung: Tabelle: Attribut ignoriert: %v\n", err)
+	}
+	return d
+}
+
+// applyTableAttributes überträgt Tabellenattribute auf TableOptions. Unbekannte Attribute und
+// ungültige Werte werden gemeldet und ignoriert.
+func applyTableAttributes(opts *blocks.TableOptions, attrs map[string]string) []error {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		value := strings.TrimSpace(attrs[key])
+		switch key {
+		case "widths":
+			// "30%,*,25": Prozent der Textbreite, mm oder * für automatisch
+			opts.Widths = nil
+			for _, item := range strings.Split(value, ",") {
+				item = strings.TrimSpace(item)
+				if item == "" || item == "*" || item == "auto" {
+					opts.Widths = append(opts.Widths, blocks.TableWidth{})
+					continue
+				}
+				f, percent, err := attributeLength(item)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("widths: %w", err))
+					opts.Widths = append(opts.Widths, blocks.TableWidth{})
+					continue
+				}
+				opts.Widths = append(opts.Widths, blocks.TableWidth{Value: f, Percent: percent})
+			}
+		case "align":
+			opts.Align = nil
+			for _, item := range strings.Split(value, ",") {
+				align, ok := tableAlignments[strings.ToLower(strings.TrimSpace(item))]
+				if !ok {
+					errs = append(errs, fmt.Errorf("align: %q (erlaubt: left, center, right, decimal)", item))
+				}
+				opts.Align = append(opts.Align, align)
+			}
+		case "style":
+			switch strings.ToLower(value) {
+			case "default", "compact", "bordered":
+				opts.Style = strings.ToLower(value)
+			default:
+				errs = append(errs, fmt.Errorf("style: %q (erlaubt: default, compact, bordered)", value))
+			}
+		case "zebra", "stretch":
+			on, err := attributeBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			if key == "zebra" {
+				opts.Zebra = &on
+			} else {
+				opts.Stretch = &on
+			}
+		case "font-size", "font_size", "padding":
+			f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSuffix(value, "pt"), "mm"), 64)
+			if err != nil || f <= 0 {
+				errs = append(errs, fmt.Errorf("%s: ungültiger Wert %q", key, value))
+				continue
+			}
+			if key == "padding" {
+				opts.Padding = f
+			} else {
+				opts.FontSize = f
+			}
+		case "header":
+			opts.Header = value
+		default:
+			errs = append(errs, fmt.Errorf("unbekanntes Attribut %q", key))
+		}
+	}
+	return errs
+}
+
+// tableAlignments sind die Werte von align=, jeweils ausgeschrieben oder als Anfangsbuchstabe.
+var tableAlignments = map[string]blocks.Align{
+	"left": blocks.AlignLeft, "l": blocks.AlignLeft,
+	"center": blocks.AlignCenter, "c": blocks.AlignCenter,
+	"right": blocks.AlignRight, "r": blocks.AlignRight,
+	"decimal": blocks.AlignDecimal, "d": blocks.AlignDecimal,
+}
+
+// attributeBool liest einen Wahrheitswert wie true/false, on/off, yes/no oder 1/0.
+func attributeBool(v string) (bool, error) {
+	switch strings.ToLower(v) {
+	case "true", "on", "yes", "1":
+		return true, nil
+	case "false", "off", "no", "none", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q (erlaubt: true, false)", v)
+}
+
+// attachTableOptions überträgt die Angaben von <!-- table ... --> auf die direkt folgende Tabelle
+// (auch Grid- und Datentabellen) und entfernt die Direktiven aus der Blockliste.
+func attachTableOptions(docBlocks []blocks.DocBlock) []blocks.DocBlock {
+	result := docBlocks[:0]
+	for i := 0; i < len(docBlocks); i++ {
+		d, ok := docBlocks[i].(tableDirective)
+		if !ok {
+			result = append(result, docBlocks[i])
+			continue
+		}
+		if i+1 < len(docBlocks) {
+			switch next := docBlocks[i+1].(type) {
+			case blocks.TableBlock:
+				next.Options = d.options
+				docBlocks[i+1] = next
+				continue
+			case blocks.DataTableBlock:
+				next.Options = d.options
+				docBlocks[i+1] = next
+				continue
+			}
+		}
+		fmt.Println("Warnung: <!-- table --> steht nicht direkt vor einer Tabelle und wird ignoriert")
+	}
+	return result
+}
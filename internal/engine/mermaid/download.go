@@ -3,57 +3,12 @@ package mermaid
 import (
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 )
 
-const MermaidJSURL = "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.min.js"
-
-// EnsureMermaidJS stellt sicher, dass die mermaid.min.js lokal vorhanden ist.
-func EnsureMermaidJS(cacheDir string) (string, error) {
-	jsPath := filepath.Join(cacheDir, "mermaid", "mermaid.min.js")
-
-	// Prüfe, ob die Datei bereits existiert
-	if _, err := os.Stat(jsPath); err == nil {
-		return jsPath, nil
-	}
-
-	// Verzeichnis erstellen
-	if err := os.MkdirAll(filepath.Dir(jsPath), 0755); err != nil {
-		return "", fmt.Errorf("Mermaid-Cache-Verzeichnis konnte nicht erstellt werden: %w", err)
-	}
-
-	fmt.Printf("Lade Mermaid JS herunter (%s)...\n", MermaidJSURL)
-
-	// Download ausführen
-	resp, err := http.Get(MermaidJSURL)
-	if err != nil {
-		return "", fmt.Errorf("Fehler beim Herunterladen von Mermaid JS: %w (Internetverbindung erforderlich)", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("Fehler beim Herunterladen von Mermaid JS: Statuscode %d", resp.StatusCode)
-	}
-
-	// In Datei speichern
-	out, err := os.Create(jsPath)
-	if err != nil {
-		return "", fmt.Errorf("Mermaid JS-Datei konnte nicht erstellt werden: %w", err)
-	}
-	defer out.Close()
-
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("Mermaid JS-Datei konnte nicht gespeichert werden: %w", err)
-	}
-
-	return jsPath, nil
-}
-
 // EnsureMmdc stellt sicher, dass die Mermaid CLI (mmdc) vorhanden ist und funktioniert.
 func EnsureMmdc(cacheDir string) (string, error) {
 	// 1. Prüfen, ob mmdc bereits im PATH ist und funktioniert
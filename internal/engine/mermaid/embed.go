@@ -0,0 +1,46 @@
+package mermaid
+
+import (
+	"embed"
+	"fmt"
+	"os"
+)
+
+//go:generate go run fetch_mermaid.go
+
+// MermaidVersion ist die gepinnte Version der eingebetteten mermaid.min.js.
+const MermaidVersion = "10.9.3"
+
+// embeddedScriptPath ist der Pfad der Mermaid-Bibliothek innerhalb von assets.
+const embeddedScriptPath = "assets/mermaid.min.js"
+
+//go:embed assets
+var assets embed.FS
+
+// LoadScript liefert den Quelltext der Mermaid-Bibliothek.
+// Ist override gesetzt (projektlokale Datei), wird diese anstelle der eingebetteten Version genutzt.
+// Es findet in keinem Fall ein Netzwerkzugriff statt.
+func LoadScript(override string) ([]byte, error) {
+	if override != "" {
+		data, err := os.ReadFile(override)
+		if err != nil {
+			return nil, fmt.Errorf("Projektlokale Mermaid-Bibliothek konnte nicht gelesen werden: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := assets.ReadFile(embeddedScriptPath)
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("Mermaid JS ist nicht im Binary eingebettet (go generate ./internal/engine/mermaid ausführen oder mermaid.script konfigurieren)")
+	}
+	return data, nil
+}
+
+// EmbeddedVersion gibt die Version der eingebetteten Mermaid-Bibliothek zurück
+// oder einen Hinweis, falls keine Bibliothek eingebettet wurde.
+func EmbeddedVersion() string {
+	if data, err := assets.ReadFile(embeddedScriptPath); err == nil && len(data) > 0 {
+		return MermaidVersion
+	}
+	return MermaidVersion + " (nicht eingebettet)"
+}
@@ -0,0 +1,53 @@
+//go:build ignore
+
+// fetch_mermaid lädt die gepinnte mermaid.min.js nach assets/ herunter.
+// Aufruf über: go generate ./internal/engine/mermaid
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"godocgen/internal/engine/mermaid"
+)
+
+func main() {
+	version := mermaid.MermaidVersion
+	url := fmt.Sprintf("https://cdn.jsdelivr.net/npm/mermaid@%s/dist/mermaid.min.js", version)
+	target := filepath.Join("assets", "mermaid.min.js")
+
+	fmt.Printf("Lade Mermaid JS %s herunter (%s)...\n", version, url)
+	resp, err := http.Get(url)
+	if err != nil {
+		fail(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		fail(fmt.Errorf("Statuscode %d", resp.StatusCode))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		fail(err)
+	}
+
+	// Plausibilitätsprüfung, damit keine Fehlerseite eingebettet wird
+	if !bytes.Contains(data, []byte("mermaid")) {
+		fail(fmt.Errorf("Antwort enthält keine Mermaid-Bibliothek"))
+	}
+
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		fail(err)
+	}
+	fmt.Printf("%s gespeichert (%d Bytes)\n", target, len(data))
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "Mermaid JS konnte nicht geladen werden: %v\n", err)
+	os.Exit(1)
+}
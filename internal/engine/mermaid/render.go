@@ -4,36 +4,131 @@ package mermaid
 import (
 	"context"
 	"encoding/json"
+	"godocgen/internal/engine/diagram"
 	"godocgen/internal/util"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/chromedp/chromedp"
 )
 
-// Render versucht ein Mermaid-Diagramm zu rendern.
-// Es nutzt mmdc (Mermaid CLI), falls installiert, ansonsten erfolgt ein Fallback auf ChromeDP.
-func Render(content string, cacheDir string) (string, string, error) {
-	hash := util.HashString(content)
-	svgPath := filepath.Join(cacheDir, "mermaid", hash+".svg")
-	pngPath := filepath.Join(cacheDir, "mermaid", hash+".png")
-
-	// Cache-Prüfung
-	if _, err := os.Stat(svgPath); err == nil {
-		if _, err := os.Stat(pngPath); err == nil {
-			return svgPath, pngPath, nil
-		}
+// Options steuert das Rendern von Mermaid-Diagrammen.
+type Options struct {
+	ScriptPath string         // Optionale projektlokale mermaid.min.js (leer = eingebettete Version)
+	Config     map[string]any // Mermaid-Konfiguration (theme, themeVariables, ...), siehe BuildConfig
+}
+
+// WithTheme gibt eine Kopie der Optionen zurück, deren Mermaid-Theme überschrieben ist.
+// Die abgeleiteten themeVariables gelten nur für das Theme "base" und werden sonst entfernt.
+func (o Options) WithTheme(theme string) Options {
+	if theme == "" {
+		return o
+	}
+	cfg := make(map[string]any, len(o.Config)+1)
+	for k, v := range o.Config {
+		cfg[k] = v
+	}
+	cfg["theme"] = theme
+	if theme != "base" {
+		delete(cfg, "themeVariables")
+	}
+	o.Config = cfg
+	return o
+}
+
+// configJSON serialisiert die Mermaid-Konfiguration (leeres Objekt, falls keine gesetzt ist).
+func (o Options) configJSON() string {
+	if len(o.Config) == 0 {
+		return "{}"
+	}
+	data, err := json.Marshal(o.Config)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// Render versucht ein einzelnes Mermaid-Diagramm zu rendern.
+// Für mehrere Diagramme sollte ein Renderer verwendet werden, der den Browser wiederverwendet.
+func Render(content string, cacheDir string, opts Options) (string, string, error) {
+	r := NewRenderer(cacheDir, 1, opts)
+	defer r.Close()
+	return r.Render(diagram.Request{Content: content})
+}
+
+// Renderer rendert Mermaid-Diagramme und teilt sich dabei eine headless Chrome-Instanz
+// sowie die mmdc-Erkennung über alle Diagramme eines Builds.
+// Render ist nebenläufig nutzbar, die Anzahl gleichzeitig geöffneter Tabs ist begrenzt.
+// Renderer implementiert diagram.Renderer.
+type Renderer struct {
+	cacheDir string
+	opts     Options
+	cache    *diagram.Cache
+	slots    chan struct{} // Begrenzt gleichzeitige Render-Vorgänge
+
+	mmdcOnce sync.Once
+	mmdcPath string
+	mmdcErr  error
+
+	browserOnce   sync.Once
+	browserCtx    context.Context
+	browserErr    error
+	cancelAlloc   context.CancelFunc
+	cancelBrowser context.CancelFunc
+}
+
+// NewRenderer erstellt einen Renderer mit maximal workers gleichzeitigen Render-Vorgängen.
+func NewRenderer(cacheDir string, workers int, opts Options) *Renderer {
+	if workers < 1 {
+		workers = 1
 	}
+	return &Renderer{
+		cacheDir: cacheDir,
+		opts:     opts,
+		cache:    diagram.NewCache(cacheDir, "mermaid"),
+		slots:    make(chan struct{}, workers),
+	}
+}
+
+// Tool gibt den Namen des bevorzugten Werkzeugs zurück.
+func (r *Renderer) Tool() string { return "mmdc" }
+
+// Close beendet die gemeinsam genutzte Chrome-Instanz, sofern sie gestartet wurde.
+func (r *Renderer) Close() {
+	if r.cancelBrowser != nil {
+		r.cancelBrowser()
+	}
+	if r.cancelAlloc != nil {
+		r.cancelAlloc()
+	}
+}
 
-	os.MkdirAll(filepath.Dir(svgPath), 0755)
+// Render rendert ein Mermaid-Diagramm und gibt die Pfade zu SVG und PNG zurück.
+// Es nutzt mmdc (Mermaid CLI), falls installiert, ansonsten erfolgt ein Fallback auf ChromeDP.
+func (r *Renderer) Render(req diagram.Request) (string, string, error) {
+	opts := r.opts.WithTheme(req.Theme)
+	// Die Konfiguration fließt in den Cache-Schlüssel ein, damit Theme-Änderungen neu rendern
+	key := req.Content + "\x00" + opts.configJSON()
+	return r.cache.Get(key, func(svgPath, pngPath string) (string, string, error) {
+		return r.render(req.Content, svgPath, pngPath, opts)
+	})
+}
+
+// render führt den eigentlichen Render-Vorgang aus.
+func (r *Renderer) render(content, svgPath, pngPath string, opts Options) (string, string, error) {
+	r.slots <- struct{}{}
+	defer func() { <-r.slots }()
 
 	// Versuche mmdc (schneller und bessere Qualität)
-	mmdcPath, err := EnsureMmdc(cacheDir)
+	r.mmdcOnce.Do(func() {
+		r.mmdcPath, r.mmdcErr = EnsureMmdc(r.cacheDir)
+	})
+	err := r.mmdcErr
 	if err == nil {
-		err = renderWithMmdc(mmdcPath, content, svgPath, pngPath)
+		err = renderWithMmdc(r.mmdcPath, content, svgPath, pngPath, opts)
 		if err == nil {
 			return svgPath, pngPath, nil
 		}
@@ -41,7 +136,7 @@ func Render(content string, cacheDir string) (string, string, error) {
 
 	// Fallback auf ChromeDP (benötigt installierten Chrome/Chromium)
 	fmt.Printf("Warnung: mmdc fehlgeschlagen oder nicht installiert, nutze ChromeDP für Mermaid: %v\n", err)
-	err = renderWithChrome(content, pngPath, cacheDir)
+	err = r.renderWithChrome(content, pngPath, opts)
 	if err != nil {
 		return "", "", fmt.Errorf("Mermaid-Rendering fehlgeschlagen (mmdc und chromedp): %w", err)
 	}
@@ -51,39 +146,61 @@ func Render(content string, cacheDir string) (string, string, error) {
 }
 
 // renderWithMmdc nutzt die Mermaid CLI (mmdc) zum Rendern.
-func renderWithMmdc(mmdcPath, content string, svgPath, pngPath string) error {
-	hash := util.HashString(content)
-	tmpFile := filepath.Join(os.TempDir(), hash+".mmd")
-	err := os.WriteFile(tmpFile, []byte(content), 0644)
+func renderWithMmdc(mmdcPath, content string, svgPath, pngPath string, opts Options) error {
+	tmpFile, err := diagram.WriteTempFile("*.mmd", content)
 	if err != nil {
 		return err
 	}
 	defer os.Remove(tmpFile)
 
-	_, err = util.RunCommand(mmdcPath, "-i", tmpFile, "-o", svgPath, "-b", "transparent")
+	cfgFile, err := diagram.WriteTempFile("*.mermaid.json", opts.configJSON())
+	if err != nil {
+		return err
+	}
+	defer os.Remove(cfgFile)
+
+	_, err = util.RunCommand(mmdcPath, "-i", tmpFile, "-o", svgPath, "-b", "transparent", "-c", cfgFile)
 	if err != nil {
 		return err
 	}
 
-	_, err = util.RunCommand(mmdcPath, "-i", tmpFile, "-o", pngPath, "-b", "transparent", "--scale", "8")
+	// Das PDF bettet das SVG als Vektorgrafik ein, das PNG ist nur noch Fallback
+	_, err = util.RunCommand(mmdcPath, "-i", tmpFile, "-o", pngPath, "-b", "transparent", "-c", cfgFile, "--scale", "3")
 	return err
 }
 
-// renderWithChrome nutzt einen headless Browser (via ChromeDP) zum Rendern.
-func renderWithChrome(content string, outputPath string, cacheDir string) error {
-	// Lokales Mermaid JS sicherstellen
-	jsPath, err := EnsureMermaidJS(cacheDir)
-	var scriptContent []byte
-	if err == nil {
-		scriptContent, _ = os.ReadFile(jsPath)
-	}
+// browser startet die gemeinsam genutzte headless Chrome-Instanz beim ersten Aufruf.
+func (r *Renderer) browser() (context.Context, error) {
+	r.browserOnce.Do(func() {
+		// ChromeDP Optionen
+		allocOpts := append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.DisableGPU,
+			chromedp.NoSandbox,
+			chromedp.Flag("force-device-scale-factor", "4"), // Erhöhe Pixeldichte für schärfere Screenshots
+		)
 
-	jsScript := ""
-	if len(scriptContent) > 0 {
-		jsScript = "<script>" + string(scriptContent) + "</script>"
-	} else {
-		jsScript = `<script src="https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.min.js"></script>`
+		allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), allocOpts...)
+		browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+		r.cancelAlloc, r.cancelBrowser = cancelAlloc, cancelBrowser
+
+		// Browser einmalig starten, weitere Diagramme öffnen nur neue Tabs
+		if err := chromedp.Run(browserCtx); err != nil {
+			r.browserErr = fmt.Errorf("Chrome konnte nicht gestartet werden: %w", err)
+			return
+		}
+		r.browserCtx = browserCtx
+	})
+	return r.browserCtx, r.browserErr
+}
+
+// renderWithChrome nutzt einen Tab der gemeinsamen headless Chrome-Instanz zum Rendern.
+// Die Mermaid-Bibliothek wird inline eingebettet, es wird kein Netzwerkzugriff benötigt.
+func (r *Renderer) renderWithChrome(content string, outputPath string, opts Options) error {
+	scriptContent, err := LoadScript(opts.ScriptPath)
+	if err != nil {
+		return err
 	}
+	jsScript := "<script>" + string(scriptContent) + "</script>"
 
 	encodedContent, _ := json.Marshal(content)
 	html := `
@@ -103,54 +220,61 @@ func renderWithChrome(content string, outputPath string, cacheDir string) error
     <script>
         async function render() {
             try {
-                mermaid.initialize({ startOnLoad: false, theme: 'default' });
+                mermaid.initialize(Object.assign({ theme: 'default' }, {{CONFIG}}, { startOnLoad: false }));
                 const { svg } = await mermaid.render('mermaid-svg', {{CONTENT}});
                 document.getElementById('container').innerHTML = svg;
-                window.mermaidReady = true;
+                // Warten, bis Schriften geladen sind und das SVG gezeichnet wurde
+                await document.fonts.ready;
+                await new Promise(resolve => requestAnimationFrame(() => requestAnimationFrame(resolve)));
             } catch (e) {
                 document.getElementById('container').innerHTML = 'Error: ' + e.message;
                 window.mermaidError = e.message;
             }
+            window.mermaidDone = true;
         }
         render();
     </script>
 </body>
 </html>
 `
-	html = strings.Replace(html, "{{MERMAID_JS}}", jsScript, 1)
+	html = strings.Replace(html, "{{CONFIG}}", opts.configJSON(), 1)
 	html = strings.Replace(html, "{{CONTENT}}", string(encodedContent), 1)
+	html = strings.Replace(html, "{{MERMAID_JS}}", jsScript, 1)
 
-	tmpFile := filepath.Join(os.TempDir(), util.HashString(content)+".html")
-	if err := os.WriteFile(tmpFile, []byte(html), 0644); err != nil {
+	tmpFile, err := diagram.WriteTempFile("*.html", html)
+	if err != nil {
 		return err
 	}
 	defer os.Remove(tmpFile)
 
-	// ChromeDP Optionen
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.DisableGPU,
-		chromedp.NoSandbox,
-		chromedp.Flag("force-device-scale-factor", "4"), // Erhöhe Pixeldichte für schärfere Screenshots
-	)
-
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer cancel()
+	browserCtx, err := r.browser()
+	if err != nil {
+		return err
+	}
 
-	ctx, cancel := chromedp.NewContext(allocCtx)
+	tabCtx, cancel := chromedp.NewContext(browserCtx)
 	defer cancel()
 
-	ctx, cancel = context.WithTimeout(ctx, 45*time.Second)
+	tabCtx, cancel = context.WithTimeout(tabCtx, 45*time.Second)
 	defer cancel()
 
-	var buf []byte
-	if err := chromedp.Run(ctx,
+	// Bereitschaftssignal der Seite abwarten statt einer festen Wartezeit
+	var done bool
+	var renderErr string
+	if err := chromedp.Run(tabCtx,
 		chromedp.Navigate("file://"+tmpFile),
-		chromedp.WaitVisible("#container svg", chromedp.ByQuery),
-		// Zusätzliche Zeit für finales Rendering (manchmal braucht Mermaid nach dem Einfügen des SVGs noch kurz)
-		chromedp.Sleep(1000*time.Millisecond),
-		chromedp.Screenshot("#container", &buf, chromedp.ByID),
+		chromedp.Poll("window.mermaidDone === true", &done, chromedp.WithPollingInterval(50*time.Millisecond)),
+		chromedp.Evaluate("window.mermaidError || ''", &renderErr),
 	); err != nil {
-		return fmt.Errorf("ChromeDP Fehler: %w (Prüfen Sie Ihre Internetverbindung)", err)
+		return fmt.Errorf("ChromeDP Fehler: %w", err)
+	}
+	if renderErr != "" {
+		return fmt.Errorf("Mermaid-Syntaxfehler: %s", renderErr)
+	}
+
+	var buf []byte
+	if err := chromedp.Run(tabCtx, chromedp.Screenshot("#container", &buf, chromedp.ByID)); err != nil {
+		return fmt.Errorf("ChromeDP Fehler: %w", err)
 	}
 
 	if len(buf) == 0 {
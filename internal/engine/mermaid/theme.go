@@ -0,0 +1,112 @@
+package mermaid
+
+import (
+	"encoding/json"
+	"fmt"
+	"godocgen/internal/config"
+	"os"
+	"strconv"
+)
+
+// BuildConfig erzeugt die Mermaid-Konfiguration (theme, themeVariables, ...) für ein Projekt.
+// Ist configPath gesetzt, wird die mermaid-config.json des Projekts unverändert übernommen.
+// Bei theme "auto" werden die themeVariables aus der Farbpalette des Dokuments abgeleitet,
+// ansonsten wird das angegebene Mermaid-Theme (default, neutral, dark, forest, base) genutzt.
+func BuildConfig(colors config.Colors, theme string, configPath string) (map[string]any, error) {
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("Mermaid-Konfiguration konnte nicht gelesen werden: %w", err)
+		}
+		cfg := map[string]any{}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("Mermaid-Konfiguration ist kein gültiges JSON: %w", err)
+		}
+		return cfg, nil
+	}
+
+	if theme != "" && theme != "auto" {
+		return map[string]any{"theme": theme}, nil
+	}
+
+	return map[string]any{
+		"theme":          "base",
+		"themeVariables": ThemeVariables(colors),
+	}, nil
+}
+
+// ThemeVariables leitet Mermaid-themeVariables aus den Dokumentfarben ab.
+// Diagramme werden im PDF auf einem hellen Container platziert, daher werden Flächen
+// als helle Tönungen der Theme-Farben und Texte immer in einer dunklen Farbe gesetzt.
+func ThemeVariables(colors config.Colors) map[string]string {
+	text := colors.Text
+	if text == "" || luminance(text) > 0.5 {
+		text = "#333333"
+	}
+	primary := fallbackColor(colors.Title, "#1e66f5")
+	accent := fallbackColor(colors.Accent, primary)
+	header := fallbackColor(colors.Header, primary)
+
+	return map[string]string{
+		"background":           "#ffffff",
+		"fontFamily":           "Arial, sans-serif",
+		"primaryColor":         tint(primary, 0.85),
+		"primaryBorderColor":   primary,
+		"primaryTextColor":     text,
+		"secondaryColor":       tint(accent, 0.85),
+		"secondaryBorderColor": accent,
+		"secondaryTextColor":   text,
+		"tertiaryColor":        tint(header, 0.9),
+		"tertiaryBorderColor":  header,
+		"tertiaryTextColor":    text,
+		"lineColor":            primary,
+		"textColor":            text,
+		"noteBkgColor":         tint(accent, 0.9),
+		"noteBorderColor":      accent,
+		"noteTextColor":        text,
+	}
+}
+
+// fallbackColor gibt hex zurück, sofern es ein gültiger Farbcode ist, sonst def.
+func fallbackColor(hex, def string) string {
+	if _, _, _, ok := parseHex(hex); ok {
+		return hex
+	}
+	return def
+}
+
+// tint mischt eine Farbe mit Weiß (amount 0 = Originalfarbe, 1 = Weiß).
+func tint(hex string, amount float64) string {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return "#ffffff"
+	}
+	mix := func(c int) int {
+		return int(float64(c) + (255-float64(c))*amount)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(r), mix(g), mix(b))
+}
+
+// luminance berechnet die relative Helligkeit einer Farbe (0 = schwarz, 1 = weiß).
+func luminance(hex string) float64 {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return 0
+	}
+	return (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 255
+}
+
+// parseHex wandelt einen Hex-Farbcode (#rrggbb) in RGB-Werte um.
+func parseHex(hex string) (int, int, int, bool) {
+	if len(hex) == 7 && hex[0] == '#' {
+		hex = hex[1:]
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff), true
+}
@@ -2,9 +2,14 @@
 package pdf
 
 import (
+	"bytes"
 	"fmt"
 	"godocgen/internal/blocks"
+	"godocgen/internal/engine/code"
+	"godocgen/internal/engine/svg"
+	"godocgen/internal/i18n"
 	"godocgen/internal/util"
+	"math"
 	"strings"
 	"unicode"
 )
@@ -106,6 +111,18 @@ func (g *Generator) renderBlock(block blocks.DocBlock, isMeasurement bool) {
 	// könnte fixPunctuationSpacing doppelt angewendet werden.
 	// Das ist bei unserer Regex-Logik aber unproblematisch (idempotent).
 
+	// Nur Absätze und Listen umfließen ein Bild, alle anderen Blöcke beginnen darunter
+	if g.float != nil {
+		switch block.(type) {
+		case blocks.ParagraphBlock, blocks.ListBlock:
+			if g.pdf.GetY() >= g.float.bottom {
+				g.endFloat(false)
+			}
+		default:
+			g.endFloat(true)
+		}
+	}
+
 	switch b := block.(type) {
 	case blocks.HeadingBlock:
 		g.renderHeading(b, isMeasurement)
@@ -115,16 +132,24 @@ func (g *Generator) renderBlock(block blocks.DocBlock, isMeasurement bool) {
 		g.renderCode(b)
 	case blocks.ImageBlock:
 		g.renderImage(b)
-	case blocks.MermaidBlock:
-		// Mermaid-Blöcke wurden bereits im Builder zu PNGs umgewandelt.
+	case blocks.DiagramBlock:
+		// Diagramm-Blöcke wurden bereits im Builder zu PNGs umgewandelt.
+	case blocks.ChartBlock:
+		g.renderChart(b)
 	case blocks.ListBlock:
 		g.renderList(b)
 	case blocks.TableBlock:
-		g.renderTable(b)
+		g.renderTable(b, isMeasurement)
 	case blocks.BlockquoteBlock:
 		g.renderBlockquote(b)
 	case blocks.PageBreakBlock:
-		g.pdf.AddPage()
+		g.addPage()
+	case blocks.OrientationBlock:
+		g.setOrientation(b.Orientation)
+	case blocks.ColumnsBlock:
+		g.renderColumns(b, isMeasurement)
+	case blocks.MatterBlock:
+		g.renderMatter(b)
 	}
 }
 
@@ -156,6 +181,16 @@ func (g *Generator) safeSetFont(family string, style string, size float64) {
 // renderHeading rendert eine Überschrift mit automatischer Nummerierung und Inhaltsverzeichniseintrag.
 // Überschriften mit ExcludeFromTOC=true werden ohne Nummerierung gerendert und nicht im TOC angezeigt.
 func (g *Generator) renderHeading(h blocks.HeadingBlock, isMeasurement bool) {
+	// Im Anhang beginnt jedes Kapitel eine neue Seite mit eigener Zählung (A-1, B-1, ...)
+	if g.appendix && h.Level == 1 {
+		g.startAppendixChapter()
+	}
+
+	// Im Duplex-Modus beginnen Kapitel (H1) auf einer rechten Seite
+	if g.cfg.Layout.Duplex && h.Level == 1 && !(g.atPageTop() && g.pdf.PageNo()%2 == 1) {
+		g.addRightPage()
+	}
+
 	// Heading-Zähler nur erhöhen wenn die Überschrift NICHT vom TOC ausgeschlossen ist
 	// So wird die Nummerierung für normale Überschriften nicht beeinflusst
 	if !h.ExcludeFromTOC {
@@ -222,6 +257,21 @@ func (g *Generator) renderHeading(h blocks.HeadingBlock, isMeasurement bool) {
 		numbering += " "
 	}
 
+	size := 14.0
+	spacing := 3.0
+	if h.Level == 1 {
+		size = 22.0
+		spacing = 10.0
+	} else if h.Level == 2 {
+		size = 18.0
+		spacing = 5.0
+	}
+
+	// Überschriften bleiben mit dem Anfang des folgenden Blocks auf einer Seite
+	displayText := numbering + text
+	g.safeSetFont("main", "B", size)
+	g.keepWithNext(spacing+g.headingHeight(displayText, h.Level)+3, g.following)
+
 	link := g.pdf.AddLink()
 
 	// Anchor-Link registrieren für interne Verlinkungen
@@ -250,18 +300,7 @@ func (g *Generator) renderHeading(h blocks.HeadingBlock, isMeasurement bool) {
 	}
 	g.pdf.SetLink(link, g.pdf.GetY(), -1)
 
-	size := 14.0
-	spacing := 3.0
-	if h.Level == 1 {
-		size = 22.0
-		spacing = 10.0
-	} else if h.Level == 2 {
-		size = 18.0
-		spacing = 5.0
-	}
-
-	// Bessere Seitenumbrüche für Überschriften (verhindert Orphan-Headings)
-	g.checkPageBreak(size + spacing + 20)
+	g.updateRunningHead(h, numbering, text, isMeasurement)
 
 	g.pdf.Ln(spacing)
 	g.safeSetFont("main", "B", size)
@@ -279,11 +318,22 @@ func (g *Generator) renderHeading(h blocks.HeadingBlock, isMeasurement bool) {
 		g.pdf.SetX(left + 5)
 	}
 
-	displayText := numbering + text
 	g.pdf.MultiCell(0, 10, g.prepareText(displayText), "", "L", false)
 	g.pdf.Ln(3)
 }
 
+// headingHeight liefert die Höhe des Überschriftentexts in der aktuell gesetzten Schrift.
+func (g *Generator) headingHeight(text string, level int) float64 {
+	left, _, right, _ := g.pdf.GetMargins()
+	pageW, _ := g.pdf.GetPageSize()
+	width := pageW - left - right - 2*g.pdf.GetCellMargin()
+	if level == 1 {
+		width -= 5 // Akzentbalken
+	}
+	lines := math.Ceil(g.pdf.GetStringWidth(g.prepareText(text)) / width)
+	return 10 * math.Max(lines, 1)
+}
+
 // splitNumberingAndText extrahiert eine führende Nummerierung und den restlichen Text.
 func splitNumberingAndText(s string) (string, string) {
 	s = strings.TrimSpace(s)
@@ -316,11 +366,6 @@ func trimLeadingNumbering(s string) string {
 	return text
 }
 
-// safeWrite schreibt Text sicher in das PDF und fängt Panics der PDF-Bibliothek ab.
-func (g *Generator) safeWrite(size float64, text string, family string, style string, link string) {
-	g.safeWriteWithFontSize(size, text, family, style, link, g.cfg.FontSize)
-}
-
 // safeWriteWithFontSize schreibt Text sicher in das PDF mit einer spezifischen Schriftgröße.
 func (g *Generator) safeWriteWithFontSize(size float64, text string, family string, style string, link string, fontSize float64) {
 	if text == "" {
@@ -385,104 +430,12 @@ func (g *Generator) getLineHeight() float64 {
 	return lh
 }
 
-// renderParagraph rendert einen Textabsatz mit Unterstützung für Fett, Kursiv, Durchgestrichen und Inline-Code.
+// renderParagraph rendert einen Textabsatz mit Unterstützung für Fett, Kursiv, Durchgestrichen und Inline-Code
+// in der konfigurierten Ausrichtung.
 func (g *Generator) renderParagraph(p blocks.ParagraphBlock) {
-	g.safeSetFont("main", "", g.cfg.FontSize)
-	g.setPrimaryTextColor()
-
-	lineHeight := g.getLineHeight()
-
-	// Prüfen, ob der Absatz Formatierungen enthält
-	hasFormatting := false
-	fullText := ""
-	for _, seg := range p.Content {
-		if seg.Bold || seg.Italic || seg.Strikethrough || seg.Code || seg.Link != "" {
-			hasFormatting = true
-		}
-		fullText += seg.Text
-	}
-
-	// Wenn keine Formatierung vorhanden ist, nutzen wir MultiCell für die konfigurierte Ausrichtung
-	if !hasFormatting {
-		align := g.getAlign(g.cfg.Layout.Body)
-		// Wir verwenden echten Blocksatz für Paragraphen, wenn "justify" eingestellt ist
-		g.pdf.MultiCell(0, lineHeight, g.prepareText(fullText), "", align, false)
-		g.pdf.Ln(2)
-		return
-	}
-
 	g.fixSegmentSpacing(p.Content)
-	for _, seg := range p.Content {
-		style := ""
-		if seg.Bold {
-			style += "B"
-		}
-		if seg.Italic {
-			style += "I"
-		}
-
-		if seg.Code {
-			fontFamily := "main"
-			if g.cfg.Fonts.Mono != "" {
-				fontFamily = "mono"
-			}
-			// Inline-Code verwendet eine etwas kleinere Schriftgröße als normaler Text
-			codeFontSize := g.cfg.Code.FontSize
-			if codeFontSize <= 0 {
-				codeFontSize = g.cfg.FontSize * 0.9 // 90% der normalen Schriftgröße für bessere Lesbarkeit
-			}
-			g.safeSetFont(fontFamily, "", codeFontSize)
-
-			if seg.Text != "" {
-				// Berechne Textbreite für den Hintergrund-Chip
-				textWidth := g.pdf.GetStringWidth(g.prepareText(seg.Text))
-				chipPaddingH := 2.5              // Horizontales Padding (links und rechts)
-				chipPaddingV := 1.2              // Vertikales Padding (oben und unten)
-				chipHeight := codeFontSize * 0.5 // Höhe basierend auf Schriftgröße
-
-				// Position merken
-				startX := g.pdf.GetX()
-				startY := g.pdf.GetY()
-
-				// Moderner Hintergrund-Chip (sanftes Grau, ohne sichtbaren Rahmen)
-				g.pdf.SetFillColor(243, 244, 246)                          // Sanftes Grau (wie Tailwind gray-100)
-				chipY := startY + (lineHeight-chipHeight-2*chipPaddingV)/2 // Vertikal zentrieren
-				g.pdf.RoundedRect(startX, chipY, textWidth+2*chipPaddingH, chipHeight+2*chipPaddingV, 1.5, "1234", "F")
-
-				// Text auf dem Chip schreiben
-				g.pdf.SetX(startX + chipPaddingH)
-				g.pdf.SetTextColor(55, 65, 81) // Dunkles Grau für Code-Text (wie Tailwind gray-700)
-				codeLineHeight := codeFontSize * 0.5
-				g.safeWriteWithFontSize(codeLineHeight, seg.Text, fontFamily, "", seg.Link, codeFontSize)
-
-				// Position nach dem Chip setzen (mit etwas Abstand)
-				g.pdf.SetX(startX + textWidth + 2*chipPaddingH + 1.5)
-
-				// Textfarbe zurücksetzen für nachfolgenden Text
-				g.setPrimaryTextColor()
-			}
-		} else {
-			g.safeSetFont("main", style, g.cfg.FontSize)
-			if seg.Text != "" {
-				// Strikethrough: Position vor dem Text merken
-				startX := g.pdf.GetX()
-				startY := g.pdf.GetY()
-
-				g.safeWrite(lineHeight, seg.Text, "main", style, seg.Link)
-
-				// Strikethrough-Linie zeichnen
-				if seg.Strikethrough {
-					endX := g.pdf.GetX()
-					// Linie in der Mitte des Textes zeichnen
-					strikeY := startY + g.cfg.FontSize*0.35
-					g.pdf.SetDrawColor(0, 0, 0)
-					g.pdf.SetLineWidth(0.3)
-					g.pdf.Line(startX, strikeY, endX, strikeY)
-				}
-			}
-		}
-	}
-	g.pdf.Ln(lineHeight + 2)
+	g.renderSegments(p.Content, 0, g.getLineHeight(), g.getAlign(g.cfg.Layout.Body))
+	g.pdf.Ln(2)
 }
 
 // calculateCodeFontSize berechnet die optimale Schriftgröße für einen Code-Block
@@ -563,8 +516,35 @@ func (g *Generator) calculateCodeFontSize(lineCount int, maxLineLen int) float64
 
 // coloredSegment repräsentiert ein Textsegment mit Farbinformation für Syntax-Highlighting.
 type coloredSegment struct {
-	text  string
-	color string
+	text      string
+	color     string
+	bgColor   string // Optionale Hintergrundfarbe (ANSI)
+	bold      bool
+	underline bool
+}
+
+// highlightPrompt färbt ein führendes Prompt-Präfix (z.B. "$ ") einer Terminal-Zeile ein.
+func highlightPrompt(line []coloredSegment, prompt, color string) []coloredSegment {
+	text := ""
+	for _, seg := range line {
+		text += seg.text
+	}
+	if prompt == "" || !strings.HasPrefix(text, prompt) {
+		return line
+	}
+
+	result := []coloredSegment{{text: prompt, color: color, bold: true}}
+	remaining := len(prompt)
+	for _, seg := range line {
+		if remaining >= len(seg.text) {
+			remaining -= len(seg.text)
+			continue
+		}
+		seg.text = seg.text[remaining:]
+		remaining = 0
+		result = append(result, seg)
+	}
+	return result
 }
 
 // renderCode rendert einen Codeblock mit Syntax-Highlighting und abgerundeten Ecken.
@@ -575,8 +555,13 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 		fontFamily = "mono"
 	}
 
+	// Terminal-Ausgaben (```ansi / ```console) erhalten einen dunklen Container
+	isTerminal := code.IsTerminalLanguage(c.Language)
+
 	bgR, bgG, bgB := 245, 245, 245
-	if c.BgColor != "" {
+	if isTerminal {
+		bgR, bgG, bgB = hexToRGB(c.BgColor)
+	} else if c.BgColor != "" {
 		r, green, b := hexToRGB(c.BgColor)
 		if r < 250 || green < 250 || b < 250 {
 			bgR, bgG, bgB = r, green, b
@@ -588,7 +573,12 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 	var currentLine []coloredSegment
 
 	for _, seg := range c.Segments {
-		segColor := seg.Color
+		base := coloredSegment{color: seg.Color}
+		if isTerminal {
+			base.bgColor = seg.Background
+			base.bold = seg.Bold
+			base.underline = seg.Underline
+		}
 		// Bereinige den Text von problematischen Unicode-Zeichen
 		text := cleanCodeText(seg.Text)
 		currentText := ""
@@ -597,7 +587,9 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 			if r == '\n' {
 				// Aktuelles Segment zur Zeile hinzufügen (falls Text vorhanden)
 				if currentText != "" {
-					currentLine = append(currentLine, coloredSegment{text: currentText, color: segColor})
+					part := base
+					part.text = currentText
+					currentLine = append(currentLine, part)
 					currentText = ""
 				}
 				// Zeile abschließen
@@ -609,7 +601,9 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 		}
 		// Restlichen Text als Segment hinzufügen
 		if currentText != "" {
-			currentLine = append(currentLine, coloredSegment{text: currentText, color: segColor})
+			part := base
+			part.text = currentText
+			currentLine = append(currentLine, part)
 		}
 	}
 	// Letzte Zeile hinzufügen
@@ -617,6 +611,13 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 		allLines = append(allLines, currentLine)
 	}
 
+	// Prompt-Hervorhebung für Terminal-Zeilen
+	if isTerminal && g.cfg.Code.Terminal.Prompt != "" {
+		for i := range allLines {
+			allLines[i] = highlightPrompt(allLines[i], g.cfg.Code.Terminal.Prompt, g.cfg.Code.Terminal.PromptColor)
+		}
+	}
+
 	lineCount := len(allLines)
 	if lineCount == 0 {
 		lineCount = 1
@@ -669,14 +670,23 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 		chunkLines := allLines[startLine:endLine]
 		chunkLineCount := len(chunkLines)
 
+		// Terminal-Container erhalten eine Titelleiste mit Fenster-Punkten
+		barHeight := 0.0
+		if isTerminal {
+			barHeight = 6.0
+		}
+
 		// Rechteckhöhe für diesen Chunk
-		rectHeight := float64(chunkLineCount)*lineHeight + 10
+		rectHeight := float64(chunkLineCount)*lineHeight + 10 + barHeight
 
 		// Seitenumbruch prüfen
 		g.checkPageBreak(rectHeight + 10)
 
 		g.pdf.SetFillColor(bgR, bgG, bgB)
 		g.pdf.SetDrawColor(200, 200, 200)
+		if isTerminal {
+			g.pdf.SetDrawColor(bgR, bgG, bgB)
+		}
 
 		x := g.pdf.GetX()
 		y := g.pdf.GetY()
@@ -684,6 +694,14 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 		// Rechteck zeichnen
 		g.pdf.RoundedRect(x, y, width, rectHeight, 4, "1234", "DF")
 
+		if isTerminal {
+			// Fenster-Punkte (rot, gelb, grün) in der Titelleiste
+			for dotIdx, dot := range []string{"#ff5f56", "#ffbd2e", "#27c93f"} {
+				g.setFillColor(dot)
+				g.pdf.Circle(x+5+float64(dotIdx)*4, y+barHeight/2+1, 1.1, "F")
+			}
+		}
+
 		// Sprach-Label (nur beim ersten Chunk) oder Fortsetzungsmarkierung
 		if chunkIdx == 0 && c.Language != "" {
 			g.safeSetFont("main", "B", 7)
@@ -695,7 +713,7 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 			// Fortsetzungsmarkierung
 			g.safeSetFont("main", "I", 6)
 			g.pdf.SetTextColor(150, 150, 150)
-			contLabel := fmt.Sprintf("... (Teil %d/%d)", chunkIdx+1, totalChunks)
+			contLabel := g.locale.T(i18n.CodePart, chunkIdx+1, totalChunks)
 			labelW := g.pdf.GetStringWidth(contLabel) + 4
 			g.pdf.SetXY(x+width-labelW-2, y+2)
 			g.pdf.CellFormat(labelW, 4, g.prepareText(contLabel), "", 0, "R", false, 0, "")
@@ -703,8 +721,8 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 
 		// Code-Zeilen rendern mit Syntax-Highlighting
 		g.safeSetFont(fontFamily, "", codeFontSize)
+		g.pdf.SetY(y + 5 + barHeight)
 		g.pdf.SetX(x + 5)
-		g.pdf.SetY(y + 5)
 
 		for i, line := range chunkLines {
 			// Jedes Segment der Zeile mit seiner eigenen Farbe rendern
@@ -712,12 +730,40 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 				if seg.color != "" {
 					r, green, b := hexToRGB(seg.color)
 					g.pdf.SetTextColor(r, green, b)
+				} else if isTerminal {
+					g.setTextColor(g.cfg.Code.Terminal.Foreground)
 				} else {
 					g.setPrimaryTextColor()
 				}
 
-				if seg.text != "" {
-					g.safeWriteWithFontSize(lineHeight, seg.text, fontFamily, "", "", codeFontSize)
+				if seg.text == "" {
+					continue
+				}
+
+				style := ""
+				if seg.bold {
+					style = "B"
+				}
+				if !isTerminal {
+					g.safeWriteWithFontSize(lineHeight, seg.text, fontFamily, style, "", codeFontSize)
+					continue
+				}
+
+				// ANSI-Hintergrund und Unterstreichung benötigen die Textbreite
+				g.safeSetFont(fontFamily, style, codeFontSize)
+				startX := g.pdf.GetX()
+				startY := g.pdf.GetY()
+				segW := g.pdf.GetStringWidth(g.prepareText(seg.text))
+				if seg.bgColor != "" {
+					g.setFillColor(seg.bgColor)
+					g.pdf.Rect(startX, startY, segW, lineHeight, "F")
+				}
+				g.safeWriteWithFontSize(lineHeight, seg.text, fontFamily, style, "", codeFontSize)
+				if seg.underline {
+					r, green, b := g.pdf.GetTextColor()
+					g.pdf.SetDrawColor(r, green, b)
+					g.pdf.SetLineWidth(0.2)
+					g.pdf.Line(startX, startY+lineHeight*0.9, startX+segW, startY+lineHeight*0.9)
 				}
 			}
 
@@ -735,76 +781,90 @@ func (g *Generator) renderCode(c blocks.CodeBlock) {
 // renderImage rendert ein Bild mit automatischer Skalierung und optionalem Titel.
 // Unterstützt konfigurierbare Breite und Skalierung über ImageBlock.Width und ImageBlock.Scale.
 func (g *Generator) renderImage(i blocks.ImageBlock) {
-	g.pdf.RegisterImage(i.Path, "")
-	info := g.pdf.GetImageInfo(i.Path)
+	// SVG-Bilder werden als Vektorgrafik gezeichnet, sonst über das Rasterbild (Fallback)
+	var doc *svg.Document
+	var srcW, srcH float64
+	if isSVG(i.Path) {
+		doc = g.loadSVG(i.Path)
+		if doc == nil {
+			if i.Fallback == "" {
+				return
+			}
+			i.Path = i.Fallback
+		} else {
+			srcW, srcH = doc.Size()
+		}
+	}
+	if doc == nil {
+		g.pdf.RegisterImage(i.Path, "")
+		if info := g.pdf.GetImageInfo(i.Path); info != nil {
+			srcW, srcH = info.Width(), info.Height()
+		}
+	}
 	left, top, right, bottom := g.pdf.GetMargins()
 	w, h_page := g.pdf.GetPageSize()
 	maxWidth := w - left - right
-	maxPageHeight := h_page - top - bottom - 40
-
-	// Berechne Bildbreite basierend auf Konfiguration
-	var widthOnPage float64
-	if i.Width > 0 {
-		// Explizite Breite angegeben
-		widthOnPage = i.Width
-		if widthOnPage > maxWidth-20 {
-			widthOnPage = maxWidth - 20
-		}
-	} else if i.Scale > 0 && i.Scale != 1.0 {
-		// Skalierungsfaktor angegeben
-		widthOnPage = (maxWidth - 20) * i.Scale
-	} else {
-		// Standard: fast volle Breite
-		widthOnPage = maxWidth - 20
-	}
-
-	var h float64
-	if info != nil && info.Width() > 0 {
-		h = (info.Height() / info.Width()) * widthOnPage
-	} else {
-		h = 60.0
-	}
+	imgW, h := imageSize(i, srcW, srcH, maxWidth, h_page-top-bottom)
 
 	titleHeight := 0.0
 	if i.Title != "" {
 		titleHeight = 10.0
 	}
 
-	if h+titleHeight > maxPageHeight {
-		h = maxPageHeight - titleHeight
-		widthOnPage = 0
-	}
-
 	padding := 5.0
-	imgW := widthOnPage
-	if imgW == 0 && info != nil {
-		imgW = (info.Width() / info.Height()) * h
+	if i.NoBorder {
+		padding = 0
 	}
 	containerH := h + 2*padding
 	containerW := imgW + 2*padding
 
+	// Umfließen nur, wenn neben dem Bild noch genug Platz für Text bleibt
+	float := i.Float
+	if containerW > maxWidth-40 {
+		float = ""
+	}
+
 	g.checkPageBreak(containerH + titleHeight + 10)
 
-	x := g.pdf.GetX()
 	y := g.pdf.GetY()
 
+	imgX := left + (maxWidth-containerW)/2
+	switch {
+	case float == "left", float == "" && i.Align == "left":
+		imgX = left
+	case float == "right", float == "" && i.Align == "right":
+		imgX = left + maxWidth - containerW
+	}
+
 	if i.Title != "" {
 		g.safeSetFont("main", "B", 10)
 		g.pdf.SetTextColor(100, 100, 100)
-		g.pdf.CellFormat(0, 8, g.prepareText(i.Title), "", 1, "C", false, 0, "")
+		g.pdf.SetX(imgX)
+		g.pdf.CellFormat(containerW, 8, g.prepareText(i.Title), "", 1, "C", false, 0, "")
 		g.pdf.Ln(2)
 	}
 
-	imgX := x + (maxWidth-containerW)/2
+	if !i.NoBorder {
+		g.pdf.SetFillColor(255, 255, 255)
+		if g.cfg.Colors.Background != "" {
+			g.pdf.SetFillColor(250, 250, 250)
+		}
+		g.pdf.SetDrawColor(220, 220, 220)
+		g.pdf.RoundedRect(imgX, g.pdf.GetY(), containerW, containerH, 5, "1234", "DF")
+	}
 
-	g.pdf.SetFillColor(255, 255, 255)
-	if g.cfg.Colors.Background != "" {
-		g.pdf.SetFillColor(250, 250, 250)
+	if doc != nil {
+		doc.Draw(g.pdf, svgFonts{g}, imgX+padding, g.pdf.GetY()+padding, imgW, h)
+	} else {
+		g.pdf.Image(i.Path, imgX+padding, g.pdf.GetY()+padding, imgW, h, false, "", 0, "")
 	}
-	g.pdf.SetDrawColor(220, 220, 220)
-	g.pdf.RoundedRect(imgX, g.pdf.GetY(), containerW, containerH, 5, "1234", "DF")
 
-	g.pdf.Image(i.Path, imgX+padding, g.pdf.GetY()+padding, imgW, h, false, "", 0, "")
+	if float != "" {
+		// Der folgende Text läuft neben dem Bild weiter
+		g.startFloat(float, containerW, y+titleHeight+containerH+5)
+		g.pdf.SetY(y)
+		return
+	}
 
 	g.pdf.SetY(y + containerH + titleHeight + 5)
 	g.pdf.Ln(2)
@@ -822,108 +882,28 @@ func (g *Generator) renderListWithIndent(l blocks.ListBlock, indentLevel int) {
 	g.setPrimaryTextColor()
 	lineHeight := g.getLineHeight()
 
-	// Basis-Einrückung entspricht dem linken Margin für konsistente Ausrichtung mit normalem Text
-	left, _, _, _ := g.pdf.GetMargins()
-	baseIndent := left
 	indentStep := 8.0 // Einrückung pro Verschachtelungsebene
-	currentIndent := baseIndent + float64(indentLevel)*indentStep
 
 	for i, item := range l.Items {
 		g.fixSegmentSpacing(item.Content)
 
+		// Basis-Einrückung entspricht dem linken Margin für konsistente Ausrichtung mit normalem Text.
+		// Er wird je Eintrag gelesen, da ein umflossenes Bild innerhalb der Liste enden kann.
+		left, _, _, _ := g.pdf.GetMargins()
+		currentIndent := left + float64(indentLevel)*indentStep
+
 		prefix := "• "
 		if l.Ordered {
 			prefix = fmt.Sprintf("%d. ", i+1)
 		}
 
-		// Prüfen, ob der Listeneintrag Formatierungen enthält
-		hasFormatting := false
-		fullText := prefix
-		for _, seg := range item.Content {
-			if seg.Bold || seg.Italic || seg.Strikethrough || seg.Code || seg.Link != "" {
-				hasFormatting = true
-			}
-			fullText += seg.Text
-		}
-
-		if !hasFormatting {
-			align := g.getAlign(g.cfg.Layout.Body)
-			g.pdf.SetX(currentIndent)
-			// Wir berechnen die Breite für MultiCell unter Berücksichtigung der Einrückung
-			w, _ := g.pdf.GetPageSize()
-			_, _, right, _ := g.pdf.GetMargins()
-			width := w - currentIndent - right
-			g.pdf.MultiCell(width, lineHeight, g.prepareText(fullText), "", align, false)
-			g.pdf.Ln(1)
-		} else {
-			g.pdf.SetX(currentIndent)
-			g.pdf.Write(lineHeight, g.prepareText(prefix))
-
-			for _, seg := range item.Content {
-				if seg.Code {
-					// Inline-Code mit Hintergrund-Chip rendern
-					fontFamily := "main"
-					if g.cfg.Fonts.Mono != "" {
-						fontFamily = "mono"
-					}
-					codeFontSize := g.cfg.Code.FontSize
-					if codeFontSize <= 0 {
-						codeFontSize = g.cfg.FontSize * 0.9 // 90% der normalen Schriftgröße für bessere Lesbarkeit
-					}
-					g.safeSetFont(fontFamily, "", codeFontSize)
-
-					if seg.Text != "" {
-						textWidth := g.pdf.GetStringWidth(g.prepareText(seg.Text))
-						chipPaddingH := 2.5              // Horizontales Padding (links und rechts)
-						chipPaddingV := 1.2              // Vertikales Padding (oben und unten)
-						chipHeight := codeFontSize * 0.5 // Höhe basierend auf Schriftgröße
-
-						startX := g.pdf.GetX()
-						startY := g.pdf.GetY()
-
-						// Moderner Hintergrund-Chip (sanftes Grau, ohne sichtbaren Rahmen)
-						g.pdf.SetFillColor(243, 244, 246)                          // Sanftes Grau (wie Tailwind gray-100)
-						chipY := startY + (lineHeight-chipHeight-2*chipPaddingV)/2 // Vertikal zentrieren
-						g.pdf.RoundedRect(startX, chipY, textWidth+2*chipPaddingH, chipHeight+2*chipPaddingV, 1.5, "1234", "F")
-
-						g.pdf.SetX(startX + chipPaddingH)
-						g.pdf.SetTextColor(55, 65, 81) // Dunkles Grau für Code-Text (wie Tailwind gray-700)
-						codeLineHeight := codeFontSize * 0.5
-						g.safeWriteWithFontSize(codeLineHeight, seg.Text, fontFamily, "", seg.Link, codeFontSize)
-
-						g.pdf.SetX(startX + textWidth + 2*chipPaddingH + 1.5)
-
-						// Textfarbe zurücksetzen für nachfolgenden Text
-						g.setPrimaryTextColor()
-					}
-				} else {
-					style := ""
-					if seg.Bold {
-						style += "B"
-					}
-					if seg.Italic {
-						style += "I"
-					}
-					g.safeSetFont("main", style, g.cfg.FontSize)
-
-					// Strikethrough: Position vor dem Text merken
-					startX := g.pdf.GetX()
-					startY := g.pdf.GetY()
-
-					g.safeWrite(lineHeight, seg.Text, "main", style, seg.Link)
-
-					// Strikethrough-Linie zeichnen
-					if seg.Strikethrough {
-						endX := g.pdf.GetX()
-						strikeY := startY + g.cfg.FontSize*0.35
-						g.pdf.SetDrawColor(0, 0, 0)
-						g.pdf.SetLineWidth(0.3)
-						g.pdf.Line(startX, strikeY, endX, strikeY)
-					}
-				}
-			}
-			g.pdf.Ln(lineHeight + 2)
-		}
+		// Wir berechnen die Zeilenbreite unter Berücksichtigung der Einrückung
+		g.pdf.SetX(currentIndent)
+		w, _ := g.pdf.GetPageSize()
+		_, _, right, _ := g.pdf.GetMargins()
+		content := append([]blocks.TextSegment{{Text: prefix}}, item.Content...)
+		g.renderSegments(content, w-currentIndent-right, lineHeight, g.getAlign(g.cfg.Layout.Body))
+		g.pdf.Ln(1)
 
 		// Verschachtelte Liste rendern
 		if item.SubList != nil {
@@ -952,16 +932,12 @@ func (g *Generator) renderBlockquote(b blocks.BlockquoteBlock) {
 		switch content := block.(type) {
 		case blocks.ParagraphBlock:
 			// Blockquote-Text kursiv rendern
-			g.safeSetFont("main", "I", g.cfg.FontSize)
-			g.setPrimaryTextColor()
-			lineHeight := g.getLineHeight()
-
-			fullText := ""
-			for _, seg := range content.Content {
-				fullText += seg.Text
+			segs := make([]blocks.TextSegment, len(content.Content))
+			for i, seg := range content.Content {
+				seg.Italic = true
+				segs[i] = seg
 			}
-			align := g.getAlign(g.cfg.Layout.Body)
-			g.pdf.MultiCell(0, lineHeight, g.prepareText(fullText), "", align, false)
+			g.renderSegments(segs, 0, g.getLineHeight(), g.getAlign(g.cfg.Layout.Body))
 			g.pdf.Ln(2)
 		case blocks.ListBlock:
 			g.renderList(content)
@@ -982,7 +958,7 @@ func (g *Generator) renderBlockquote(b blocks.BlockquoteBlock) {
 
 // renderTable rendert eine Tabelle mit Kopfzeile und automatischer Spaltenbreite.
 // Verbesserte Darstellung mit schöneren Rahmen, Padding und Zebra-Streifen.
-func (g *Generator) renderTable(t blocks.TableBlock) {
+func (g *Generator) renderTable(t blocks.TableBlock, isMeasurement bool) {
 	if len(t.Rows) == 0 {
 		return
 	}
@@ -990,79 +966,94 @@ func (g *Generator) renderTable(t blocks.TableBlock) {
 	left, _, right, _ := g.pdf.GetMargins()
 	w, pageHeight := g.pdf.GetPageSize()
 	width := w - left - right
-	colCount := 0
-	for _, row := range t.Rows {
-		if len(row) > colCount {
-			colCount = len(row)
-		}
-	}
+
+	// Zellen im Raster platzieren, von Zellen darüber überspannte Plätze werden übersprungen
+	grid, colCount := placeTableCells(t.Rows)
 	if colCount == 0 {
 		return
 	}
 
-	// Tabellen-Styling Konstanten
-	cellPadding := 4.0
-	headerBgR, headerBgG, headerBgB := 52, 73, 94 // Elegantes Dunkelblau für Header (Fallback)
-	if g.cfg.Colors.Accent != "" {
-		headerBgR, headerBgG, headerBgB = hexToRGB(g.cfg.Colors.Accent)
-	}
-	headerTextR, headerTextG, headerTextB := 255, 255, 255 // Weißer Text für Header
-	evenRowR, evenRowG, evenRowB := 245, 247, 250          // Sehr helles Blau-Grau für Zebra
-	oddRowR, oddRowG, oddRowB := 255, 255, 255             // Weiß
-	borderR, borderG, borderB := 200, 200, 210             // Dezenter Rahmen
+	// Darstellung aus docgen.yml und <!-- table ... -->
+	style := g.newTableStyle(t)
+	cellPadding := style.padding
 
 	// Berechne dynamische Spaltenbreiten
 	colWidths := make([]float64, colCount)
-	g.safeSetFont("main", "B", g.cfg.FontSize)
+	g.safeSetFont("main", "B", style.fontSize)
 
 	// 1. Berechne benötigte Breite für jede Spalte
-	for _, row := range t.Rows {
-		for i, cell := range row {
-			if i >= colCount {
-				break
+	for _, row := range grid {
+		for _, c := range row {
+			if c.colSpan > 1 {
+				continue
 			}
-			cellText := ""
-			for _, seg := range cell.Content {
-				cellText += seg.Text
+			if cw := g.cellWidth(c.cell, cellPadding); cw > colWidths[c.col] {
+				colWidths[c.col] = cw
 			}
-			// Breite des Textes in einer Zeile messen
-			textWidth := g.pdf.GetStringWidth(cellText) + 2*cellPadding + 4
-			if textWidth > colWidths[i] {
-				colWidths[i] = textWidth
+		}
+	}
+	// Zellen über mehrere Spalten verteilen ihre fehlende Breite gleichmäßig auf diese Spalten
+	for _, row := range grid {
+		for _, c := range row {
+			if c.colSpan <= 1 {
+				continue
+			}
+			if missing := g.cellWidth(c.cell, cellPadding) - c.width(colWidths); missing > 0 {
+				for i := c.col; i < c.col+c.colSpan; i++ {
+					colWidths[i] += missing / float64(c.colSpan)
+				}
 			}
 		}
 	}
 
-	// 2. Proportionale Anpassung an die Gesamtbreite
+	// 2. Feste Breiten aus widths (mm oder Prozent der Textbreite), die übrigen Spalten teilen
+	// sich den restlichen Platz im Verhältnis ihrer natürlichen Breite
+	fixedWidth := 0.0
+	fixed := make([]bool, colCount)
+	for i := range colWidths {
+		if i < len(style.widths) && style.widths[i].Value > 0 {
+			colWidths[i] = style.widths[i].Value
+			if style.widths[i].Percent {
+				colWidths[i] = width * style.widths[i].Value / 100
+			}
+			fixed[i] = true
+			fixedWidth += colWidths[i]
+		}
+	}
 	totalNeededWidth := 0.0
-	for _, cw := range colWidths {
-		totalNeededWidth += cw
+	for i, cw := range colWidths {
+		if !fixed[i] {
+			totalNeededWidth += cw
+		}
 	}
 
 	// Tabellen sollten immer die volle Breite nutzen, wenn sie nicht winzig sind.
-	// Das sorgt für ein konsistentes Look & Feel.
+	// Das sorgt für ein konsistentes Look & Feel. Mit stretch=false behalten sie ihre
+	// natürliche Breite und werden nur verkleinert, wenn sie zu breit sind.
 	if totalNeededWidth > 0 {
-		scaleFactor := width / totalNeededWidth
+		scaleFactor := (width - fixedWidth) / totalNeededWidth
 		// Wenn die Tabelle natürlich sehr schmal wäre, skalieren wir sie nur moderat,
 		// es sei denn, der User möchte volle Breite (was bei Markdown-Tabellen meist erwartet wird).
-		if totalNeededWidth < width*0.5 {
-			// Begrenze das "Aufblasen" auf maximal 1.5x der natürlichen Breite,
+		if fixedWidth == 0 && totalNeededWidth < width*0.5 {
+			// Begrenze das "Aufblasen" (Standard: 1.5x der natürlichen Breite),
 			// damit schmale Tabellen nicht absurd breit werden.
-			if scaleFactor > 1.5 {
-				scaleFactor = 1.5
+			if scaleFactor > style.maxStretch {
+				scaleFactor = style.maxStretch
 			}
 		}
+		if !style.stretch && scaleFactor > 1 {
+			scaleFactor = 1
+		}
 
 		for i := range colWidths {
-			colWidths[i] *= scaleFactor
+			if !fixed[i] {
+				colWidths[i] *= max(scaleFactor, 0)
+			}
 		}
 	}
 
 	// Sicherstellen, dass die Gesamtbreite nicht das Limit überschreitet
-	currentTotal := 0.0
-	for _, cw := range colWidths {
-		currentTotal += cw
-	}
+	currentTotal := sumLengths(colWidths)
 	if currentTotal > width {
 		shrink := width / currentTotal
 		for i := range colWidths {
@@ -1071,187 +1062,623 @@ func (g *Generator) renderTable(t blocks.TableBlock) {
 	}
 
 	// Falls nach dem Shrinken immer noch winzige Rundungsdifferenzen bestehen,
-	// passen wir die letzte Spalte an.
-	currentTotal = 0.0
-	for _, cw := range colWidths {
-		currentTotal += cw
+	// passen wir die letzte automatische Spalte an.
+	currentTotal = sumLengths(colWidths)
+	if style.stretch && totalNeededWidth > 0 && currentTotal < width-0.1 {
+		for i := len(colWidths) - 1; i >= 0; i-- {
+			if !fixed[i] {
+				colWidths[i] += width - currentTotal
+				break
+			}
+		}
 	}
-	if len(colWidths) > 0 && currentTotal < width-0.1 {
-		colWidths[len(colWidths)-1] += (width - currentTotal)
+
+	// Dezimale Ausrichtung: breitester Nachkommateil je Spalte
+	g.safeSetFont("main", "", style.fontSize)
+	style.decimals = make([]float64, colCount)
+	for _, row := range grid {
+		for _, c := range row {
+			if c.colSpan == 1 && !c.cell.Header && style.align(c.col) == blocks.AlignDecimal {
+				style.decimals[c.col] = max(style.decimals[c.col], g.decimalWidth(cellText(c.cell)))
+			}
+		}
 	}
 
 	g.setPrimaryTextColor()
 
 	// Berechne die Zeilenhöhen basierend auf den neuen Spaltenbreiten
-	rowHeights := make([]float64, len(t.Rows))
-	totalTableHeight := 0.0
-	lineHeightFactor := 1.2 // Faktor für Zeilenabstand innerhalb der Zelle
-	for rowIdx, row := range t.Rows {
-		maxH := 0.0
-		for i, cell := range row {
-			if i >= colCount {
-				break
+	rowHeights := make([]float64, len(grid))
+	rowLines := make([]int, len(grid))
+	lineHeight := style.lineHeight()
+	for rowIdx, row := range grid {
+		maxLines := 1 // Mindesthöhe für eine Zeile
+		rowHeights[rowIdx] = lineHeight + 2*cellPadding
+		for _, c := range row {
+			if c.rowSpan > 1 {
+				continue
+			}
+			h, lines := g.cellHeight(c.cell, c.width(colWidths), style)
+			maxLines = max(maxLines, lines)
+			rowHeights[rowIdx] = max(rowHeights[rowIdx], h)
+		}
+		rowLines[rowIdx] = maxLines
+	}
+	// Zellen über mehrere Zeilen vergrößern bei Bedarf die letzte ihrer Zeilen
+	for rowIdx, row := range grid {
+		for _, c := range row {
+			if c.rowSpan <= 1 {
+				continue
+			}
+			h, _ := g.cellHeight(c.cell, c.width(colWidths), style)
+			if missing := h - sumLengths(rowHeights[rowIdx:rowIdx+c.rowSpan]); missing > 0 {
+				rowHeights[rowIdx+c.rowSpan-1] += missing
 			}
-			g.fixSegmentSpacing(cell.Content)
-			cellText := ""
-			for _, seg := range cell.Content {
-				cellText += seg.Text
+		}
+	}
+	totalTableHeight := sumLengths(rowHeights) + 5 // Abstand nach der Tabelle
+
+	// Zeilen, die durch Zellen über mehrere Zeilen verbunden sind, bilden einen Verbund, der
+	// nicht geteilt wird. bandEnd liefert die erste Zeile nach dem Verbund, der in row beginnt.
+	bandEnd := func(row int) int {
+		end := row + 1
+		for r := row; r < end; r++ {
+			for _, c := range grid[r] {
+				end = max(end, r+c.rowSpan)
+			}
+		}
+		return end
+	}
+	isHeaderRow := func(row int) bool {
+		return len(grid[row]) > 0 && grid[row][0].cell.Header
+	}
+
+	// Kopfzeilen am Tabellenanfang werden auf jeder Folgeseite wiederholt
+	headerRows := 0
+	for headerRows < len(grid) && isHeaderRow(headerRows) {
+		headerRows = bandEnd(headerRows)
+	}
+	headerHeight := sumLengths(rowHeights[:headerRows])
+
+	// renderRows setzt die Zeilen from bis to (ausschließlich) untereinander. rowIndex ist der
+	// Zebra-Index der ersten Datenzeile, geliefert wird der Index nach dem Verbund.
+	renderRows := func(from, to, rowIndex int) int {
+		for r := from; r < to; r++ {
+			header := isHeaderRow(r)
+			g.renderTableRow(grid[r], colWidths, rowHeights[r:to], header, rowIndex, style, 0, -1, isMeasurement)
+			if !header {
+				rowIndex++
 			}
+		}
+		return rowIndex
+	}
+	repeatHeader := func() {
+		renderRows(0, headerRows, 0)
+	}
+
+	// Seitenumbruch vor der Tabelle: Passt sie ganz auf eine leere Seite, beginnt sie dort. Sonst
+	// müssen wenigstens die Kopfzeilen und der Anfang der ersten Datenzeile auf die Seite passen.
+	limit := func() float64 {
+		_, _, _, bottom := g.pdf.GetMargins()
+		return pageHeight - bottom - blockBreakSpace
+	}
+	firstRow := headerHeight
+	if headerRows < len(grid) {
+		firstRow += math.Min(sumLengths(rowHeights[headerRows:bandEnd(headerRows)]), lineHeight+2*cellPadding)
+	}
+	if !g.atColumnTop() {
+		room := limit() - g.pdf.GetY()
+		fitsPage := totalTableHeight <= g.spaceTotal()-blockBreakSpace
+		if (totalTableHeight > room && fitsPage) || firstRow > room {
+			g.breakPage()
+		}
+	}
 
-			// Font für Messung setzen (Header ist Fett)
-			if cell.Header {
-				g.safeSetFont("main", "B", g.cfg.FontSize)
+	// Umbruch innerhalb der Tabelle: neue Seite oder Spalte, optional mit Fortsetzungshinweis,
+	// danach die Kopfzeilen
+	breakTable := func() {
+		g.breakPage()
+		if g.cfg.Tables.Continued {
+			g.safeSetFont("main", "I", g.cfg.FontSize*0.8)
+			g.pdf.SetTextColor(150, 150, 150)
+			g.pdf.CellFormat(0, lineHeight+1, g.prepareText(g.locale.T(i18n.TableContinued)), "", 1, "L", false, 0, "")
+		}
+		repeatHeader()
+	}
+	// Platz auf einer Folgeseite unter Fortsetzungshinweis und Kopfzeilen
+	fresh := func() float64 {
+		space := g.spaceTotal() - blockBreakSpace - headerHeight
+		if g.cfg.Tables.Continued {
+			space -= lineHeight + 1
+		}
+		return space
+	}
+
+	if headerRows > 0 {
+		repeatHeader()
+	}
+
+	// Der Zebra-Index zählt nur Datenzeilen, damit die Streifen über Seitenumbrüche und
+	// wiederholte Kopfzeilen hinweg gleich bleiben
+	rowIndex := 0
+	for rowIdx := headerRows; rowIdx < len(grid); {
+		end := bandEnd(rowIdx)
+
+		// Verbünde und Zeilen mit Blockinhalt wechseln als Ganzes auf die nächste Seite
+		if end-rowIdx > 1 || grid[rowIdx].hasBlocks() {
+			height := sumLengths(rowHeights[rowIdx:end])
+			if room := limit() - g.pdf.GetY(); height > room && room < fresh()-0.01 {
+				breakTable()
+			}
+			// Sind sie auch dort höher als der Platz bis zum unteren Rand, werden sie abgeschnitten
+			if room := limit() + blockBreakSpace - g.pdf.GetY(); height > room {
+				if !isMeasurement {
+					fmt.Printf("Warnung: Tabellenzeile mit Blockinhalt ist %.0f mm hoch und wird am Seitenende abgeschnitten (Platz: %.0f mm)\n", height, room)
+				}
+				// Die Zeilen enden am unteren Rand, damit Rahmen und mittig gesetzter Text sichtbar bleiben
+				for r, excess := end-1, height-room; r >= rowIdx && excess > 0; r-- {
+					cut := min(excess, rowHeights[r])
+					rowHeights[r] -= cut
+					excess -= cut
+				}
+				left, _, _, _ := g.pdf.GetMargins()
+				auto, margin := g.pdf.GetAutoPageBreak()
+				g.pdf.SetAutoPageBreak(false, margin)
+				g.pdf.ClipRect(left, g.pdf.GetY(), sumLengths(colWidths), room, false)
+				bottom := g.pdf.GetY() + room
+				rowIndex = renderRows(rowIdx, end, rowIndex)
+				g.pdf.ClipEnd()
+				g.pdf.SetAutoPageBreak(auto, margin)
+				g.pdf.SetY(bottom)
 			} else {
-				g.safeSetFont("main", "", g.cfg.FontSize)
+				rowIndex = renderRows(rowIdx, end, rowIndex)
 			}
+			rowIdx = end
+			continue
+		}
 
-			lines := g.pdf.SplitLines([]byte(g.prepareText(cellText)), colWidths[i]-2*cellPadding)
-			h := float64(len(lines)) * (g.cfg.FontSize * 0.35 * lineHeightFactor)
-			if h > maxH {
-				maxH = h
+		row := grid[rowIdx]
+		isHeader := isHeaderRow(rowIdx)
+
+		// Zeilen, die auf keine Seite passen, werden zwischen ihren Textzeilen geteilt
+		from := 0
+		broke := false
+		for {
+			remaining := rowLines[rowIdx] - from
+			height := float64(remaining)*lineHeight + 2*cellPadding
+			if from == 0 {
+				height = rowHeights[rowIdx]
+			}
+			room := limit() - g.pdf.GetY()
+			if height <= room {
+				g.renderTableRow(row, colWidths, []float64{height}, isHeader, rowIndex, style, from, -1, isMeasurement)
+				break
+			}
+			fit := int((room - 2*cellPadding) / lineHeight)
+			if fit < 1 && broke {
+				// Nicht einmal eine Textzeile passt unter die Kopfzeilen: Rest unverändert setzen
+				g.renderTableRow(row, colWidths, []float64{height}, isHeader, rowIndex, style, from, -1, isMeasurement)
+				break
 			}
+			broke = true
+			if height <= fresh() || fit < 1 {
+				breakTable()
+				continue
+			}
+			g.renderTableRow(row, colWidths, []float64{float64(fit)*lineHeight + 2*cellPadding}, isHeader, rowIndex, style, from, from+fit, isMeasurement)
+			from += fit
+			breakTable()
 		}
-		maxH += 2 * cellPadding // Padding oben und unten
-		// Mindesthöhe für eine Zeile
-		minH := g.cfg.FontSize*0.35*lineHeightFactor + 2*cellPadding
-		if maxH < minH {
-			maxH = minH
+
+		if !isHeader {
+			rowIndex++
 		}
-		rowHeights[rowIdx] = maxH
-		totalTableHeight += maxH
+		rowIdx++
 	}
-	totalTableHeight += 5 // Abstand nach der Tabelle
+	g.pdf.Ln(5)
+}
 
-	// Berechne verfügbare Höhe auf der aktuellen Seite
-	_, top, _, bottom := g.pdf.GetMargins()
-	availableHeight := pageHeight - g.pdf.GetY() - bottom - 10
+// tableLineFactor ist der Zeilenabstand innerhalb einer Tabellenzelle (Faktor der Schriftgröße).
+const tableLineFactor = 1.2
+
+// tableStyle fasst Farben, Innenabstand, Schrift und Spaltenangaben einer Tabelle zusammen.
+type tableStyle struct {
+	headerBg    [3]int              // Hintergrund der Kopfzeilen
+	headerText  [3]int              // Textfarbe der Kopfzeilen
+	evenRow     [3]int              // Hintergrund gerader Datenzeilen (Zebra)
+	oddRow      [3]int              // Hintergrund ungerader Datenzeilen
+	border      [3]int              // Rahmenfarbe
+	borderWidth float64             // Linienstärke der Rahmen
+	padding     float64             // Innenabstand der Zellen
+	fontSize    float64             // Schriftgröße in pt
+	zebra       bool                // Datenzeilen abwechselnd einfärben
+	stretch     bool                // Auf die volle Textbreite strecken
+	maxStretch  float64             // Höchster Streckfaktor schmaler Tabellen
+	widths      []blocks.TableWidth // Feste Spaltenbreiten
+	alignments  []blocks.Align      // Ausrichtung je Spalte
+	decimals    []float64           // Breite des breitesten Nachkommateils je Spalte (dezimale Ausrichtung)
+}
 
-	// Seitenumbruch-Logik
-	maxPageHeight := pageHeight - top - bottom - 20
-	if totalTableHeight > availableHeight && totalTableHeight <= maxPageHeight {
-		g.pdf.AddPage()
+// newTableStyle ermittelt die Darstellung einer Tabelle aus den Vorgaben in docgen.yml (tables)
+// und den Angaben der Direktive <!-- table ... -->, die Vorrang haben.
+func (g *Generator) newTableStyle(t blocks.TableBlock) tableStyle {
+	defaults, opts := g.cfg.Tables, t.Options
+	style := tableStyle{
+		headerBg:    [3]int{52, 73, 94},    // Elegantes Dunkelblau für Header (Fallback)
+		headerText:  [3]int{255, 255, 255}, // Weißer Text für Header
+		evenRow:     [3]int{245, 247, 250}, // Sehr helles Blau-Grau für Zebra
+		oddRow:      [3]int{255, 255, 255}, // Weiß
+		border:      [3]int{200, 200, 210}, // Dezenter Rahmen
+		borderWidth: 0.15,
+		padding:     4.0,
+		fontSize:    g.cfg.FontSize,
+		zebra:       defaults.ZebraEnabled(),
+		stretch:     defaults.StretchEnabled(),
+		maxStretch:  defaults.MaxStretch,
+		widths:      opts.Widths,
 	}
 
-	rowIndex := 0
-	headerRow := -1
-	for i, row := range t.Rows {
-		if len(row) > 0 && row[0].Header {
-			headerRow = i
-			break
+	name := defaults.Style
+	if opts.Style != "" {
+		name = opts.Style
+	}
+	switch name {
+	case "compact":
+		style.padding = 1.5
+	case "bordered":
+		style.border = [3]int{90, 90, 100}
+		style.borderWidth = 0.4
+	}
+	// Der Innenabstand aus docgen.yml gilt für Tabellen ohne eigenen Stil
+	if defaults.Padding > 0 && opts.Style == "" {
+		style.padding = defaults.Padding
+	}
+	if opts.Padding > 0 {
+		style.padding = opts.Padding
+	}
+	if defaults.FontSize > 0 {
+		style.fontSize = defaults.FontSize
+	}
+	if opts.FontSize > 0 {
+		style.fontSize = opts.FontSize
+	}
+	if opts.Zebra != nil {
+		style.zebra = *opts.Zebra
+	}
+	if opts.Stretch != nil {
+		style.stretch = *opts.Stretch
+	}
+	if style.maxStretch <= 0 {
+		style.maxStretch = 1.5
+	}
+
+	header := g.cfg.Colors.Accent
+	if defaults.Header != "" {
+		header = defaults.Header
+	}
+	if opts.Header != "" {
+		header = opts.Header
+	}
+	if header != "" {
+		r, green, b := hexToRGB(header)
+		style.headerBg = [3]int{r, green, b}
+	}
+
+	// Ausrichtung der Direktive vor der aus dem Markdown
+	style.alignments = append([]blocks.Align(nil), t.Alignments...)
+	for i, align := range opts.Align {
+		if i < len(style.alignments) {
+			style.alignments[i] = align
+		} else {
+			style.alignments = append(style.alignments, align)
+		}
+	}
+	return style
+}
+
+// lineHeight liefert den Zeilenabstand innerhalb einer Zelle.
+func (s tableStyle) lineHeight() float64 {
+	return s.fontSize * 0.35 * tableLineFactor
+}
+
+// align liefert die Ausrichtung einer Spalte (-1, wenn keine angegeben ist).
+func (s tableStyle) align(col int) blocks.Align {
+	if col < len(s.alignments) {
+		return s.alignments[col]
+	}
+	return -1
+}
+
+// decimalWidth liefert die Breite des Nachkommateils einer Zahl ab dem Dezimaltrennzeichen in der
+// aktuellen Schrift (0 für Werte ohne Nachkommateil).
+func (g *Generator) decimalWidth(text string) float64 {
+	text = g.prepareText(text)
+	i := decimalSeparator(text)
+	if i < 0 {
+		return 0
+	}
+	return g.pdf.GetStringWidth(text[i:])
+}
+
+// decimalSeparator liefert die Position des Dezimaltrennzeichens eines Werts (-1 = keines). Das ist
+// der letzte Punkt oder das letzte Komma, dem nur noch Ziffern folgen; eine Einheit wie " €" oder
+// "%" am Ende zählt nicht mit. So stehen "3.14" und "2,5" auch in gemischten Spalten untereinander.
+func decimalSeparator(text string) int {
+	number := strings.TrimRightFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	i := strings.LastIndexAny(number, ".,")
+	if i < 0 || i == len(number)-1 {
+		return -1
+	}
+	if strings.IndexFunc(number[i+1:], func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+		return -1
+	}
+	return i
+}
+
+// tableCell ist eine Tabellenzelle mit ihrer Position im Spaltenraster.
+type tableCell struct {
+	cell    blocks.TableRow
+	col     int // Erste Spalte
+	colSpan int // Anzahl Spalten (mindestens 1)
+	rowSpan int // Anzahl Zeilen (mindestens 1, auf das Tabellenende begrenzt)
+}
+
+// width liefert die Breite der Zelle über alle überspannten Spalten.
+func (c tableCell) width(colWidths []float64) float64 {
+	return sumLengths(colWidths[c.col : c.col+c.colSpan])
+}
+
+// tableCells sind die Zellen, die in einer Tabellenzeile beginnen.
+type tableCells []tableCell
+
+// hasBlocks prüft, ob eine Zelle der Zeile Blockinhalt hat.
+func (row tableCells) hasBlocks() bool {
+	for _, c := range row {
+		if len(c.cell.Blocks) > 0 {
+			return true
 		}
 	}
+	return false
+}
 
-	for rowIdx, row := range t.Rows {
-		maxH := rowHeights[rowIdx]
-		isHeader := len(row) > 0 && row[0].Header
+// placeTableCells ordnet die Zellen den Spalten zu. Plätze, die eine Zelle aus einer Zeile darüber
+// überspannt, werden übersprungen. Kopfzeilen werden bis zur letzten Spalte mit leeren Zellen
+// aufgefüllt, damit ihr Hintergrund durchgehend ist.
+func placeTableCells(rows [][]blocks.TableRow) ([]tableCells, int) {
+	occupied := map[[2]int]bool{}
+	grid := make([]tableCells, len(rows))
+	colCount := 0
+	for r, row := range rows {
+		col := 0
+		for _, cell := range row {
+			for occupied[[2]int{r, col}] {
+				col++
+			}
+			c := tableCell{cell: cell, col: col, colSpan: max(cell.ColSpan, 1), rowSpan: min(max(cell.RowSpan, 1), len(rows)-r)}
+			for y := r; y < r+c.rowSpan; y++ {
+				for x := col; x < col+c.colSpan; x++ {
+					occupied[[2]int{y, x}] = true
+				}
+			}
+			grid[r] = append(grid[r], c)
+			col += c.colSpan
+			colCount = max(colCount, col)
+		}
+	}
 
-		// Prüfe auf Seitenumbruch innerhalb der Tabelle
-		// Wir lassen etwas Puffer (10mm statt 5mm) am Seitenende für Stabilität
-		if g.pdf.GetY()+maxH > pageHeight-bottom-10 {
-			g.pdf.AddPage()
-			// Wenn wir eine neue Seite anfangen, wiederholen wir den Header
-			if headerRow != -1 && !isHeader {
-				g.renderTableRow(t.Rows[headerRow], colWidths, rowHeights[headerRow], true, rowIndex, headerBgR, headerBgG, headerBgB, headerTextR, headerTextG, headerTextB, evenRowR, evenRowG, evenRowB, oddRowR, oddRowG, oddRowB, borderR, borderG, borderB, t.Alignments, cellPadding)
+	for r, row := range grid {
+		if len(row) == 0 || !row[0].cell.Header {
+			continue
+		}
+		for col := 0; col < colCount; col++ {
+			if !occupied[[2]int{r, col}] {
+				grid[r] = append(grid[r], tableCell{cell: blocks.TableRow{Header: true}, col: col, colSpan: 1, rowSpan: 1})
 			}
 		}
+	}
+	return grid, colCount
+}
 
-		g.renderTableRow(row, colWidths, maxH, isHeader, rowIndex, headerBgR, headerBgG, headerBgB, headerTextR, headerTextG, headerTextB, evenRowR, evenRowG, evenRowB, oddRowR, oddRowG, oddRowB, borderR, borderG, borderB, t.Alignments, cellPadding)
+// sumLengths addiert Zeilenhöhen bzw. Spaltenbreiten.
+func sumLengths(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
 
-		if !isHeader {
-			rowIndex++
+// cellText liefert den Text einer Tabellenzelle.
+func cellText(cell blocks.TableRow) string {
+	return segmentsText(cell.Content)
+}
+
+// segmentsText liefert den Text von Textsegmenten.
+func segmentsText(segs []blocks.TextSegment) string {
+	text := ""
+	for _, seg := range segs {
+		text += seg.Text
+	}
+	return text
+}
+
+// cellLines bricht den Text einer Zelle in der aktuellen Schrift auf die Breite um.
+func (g *Generator) cellLines(cell blocks.TableRow, width float64) [][]byte {
+	return g.pdf.SplitLines([]byte(g.prepareText(cellText(cell))), width)
+}
+
+// cellWidth liefert die natürliche Breite einer Zelle: ihr Text in einer Zeile, bei Blockinhalt
+// der breiteste Absatz, Listeneintrag oder die längste Codezeile, jeweils mit Innenabstand.
+func (g *Generator) cellWidth(cell blocks.TableRow, padding float64) float64 {
+	textWidth := g.pdf.GetStringWidth(cellText(cell))
+	for _, block := range cell.Blocks {
+		textWidth = max(textWidth, g.blockWidth(block))
+	}
+	return textWidth + 2*padding + 4
+}
+
+// blockWidth schätzt die natürliche Breite eines Blocks in einer Tabellenzelle.
+func (g *Generator) blockWidth(block blocks.DocBlock) float64 {
+	switch b := block.(type) {
+	case blocks.ParagraphBlock:
+		return g.pdf.GetStringWidth(segmentsText(b.Content))
+	case blocks.ListBlock:
+		width := 0.0
+		for _, item := range b.Items {
+			width = max(width, g.pdf.GetStringWidth("• "+segmentsText(item.Content)))
+			if item.SubList != nil {
+				width = max(width, 8+g.blockWidth(*item.SubList))
+			}
+		}
+		return width
+	case blocks.CodeBlock:
+		width := 0.0
+		for _, line := range strings.Split(b.Content, "\n") {
+			width = max(width, g.pdf.GetStringWidth(line))
 		}
+		return width + 10
 	}
-	g.pdf.Ln(5)
+	return 0
+}
+
+// cellHeight liefert die Höhe einer Zelle der Breite width mit Innenabstand sowie die Anzahl ihrer
+// Textzeilen. Blockinhalt wird im Mess-Dokument gesetzt und hat keine teilbaren Textzeilen.
+func (g *Generator) cellHeight(cell blocks.TableRow, width float64, style tableStyle) (float64, int) {
+	padding := style.padding
+	if len(cell.Blocks) > 0 {
+		left, top, right, _ := g.pdf.GetMargins()
+		pageW, _ := g.pdf.GetPageSize()
+		g.pdf.SetMargins(left, top, pageW-left-(width-2*padding))
+		h := g.measureBlocks(cell.Blocks)
+		g.pdf.SetMargins(left, top, right)
+		return h + 2*padding, 0
+	}
+
+	g.fixSegmentSpacing(cell.Content)
+	// Font für Messung setzen (Header ist Fett)
+	if cell.Header {
+		g.safeSetFont("main", "B", style.fontSize)
+	} else {
+		g.safeSetFont("main", "", style.fontSize)
+	}
+	lines := len(g.cellLines(cell, width-2*padding))
+	return float64(max(lines, 1))*style.lineHeight() + 2*padding, lines
 }
 
-// renderTableRow rendert eine einzelne Tabellenzeile (Hilfsfunktion für renderTable)
-func (g *Generator) renderTableRow(row []blocks.TableRow, colWidths []float64, maxH float64, isHeader bool, rowIndex int, hBgR, hBgG, hBgB, hTextR, hTextG, hTextB, eRowR, eRowG, eRowB, oRowR, oRowG, oRowB, bR, bG, bB int, alignments []blocks.Align, cellPadding float64) {
+// renderCellBlocks setzt den Blockinhalt einer Zelle in den Bereich ab (x, y) mit der Breite
+// width. Die Zeile passt bereits auf die Seite, innerhalb der Zelle wird daher nicht umbrochen.
+func (g *Generator) renderCellBlocks(content []blocks.DocBlock, x, y, width float64, isMeasurement bool) {
+	left, top, right, _ := g.pdf.GetMargins()
+	auto, bottom := g.pdf.GetAutoPageBreak()
+	pageW, _ := g.pdf.GetPageSize()
+	g.pdf.SetMargins(x, top, pageW-x-width)
+	g.pdf.SetAutoPageBreak(auto, 0)
+	g.pdf.SetXY(x, y)
+	for _, block := range content {
+		g.renderBlock(block, isMeasurement)
+	}
+	g.pdf.SetMargins(left, top, right)
+	g.pdf.SetAutoPageBreak(auto, bottom)
+}
+
+// renderTableRow rendert eine Tabellenzeile (Hilfsfunktion für renderTable). heights[0] ist die
+// Höhe der Zeile, Zellen über mehrere Zeilen reichen über die folgenden Höhen. Von einer geteilten
+// Zeile werden nur die Textzeilen from bis to gesetzt (to < 0: bis zum Ende).
+func (g *Generator) renderTableRow(row tableCells, colWidths []float64, heights []float64, isHeader bool, rowIndex int, style tableStyle, from, to int, isMeasurement bool) {
 	left, _, _, _ := g.pdf.GetMargins()
 	startY := g.pdf.GetY()
-	colCount := len(colWidths)
+	cellPadding := style.padding
+	cellX := func(c tableCell) float64 {
+		return left + sumLengths(colWidths[:c.col])
+	}
+	cellH := func(c tableCell) float64 {
+		return sumLengths(heights[:min(c.rowSpan, len(heights))])
+	}
 
 	// Zeichne zuerst die Hintergründe und Rahmen für alle Zellen der Zeile
-	currentX := left
-	for i := range colWidths {
-		if i >= len(row) && !isHeader { // Falls die Zeile weniger Zellen hat als Spalten (sollte nicht sein)
-			break
-		}
-
-		g.pdf.SetXY(currentX, startY)
-
+	for _, c := range row {
 		// Hintergrundfarbe setzen
+		fill := style.oddRow
 		if isHeader {
-			g.pdf.SetFillColor(hBgR, hBgG, hBgB)
-		} else {
-			if rowIndex%2 == 0 {
-				g.pdf.SetFillColor(eRowR, eRowG, eRowB)
-			} else {
-				g.pdf.SetFillColor(oRowR, oRowG, oRowB)
-			}
+			fill = style.headerBg
+		} else if style.zebra && rowIndex%2 == 0 {
+			fill = style.evenRow
 		}
+		g.pdf.SetFillColor(fill[0], fill[1], fill[2])
 
 		// Zelle mit Hintergrund zeichnen
-		g.pdf.Rect(currentX, startY, colWidths[i], maxH, "F")
+		g.pdf.Rect(cellX(c), startY, c.width(colWidths), cellH(c), "F")
 
 		// Rahmen zeichnen
-		g.pdf.SetDrawColor(bR, bG, bB)
-		g.pdf.SetLineWidth(0.15)
-		g.pdf.Rect(currentX, startY, colWidths[i], maxH, "D")
-
-		currentX += colWidths[i]
+		g.pdf.SetDrawColor(style.border[0], style.border[1], style.border[2])
+		g.pdf.SetLineWidth(style.borderWidth)
+		g.pdf.Rect(cellX(c), startY, c.width(colWidths), cellH(c), "D")
 	}
 
-	// Dann den Text in die Zellen rendern
-	currentX = left
-	for i, cell := range row {
-		if i >= colCount {
-			break
+	// Dann den Inhalt in die Zellen rendern
+	for _, c := range row {
+		cell := c.cell
+		cellWidth := c.width(colWidths)
+
+		// Blockinhalt beginnt oben in der Zelle
+		if len(cell.Blocks) > 0 {
+			g.renderCellBlocks(cell.Blocks, cellX(c)+cellPadding, startY+cellPadding, cellWidth-2*cellPadding, isMeasurement)
+			continue
 		}
 
 		// Textfarbe und Font setzen
-		if cell.Header || isHeader {
-			g.pdf.SetTextColor(hTextR, hTextG, hTextB)
-			g.safeSetFont("main", "B", g.cfg.FontSize)
+		header := cell.Header || isHeader
+		if header {
+			g.pdf.SetTextColor(style.headerText[0], style.headerText[1], style.headerText[2])
+			g.safeSetFont("main", "B", style.fontSize)
 		} else {
 			g.setPrimaryTextColor()
-			g.safeSetFont("main", "", g.cfg.FontSize)
+			g.safeSetFont("main", "", style.fontSize)
 		}
 
-		cellText := ""
-		for _, seg := range cell.Content {
-			cellText += seg.Text
+		align := g.getAlign(g.cfg.Layout.Body)
+		switch style.align(c.col) {
+		case blocks.AlignCenter:
+			align = "C"
+		case blocks.AlignRight, blocks.AlignDecimal:
+			align = "R"
+		case blocks.AlignLeft:
+			align = "L"
 		}
 
-		align := g.getAlign(g.cfg.Layout.Body)
-		if i < len(alignments) {
-			switch alignments[i] {
-			case blocks.AlignCenter:
-				align = "C"
-			case blocks.AlignRight:
-				align = "R"
-			case blocks.AlignLeft:
-				align = "L"
+		// Text der Zelle, bei geteilten Zeilen nur der Ausschnitt für diese Seite
+		text := g.prepareText(cellText(cell))
+		lines := g.cellLines(cell, cellWidth-2*cellPadding)
+		if from > 0 || to >= 0 {
+			end := len(lines)
+			if to >= 0 && to < end {
+				end = to
 			}
+			lines = lines[min(from, end):end]
+			text = string(bytes.Join(lines, []byte("\n")))
+		}
+
+		// Dezimale Ausrichtung: kürzere Nachkommateile rücken nach links, damit die
+		// Dezimaltrennzeichen untereinander stehen
+		textWidth := cellWidth - 2*cellPadding
+		if style.align(c.col) == blocks.AlignDecimal && !header && c.colSpan == 1 {
+			textWidth -= style.decimals[c.col] - g.decimalWidth(cellText(cell))
 		}
 
 		// Vertikales Zentrieren
-		lines := g.pdf.SplitLines([]byte(g.prepareText(cellText)), colWidths[i]-2*cellPadding)
-		lineHeight := g.cfg.FontSize * 0.35 * 1.2
+		lineHeight := style.lineHeight()
 		textHeight := float64(len(lines)) * lineHeight
-		verticalOffset := (maxH - textHeight) / 2
+		verticalOffset := (cellH(c) - textHeight) / 2
 		if verticalOffset < cellPadding {
 			verticalOffset = cellPadding
 		}
 
-		g.pdf.SetXY(currentX+cellPadding, startY+verticalOffset)
+		g.pdf.SetXY(cellX(c)+cellPadding, startY+verticalOffset)
 
 		// Wenn align "J" (Justify) ist, müssen wir sicherstellen, dass MultiCell
 		// die Breite der Zelle abzüglich Padding nutzt.
-		g.pdf.MultiCell(colWidths[i]-2*cellPadding, lineHeight, g.prepareText(cellText), "", align, false)
-
-		currentX += colWidths[i]
+		g.pdf.MultiCell(textWidth, lineHeight, text, "", align, false)
 	}
 
 	// Nach der Zeile setzen wir den Y-Cursor absolut auf das Ende der Zeile
-	g.pdf.SetXY(left, startY+maxH)
+	g.pdf.SetXY(left, startY+heights[0])
 }
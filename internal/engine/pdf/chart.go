@@ -0,0 +1,424 @@
+package pdf
+
+import (
+	"math"
+	"strings"
+
+	"godocgen/internal/blocks"
+	"godocgen/internal/i18n"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// chartFallbackPalette ergänzt die Theme-Farben, wenn ein Chart mehr Reihen als Theme-Farben hat.
+var chartFallbackPalette = []string{
+	"#1e66f5", "#fe640b", "#40a02b", "#d20f39", "#8839ef", "#179299", "#df8e1d", "#ea76cb",
+}
+
+const (
+	chartDefaultHeight = 70.0 // Standardhöhe der Zeichenfläche in mm
+	chartFontSize      = 8.0  // Schriftgröße für Achsen und Legende
+	chartLegendRow     = 6.0  // Höhe einer Legendenzeile in mm
+)
+
+// chartPalette liefert n Farben für die Datenreihen: eigene Chart-Farben, dann Theme-Farben
+// (Akzent, Titel, Header) und zuletzt eine feste Palette.
+func (g *Generator) chartPalette(c blocks.ChartBlock, n int) []string {
+	seen := map[string]bool{strings.ToLower(g.cfg.Colors.Background): true}
+	var palette []string
+	add := func(color string) {
+		key := strings.ToLower(color)
+		if color == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		palette = append(palette, color)
+	}
+	for _, color := range c.Colors {
+		add(color)
+	}
+	add(g.cfg.Colors.Accent)
+	add(g.cfg.Colors.Title)
+	add(g.cfg.Colors.Header)
+	for _, color := range chartFallbackPalette {
+		add(color)
+	}
+	for len(palette) < n {
+		palette = append(palette, palette...)
+	}
+	return palette[:n]
+}
+
+// renderChart zeichnet einen ChartBlock als Vektorgrafik mit Achsen und Legende.
+func (g *Generator) renderChart(c blocks.ChartBlock) {
+	left, _, right, _ := g.pdf.GetMargins()
+	pageW, _ := g.pdf.GetPageSize()
+	width := pageW - left - right
+
+	plotH := c.Height
+	if plotH <= 0 {
+		plotH = chartDefaultHeight
+	}
+
+	// Legende: bei Tortendiagrammen die Kategorien, sonst die Datenreihen
+	var legend []string
+	colorCount := len(c.Series)
+	if c.Type == "pie" {
+		legend = g.pieLegend(c)
+		colorCount = len(c.Labels)
+	} else if len(c.Series) > 1 || c.Series[0].Name != "" {
+		for _, s := range c.Series {
+			legend = append(legend, s.Name)
+		}
+	}
+	colors := g.chartPalette(c, colorCount)
+
+	g.safeSetFont("main", "", chartFontSize)
+	legendH := g.chartLegendHeight(legend, width)
+
+	titleH := 0.0
+	if c.Title != "" {
+		titleH = 10.0
+	}
+	axisH := 0.0
+	if c.Type != "pie" {
+		axisH = 6.0 // Beschriftung der X-Achse
+		if c.Unit != "" {
+			titleH += 5 // Platz für die Einheit über der Y-Achse
+		}
+	}
+	totalH := titleH + plotH + axisH + legendH
+
+	g.checkPageBreak(totalH + 5)
+
+	y := g.pdf.GetY()
+	if c.Title != "" {
+		g.safeSetFont("main", "B", 10)
+		g.setPrimaryTextColor()
+		g.pdf.SetXY(left, y)
+		g.pdf.CellFormat(width, 8, g.prepareText(c.Title), "", 0, "C", false, 0, "")
+	}
+
+	lineWidth := g.pdf.GetLineWidth()
+	g.safeSetFont("main", "", chartFontSize)
+	if c.Type == "pie" {
+		g.drawPieChart(c, colors, left, y+titleH, width, plotH)
+	} else {
+		g.drawAxisChart(c, colors, left, y+titleH, width, plotH)
+	}
+	g.drawChartLegend(legend, colors, left, y+titleH+plotH+axisH, width)
+	g.pdf.SetLineWidth(lineWidth)
+	g.pdf.SetAlpha(1, "Normal")
+
+	g.pdf.SetXY(left, y+totalH+3)
+	g.pdf.Ln(2)
+}
+
+// chartRange berechnet den Wertebereich aller Reihen. Gestapelte Charts summieren
+// positive und negative Werte je Kategorie getrennt.
+func chartRange(c blocks.ChartBlock) (float64, float64) {
+	minV, maxV := 0.0, 0.0
+	if c.Type == "stacked" {
+		for i := range c.Labels {
+			pos, neg := 0.0, 0.0
+			for _, s := range c.Series {
+				if i < len(s.Values) {
+					if s.Values[i] >= 0 {
+						pos += s.Values[i]
+					} else {
+						neg += s.Values[i]
+					}
+				}
+			}
+			maxV = math.Max(maxV, pos)
+			minV = math.Min(minV, neg)
+		}
+		return minV, maxV
+	}
+	for _, s := range c.Series {
+		for _, v := range s.Values {
+			maxV = math.Max(maxV, v)
+			minV = math.Min(minV, v)
+		}
+	}
+	return minV, maxV
+}
+
+// niceScale liefert gut lesbare Achsengrenzen und Schrittweite für etwa ticks Unterteilungen.
+func niceScale(minV, maxV float64, ticks int) (float64, float64, float64) {
+	if maxV <= minV {
+		maxV = minV + 1
+	}
+	rawStep := (maxV - minV) / float64(ticks)
+	magnitude := math.Pow(10, math.Floor(math.Log10(rawStep)))
+	step := magnitude * 10
+	for _, f := range []float64{1, 2, 2.5, 5, 10} {
+		if rawStep <= f*magnitude {
+			step = f * magnitude
+			break
+		}
+	}
+	return math.Floor(minV/step) * step, math.Ceil(maxV/step) * step, step
+}
+
+// formatChartValue formatiert einen Achsenwert mit so vielen Nachkommastellen wie die Schrittweite benötigt.
+func (g *Generator) formatChartValue(v, step float64) string {
+	decimals := 0
+	if step > 0 && step < 1 {
+		decimals = int(math.Ceil(-math.Log10(step)))
+		if step*math.Pow(10, float64(decimals)) != math.Round(step*math.Pow(10, float64(decimals))) {
+			decimals++
+		}
+	}
+	if math.Abs(v) < step/1e6 {
+		v = 0
+	}
+	return g.locale.FormatFloat(v, decimals)
+}
+
+// drawAxisChart zeichnet Balken-, gestapelte Balken- und Liniendiagramme inklusive Achsen und Gitter.
+func (g *Generator) drawAxisChart(c blocks.ChartBlock, colors []string, x, y, width, height float64) {
+	minV, maxV := chartRange(c)
+	lo, hi, step := niceScale(minV, maxV, 5)
+
+	// Breite der Y-Achsenbeschriftung ermitteln
+	labelW := 0.0
+	for v := lo; v <= hi+step/2; v += step {
+		labelW = math.Max(labelW, g.pdf.GetStringWidth(g.formatChartValue(v, step)))
+	}
+	if c.Unit != "" {
+		labelW = math.Max(labelW, g.pdf.GetStringWidth(g.prepareText(c.Unit)))
+	}
+
+	plotX := x + labelW + 3
+	plotW := width - labelW - 3
+	plotY := y + 2 // Platz für die oberste Beschriftung
+	plotH := height - 2
+	yOf := func(v float64) float64 {
+		return plotY + plotH - (v-lo)/(hi-lo)*plotH
+	}
+
+	textR, textG, textB := 0, 0, 0
+	if g.cfg.Colors.Text != "" {
+		textR, textG, textB = hexToRGB(g.cfg.Colors.Text)
+	}
+	g.pdf.SetTextColor(textR, textG, textB)
+
+	// Gitterlinien und Y-Beschriftung
+	g.pdf.SetLineWidth(0.15)
+	for v := lo; v <= hi+step/2; v += step {
+		ly := yOf(v)
+		g.pdf.SetAlpha(0.2, "Normal")
+		g.pdf.SetDrawColor(textR, textG, textB)
+		g.pdf.Line(plotX, ly, plotX+plotW, ly)
+		g.pdf.SetAlpha(1, "Normal")
+		g.pdf.SetXY(x, ly-2)
+		g.pdf.CellFormat(labelW, 4, g.formatChartValue(v, step), "", 0, "R", false, 0, "")
+	}
+	if c.Unit != "" {
+		g.pdf.SetXY(x, plotY-6)
+		g.pdf.CellFormat(labelW, 4, g.prepareText(c.Unit), "", 0, "R", false, 0, "")
+	}
+
+	n := len(c.Labels)
+	catW := plotW / float64(n)
+	zeroY := yOf(math.Max(lo, math.Min(0, hi)))
+
+	switch c.Type {
+	case "bar":
+		groupW := catW * 0.7
+		barW := groupW / float64(len(c.Series))
+		for si, s := range c.Series {
+			g.setFillColor(colors[si])
+			for i, v := range s.Values {
+				bx := plotX + float64(i)*catW + (catW-groupW)/2 + float64(si)*barW
+				top, bottom := math.Min(yOf(v), zeroY), math.Max(yOf(v), zeroY)
+				g.pdf.Rect(bx, top, barW*0.92, bottom-top, "F")
+			}
+		}
+	case "stacked":
+		barW := catW * 0.6
+		for i := 0; i < n; i++ {
+			pos, neg := 0.0, 0.0
+			bx := plotX + float64(i)*catW + (catW-barW)/2
+			for si, s := range c.Series {
+				if i >= len(s.Values) {
+					continue
+				}
+				v := s.Values[i]
+				var from, to float64
+				if v >= 0 {
+					from, to = pos, pos+v
+					pos = to
+				} else {
+					from, to = neg, neg+v
+					neg = to
+				}
+				g.setFillColor(colors[si])
+				top, bottom := math.Min(yOf(from), yOf(to)), math.Max(yOf(from), yOf(to))
+				g.pdf.Rect(bx, top, barW, bottom-top, "F")
+			}
+		}
+	case "line":
+		for si, s := range c.Series {
+			r, gr, b := hexToRGB(colors[si])
+			g.pdf.SetDrawColor(r, gr, b)
+			g.pdf.SetFillColor(r, gr, b)
+			g.pdf.SetLineWidth(0.6)
+			for i := 1; i < len(s.Values); i++ {
+				x1 := plotX + (float64(i-1)+0.5)*catW
+				x2 := plotX + (float64(i)+0.5)*catW
+				g.pdf.Line(x1, yOf(s.Values[i-1]), x2, yOf(s.Values[i]))
+			}
+			for i, v := range s.Values {
+				g.pdf.Circle(plotX+(float64(i)+0.5)*catW, yOf(v), 0.9, "F")
+			}
+		}
+	}
+
+	// Achsen
+	g.pdf.SetDrawColor(textR, textG, textB)
+	g.pdf.SetLineWidth(0.3)
+	g.pdf.Line(plotX, plotY, plotX, plotY+plotH)
+	g.pdf.Line(plotX, zeroY, plotX+plotW, zeroY)
+
+	// X-Beschriftung, bei Platzmangel verkleinert
+	fontSize := chartFontSize
+	for _, label := range c.Labels {
+		for fontSize > 5 && g.pdf.GetStringWidth(g.prepareText(label)) > catW-1 {
+			fontSize -= 0.5
+			g.safeSetFont("main", "", fontSize)
+		}
+	}
+	g.pdf.SetTextColor(textR, textG, textB)
+	for i, label := range c.Labels {
+		g.pdf.SetXY(plotX+float64(i)*catW, plotY+plotH+1)
+		g.pdf.CellFormat(catW, 4, g.prepareText(label), "", 0, "C", false, 0, "")
+	}
+	g.safeSetFont("main", "", chartFontSize)
+}
+
+// pieLegend erzeugt die Legendeneinträge eines Tortendiagramms mit Prozentangaben.
+func (g *Generator) pieLegend(c blocks.ChartBlock) []string {
+	values := c.Series[0].Values
+	total := 0.0
+	for _, v := range values {
+		if v > 0 {
+			total += v
+		}
+	}
+	legend := make([]string, len(c.Labels))
+	for i, label := range c.Labels {
+		share := 0.0
+		if i < len(values) && values[i] > 0 && total > 0 {
+			share = values[i] / total * 100
+		}
+		legend[i] = g.locale.T(i18n.ChartShare, label, g.locale.FormatFloat(share, 1))
+	}
+	return legend
+}
+
+// drawPieChart zeichnet die erste Datenreihe als Tortendiagramm. Negative Werte werden ignoriert.
+func (g *Generator) drawPieChart(c blocks.ChartBlock, colors []string, x, y, width, height float64) {
+	values := c.Series[0].Values
+	total := 0.0
+	for _, v := range values {
+		if v > 0 {
+			total += v
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	radius := math.Min(height, width) / 2
+	cx, cy := x+width/2, y+height/2
+
+	// Trennlinien in Hintergrundfarbe, damit die Segmente sich abheben
+	bgR, bgG, bgB := 255, 255, 255
+	if g.cfg.Colors.Background != "" {
+		bgR, bgG, bgB = hexToRGB(g.cfg.Colors.Background)
+	}
+	g.pdf.SetDrawColor(bgR, bgG, bgB)
+	g.pdf.SetLineWidth(0.4)
+
+	angle := -90.0 // Start bei 12 Uhr, im Uhrzeigersinn
+	for i, v := range values {
+		if v <= 0 {
+			continue
+		}
+		sweep := v / total * 360
+		points := []gofpdf.PointType{{X: cx, Y: cy}}
+		steps := int(math.Ceil(sweep/2)) + 1
+		for s := 0; s <= steps; s++ {
+			a := (angle + sweep*float64(s)/float64(steps)) * math.Pi / 180
+			points = append(points, gofpdf.PointType{X: cx + radius*math.Cos(a), Y: cy + radius*math.Sin(a)})
+		}
+		g.setFillColor(colors[i])
+		g.pdf.Polygon(points, "DF")
+		angle += sweep
+	}
+}
+
+// chartLegendHeight berechnet die Höhe der Legende bei zeilenweisem Umbruch.
+func (g *Generator) chartLegendHeight(legend []string, width float64) float64 {
+	if len(legend) == 0 {
+		return 0
+	}
+	rows, lineW := 1, 0.0
+	for _, entry := range legend {
+		entryW := g.chartLegendEntryWidth(entry)
+		if lineW > 0 && lineW+entryW > width {
+			rows++
+			lineW = 0
+		}
+		lineW += entryW
+	}
+	return float64(rows)*chartLegendRow + 2
+}
+
+// chartLegendEntryWidth liefert die Breite eines Legendeneintrags (Farbfeld, Text, Abstand).
+func (g *Generator) chartLegendEntryWidth(entry string) float64 {
+	return 3 + 2 + g.pdf.GetStringWidth(g.prepareText(entry)) + 6
+}
+
+// drawChartLegend zeichnet die Legende zentriert unter dem Chart.
+func (g *Generator) drawChartLegend(legend []string, colors []string, x, y, width float64) {
+	if len(legend) == 0 {
+		return
+	}
+
+	// Einträge auf Zeilen verteilen
+	var rows [][]int
+	var current []int
+	lineW := 0.0
+	for i, entry := range legend {
+		entryW := g.chartLegendEntryWidth(entry)
+		if lineW > 0 && lineW+entryW > width {
+			rows = append(rows, current)
+			current, lineW = nil, 0
+		}
+		current = append(current, i)
+		lineW += entryW
+	}
+	rows = append(rows, current)
+
+	g.setPrimaryTextColor()
+	ly := y + 2
+	for _, row := range rows {
+		rowW := 0.0
+		for _, i := range row {
+			rowW += g.chartLegendEntryWidth(legend[i])
+		}
+		lx := x + (width-rowW)/2
+		for _, i := range row {
+			g.setFillColor(colors[i])
+			g.pdf.Rect(lx, ly+0.5, 3, 3, "F")
+			g.pdf.SetXY(lx+5, ly)
+			text := g.prepareText(legend[i])
+			g.pdf.CellFormat(g.pdf.GetStringWidth(text), 4, text, "", 0, "L", false, 0, "")
+			lx += g.chartLegendEntryWidth(legend[i])
+		}
+		ly += chartLegendRow
+	}
+}
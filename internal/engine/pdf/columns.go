@@ -0,0 +1,168 @@
+package pdf
+
+import "godocgen/internal/blocks"
+
+// columnState beschreibt einen aktiven mehrspaltigen Abschnitt. Die Spalten werden über die
+// Seitenränder umgesetzt: Jede Spalte ist für gofpdf eine schmale Seite, beim Erreichen des
+// unteren Rands wechselt der Text in die nächste Spalte und erst nach der letzten auf eine neue Seite.
+type columnState struct {
+	count     int     // Anzahl der Spalten
+	gap       float64 // Spaltenabstand in mm
+	rule      bool    // Trennlinie zwischen den Spalten
+	section   int     // Laufende Nummer des Abschnitts (Schlüssel für den Spaltenausgleich)
+	startPage int     // Seite, auf der der Abschnitt beginnt
+	index     int     // Aktuelle Spalte
+	top       float64 // Y-Position, an der die Spalten auf der aktuellen Seite beginnen
+	bottom    float64 // Tiefste erreichte Y-Position auf der aktuellen Seite
+	used      float64 // Summe der belegten Spaltenhöhen auf der aktuellen Seite
+	balanced  bool    // Spalten werden auf dieser Seite auf gleiche Höhe ausgeglichen
+}
+
+// columnBalance speichert aus dem Mess-Durchgang, wie ein Abschnitt auf seiner letzten Seite endet.
+type columnBalance struct {
+	pages int     // Anzahl der Folgeseiten nach der Startseite
+	used  float64 // Summe der belegten Spaltenhöhen auf der letzten Seite
+}
+
+// renderColumns beginnt oder beendet einen mehrspaltigen Abschnitt.
+func (g *Generator) renderColumns(c blocks.ColumnsBlock, isMeasurement bool) {
+	g.endColumns(isMeasurement)
+	if c.Count <= 1 {
+		return
+	}
+
+	g.endFloat(true)
+	gap := c.Gap
+	if gap <= 0 {
+		gap = g.cfg.Layout.Columns.Gap
+	}
+	rule := g.cfg.Layout.Columns.Rule
+	if c.Rule != nil {
+		rule = *c.Rule
+	}
+
+	g.columnSections++
+	g.columns = &columnState{
+		count:     c.Count,
+		gap:       gap,
+		rule:      rule,
+		section:   g.columnSections,
+		startPage: g.pdf.PageNo(),
+	}
+	g.startColumnPage(g.pdf.GetY())
+}
+
+// startColumnPage beginnt die Spalten auf der aktuellen Seite ab Position top.
+func (g *Generator) startColumnPage(top float64) {
+	c := g.columns
+	g.pdf.SetAutoPageBreak(true, g.cfg.Layout.Margins.Bottom)
+	c.index = 0
+	c.top = top
+	c.bottom = top
+	c.used = 0
+	c.balanced = false
+	g.setColumn(0)
+
+	// Auf der letzten Seite des Abschnitts die Spaltenhöhe aus dem Mess-Durchgang ausgleichen
+	if b, ok := g.columnBalances[c.section]; ok && g.pdf.PageNo()-c.startPage == b.pages {
+		limit := b.used/float64(c.count) + g.getLineHeight()
+		_, pageH := g.pdf.GetPageSize()
+		if top+limit < pageH-g.cfg.Layout.Margins.Bottom {
+			c.balanced = true
+			g.pdf.SetAutoPageBreak(true, pageH-top-limit)
+		}
+	}
+}
+
+// columnFrame liefert linken Rand und Breite der Spalte i auf der aktuellen Seite.
+func (g *Generator) columnFrame(i int) (float64, float64) {
+	c := g.columns
+	left, right := g.cfg.Layout.Margins.Horizontal(g.cfg.Layout.Duplex, g.pdf.PageNo())
+	pageW, _ := g.pdf.GetPageSize()
+	width := (pageW - left - right - float64(c.count-1)*c.gap) / float64(c.count)
+	return left + float64(i)*(width+c.gap), width
+}
+
+// setColumn setzt die Ränder auf Spalte i und springt an deren Anfang.
+func (g *Generator) setColumn(i int) {
+	x, width := g.columnFrame(i)
+	pageW, _ := g.pdf.GetPageSize()
+	g.pdf.SetLeftMargin(x)
+	g.pdf.SetRightMargin(pageW - x - width)
+	g.pdf.SetXY(x, g.columns.top)
+}
+
+// nextColumn wechselt in die nächste Spalte der Seite. Gibt false zurück, wenn die
+// aktuelle Spalte bereits die letzte ist.
+func (g *Generator) nextColumn() bool {
+	c := g.columns
+	if c == nil || c.index+1 >= c.count {
+		return false
+	}
+	g.endFloat(false)
+	y := g.pdf.GetY()
+	if y > c.bottom {
+		c.bottom = y
+	}
+	c.used += y - c.top
+	c.index++
+	g.setColumn(c.index)
+	if c.balanced && c.index == c.count-1 {
+		// Die letzte Spalte darf bis zum Seitenende laufen, damit kein Rest auf eine neue Seite fällt
+		g.pdf.SetAutoPageBreak(true, g.cfg.Layout.Margins.Bottom)
+	}
+	return true
+}
+
+// finishColumnPage zeichnet die Trennlinien zwischen den belegten Spalten der aktuellen Seite.
+func (g *Generator) finishColumnPage() {
+	c := g.columns
+	if c == nil {
+		return
+	}
+	bottom := c.bottom
+	if y := g.pdf.GetY(); y > bottom {
+		bottom = y
+	}
+	if !c.rule || c.index == 0 || bottom <= c.top {
+		return
+	}
+	r, green, b := hexToRGB(g.cfg.Colors.Header)
+	g.pdf.SetDrawColor(r, green, b)
+	g.pdf.SetLineWidth(0.2)
+	for i := 1; i <= c.index; i++ {
+		x, _ := g.columnFrame(i)
+		lineX := x - c.gap/2
+		g.pdf.Line(lineX, c.top, lineX, bottom)
+	}
+}
+
+// endColumns beendet einen mehrspaltigen Abschnitt und setzt die Position unter die längste Spalte.
+// Im Mess-Durchgang wird festgehalten, wie viel Inhalt auf der letzten Seite steht.
+func (g *Generator) endColumns(isMeasurement bool) {
+	c := g.columns
+	if c == nil {
+		return
+	}
+	g.endFloat(true)
+	g.finishColumnPage()
+
+	y := g.pdf.GetY()
+	if y < c.bottom {
+		y = c.bottom
+	}
+	if isMeasurement {
+		g.columnBalances[c.section] = columnBalance{
+			pages: g.pdf.PageNo() - c.startPage,
+			used:  c.used + g.pdf.GetY() - c.top,
+		}
+	}
+
+	g.columns = nil
+	left, right := g.cfg.Layout.Margins.Horizontal(g.cfg.Layout.Duplex, g.pdf.PageNo())
+	g.pdf.SetLeftMargin(left)
+	g.pdf.SetRightMargin(right)
+	g.pdf.SetAutoPageBreak(true, g.cfg.Layout.Margins.Bottom)
+	g.pdf.SetXY(left, y)
+	g.pdf.Ln(2)
+}
@@ -0,0 +1,155 @@
+package pdf
+
+import (
+	"godocgen/internal/config"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// namedCoverPage ist eine konfigurierte Umschlagseite mit ihrem Namen für Fehlermeldungen.
+type namedCoverPage struct {
+	name string
+	page *config.CoverPage
+}
+
+// coverPages liefert alle konfigurierten Umschlagseiten (Deckblatt, Innen- und Rückseite).
+func (g *Generator) coverPages() []namedCoverPage {
+	pages := []namedCoverPage{{"cover", &g.cfg.Cover.CoverPage}}
+	if g.cfg.Cover.Inside != nil {
+		pages = append(pages, namedCoverPage{"cover.inside", g.cfg.Cover.Inside})
+	}
+	if g.cfg.Cover.Back != nil {
+		pages = append(pages, namedCoverPage{"cover.back", g.cfg.Cover.Back})
+	}
+	return pages
+}
+
+// renderCover rendert das Deckblatt und, falls konfiguriert, die Innenseite des Umschlags.
+// Ohne eigene Gestaltung wird das Standard-Deckblatt gesetzt.
+func (g *Generator) renderCover() {
+	if g.cfg.Cover.IsEmpty() {
+		g.renderFrontPage()
+	} else {
+		g.pdf.AddPage()
+		g.renderCoverPage(g.cfg.Cover.CoverPage)
+	}
+	if g.cfg.Cover.Inside != nil {
+		g.pdf.AddPage()
+		g.renderCoverPage(*g.cfg.Cover.Inside)
+	}
+}
+
+// renderBackCover setzt die Rückseite des Umschlags als letzte Seite ohne Seitenzahl.
+// Im Duplex-Modus liegt sie auf einer linken Seite, davor wird bei Bedarf eine leere Seite eingefügt.
+func (g *Generator) renderBackCover() {
+	if g.cfg.Cover.Back == nil {
+		return
+	}
+	g.pendingSection = &pageSection{style: "none"}
+	if g.cfg.Layout.Duplex && g.pdf.PageNo()%2 == 0 {
+		g.blankPages[g.pdf.PageNo()+1] = true
+		g.pdf.AddPage()
+	}
+	g.pdf.AddPage()
+	g.renderCoverPage(*g.cfg.Cover.Back)
+}
+
+// renderCoverPage zeichnet Hintergrund, Bilder und Textfelder einer Umschlagseite.
+func (g *Generator) renderCoverPage(p config.CoverPage) {
+	// Felder am unteren Rand dürfen keinen Seitenumbruch auslösen
+	auto, margin := g.pdf.GetAutoPageBreak()
+	g.pdf.SetAutoPageBreak(false, 0)
+	defer g.pdf.SetAutoPageBreak(auto, margin)
+
+	w, h := g.pdf.GetPageSize()
+	switch {
+	case p.Background != "":
+		if path, ok := g.projectImage(p.Background, "Deckblatt"); ok {
+			g.pdf.ImageOptions(path, 0, 0, w, h, false, gofpdf.ImageOptions{}, 0, "")
+		}
+	case p.Color != "":
+		r, green, b := hexToRGB(p.Color)
+		g.pdf.SetFillColor(r, green, b)
+		g.pdf.Rect(0, 0, w, h, "F")
+	case g.cfg.Gradient.Enabled:
+		g.drawGradient(g.cfg.Gradient.Start, g.cfg.Gradient.End, g.cfg.Gradient.Orientation)
+	}
+
+	for _, img := range p.Images {
+		g.renderCoverImage(img)
+	}
+	for _, field := range p.Fields {
+		g.renderCoverField(field)
+	}
+}
+
+// coverPosition rechnet eine Position auf der Umschlagseite in Seitenkoordinaten um.
+// Ohne x beginnt das Element am linken Seitenrand, negative Werte zählen vom rechten bzw. unteren Rand.
+func (g *Generator) coverPosition(x *float64, y float64) (float64, float64) {
+	w, h := g.pdf.GetPageSize()
+	left, _, _, _ := g.pdf.GetMargins()
+	if x != nil {
+		left = *x
+		if left < 0 {
+			left += w
+		}
+	}
+	if y < 0 {
+		y += h
+	}
+	return left, y
+}
+
+// renderCoverImage platziert ein Bild (z.B. ein Logo) auf der Umschlagseite.
+func (g *Generator) renderCoverImage(img config.CoverImage) {
+	path, ok := g.projectImage(img.Path, "Deckblatt")
+	if !ok {
+		return
+	}
+	width, height := img.Width, img.Height
+	if width == 0 && height == 0 {
+		width = 40
+	}
+	x, y := g.coverPosition(img.X, img.Y)
+	g.pdf.ImageOptions(path, x, y, width, height, false, gofpdf.ImageOptions{}, 0, "")
+}
+
+// renderCoverField setzt ein Textfeld auf die Umschlagseite. Felder, deren Vorlage
+// einen leeren Text ergibt (z.B. ohne Einstufung), entfallen.
+func (g *Generator) renderCoverField(field config.CoverField) {
+	text := strings.TrimSpace(g.executeZone(field.Text))
+	if text == "" {
+		return
+	}
+
+	size := field.Size
+	if size == 0 {
+		size = g.cfg.FontSize
+	}
+	color := field.Color
+	if color == "" {
+		color = g.cfg.Colors.Title
+	}
+	align := field.Align
+	if align == "" {
+		align = g.cfg.Layout.StartPage
+	}
+
+	w, _ := g.pdf.GetPageSize()
+	_, _, right, _ := g.pdf.GetMargins()
+	x, y := g.coverPosition(field.X, field.Y)
+	width := field.Width
+	if width == 0 {
+		width = w - right - x
+		if width <= 0 {
+			width = w - x
+		}
+	}
+
+	g.safeSetFont("main", field.Style, size)
+	r, green, b := hexToRGB(color)
+	g.pdf.SetTextColor(r, green, b)
+	g.pdf.SetXY(x, y)
+	g.pdf.MultiCell(width, size*0.5, g.prepareText(text), "", g.getAlign(align), false)
+}
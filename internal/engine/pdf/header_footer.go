@@ -1,10 +1,6 @@
 package pdf
 
-import (
-	"fmt"
-	"strings"
-	"time"
-)
+import "godocgen/internal/i18n"
 
 // drawBackground zeichnet den Seitenhintergrund (Farbverlauf oder Vollfarbe).
 func (g *Generator) drawBackground() {
@@ -21,36 +17,65 @@ func (g *Generator) drawBackground() {
 // setupHeaderFooter konfiguriert die Header- und Footer-Funktionen für das PDF.
 func (g *Generator) setupHeaderFooter() {
 	g.pdf.SetHeaderFunc(func() {
+		// Hier beginnt der Inhalt, sobald der Header gezeichnet ist
+		// Mehrspaltige Abschnitte laufen auf der neuen Seite in der ersten Spalte weiter
+		defer func() {
+			g.pageTopY = g.pdf.GetY()
+			if g.columns != nil {
+				g.startColumnPage(g.pageTopY)
+			}
+		}()
+
+		// Ein umflossenes Bild endet mit der Seite
+		g.endFloat(false)
+		g.applyPendingSection()
+		g.recordRunningHead()
+		g.applyPageMargins()
 		g.drawBackground()
-		if g.inTOC || g.pdf.PageNo() == 1 || g.pdf.PageNo() < g.cfg.PageNumbers.StartPage {
-			return // Kein Header auf Titelseite, TOC oder vor Startseite
+		if g.inTOC || g.blankPages[g.pdf.PageNo()] || !g.pageNumbered(g.pdf.PageNo()) {
+			return // Kein Header auf Titelseite, erster TOC-Seite, leeren Rückseiten oder unnummerierten Seiten
 		}
 
-		left, top, _, _ := g.pdf.GetMargins()
+		left, top, right, _ := g.pdf.GetMargins()
+		w, _ := g.pdf.GetPageSize()
 		// Header-Y ist mittig im oberen Rand
 		headerY := top / 2
 		if headerY < 5 {
 			headerY = 10
 		}
 
-		g.pdf.SetY(headerY)
 		r, green, b := hexToRGB(g.cfg.Colors.Header)
 		g.pdf.SetTextColor(r, green, b)
-		g.safeSetFont("main", "", 8)
 
+		// Logo außen: links, auf linken Seiten im Duplex-Modus rechts
+		x, width := left, w-left-right
 		if g.cfg.Header.Image != "" {
-			g.pdf.Image(g.cfg.Header.Image, left, headerY, 20, 0, false, "", 0, "")
-			g.pdf.SetX(left + 25)
-		} else {
-			g.pdf.SetX(left)
+			imgX := left
+			if g.isVerso() {
+				imgX = w - right - 20
+			} else {
+				x += 25
+			}
+			g.pdf.Image(g.cfg.Header.Image, imgX, headerY, 20, 0, false, "", 0, "")
+			width -= 25
 		}
-
-		g.pdf.CellFormat(0, 10, g.prepareText(g.cfg.Header.Text), "", 0, "L", false, 0, "")
-		g.pdf.Ln(top)
+		g.renderZones(g.cfg.Header.PageZones, g.cfg.Header.Text, x, headerY, width)
+
+		contentY := headerY + top
+		if g.cfg.Header.Rule {
+			ruleY := headerY + g.cfg.Header.Height
+			g.pdf.SetDrawColor(r, green, b)
+			g.pdf.SetLineWidth(0.2)
+			g.pdf.Line(left, ruleY, w-right, ruleY)
+			if contentY < ruleY+3 {
+				contentY = ruleY + 3
+			}
+		}
+		g.pdf.SetXY(left, contentY)
 	})
 
 	g.pdf.SetFooterFunc(func() {
-		if g.inTOC || g.pdf.PageNo() == 1 || g.pdf.PageNo() < g.cfg.PageNumbers.StartPage {
+		if g.inTOC || g.blankPages[g.pdf.PageNo()] || !g.pageNumbered(g.pdf.PageNo()) {
 			return
 		}
 
@@ -60,14 +85,40 @@ func (g *Generator) setupHeaderFooter() {
 
 		g.renderFooterAt(0)
 	})
+
+	// Bei automatischen Seitenumbrüchen übernimmt gofpdf die X-Position der alten Seite.
+	// Sie wird auf den linken Rand der neuen Seite umgerechnet (Duplex-Ränder, umflossene Bilder).
+	// In mehrspaltigen Abschnitten wird stattdessen zuerst die nächste Spalte begonnen.
+	g.pdf.SetAcceptPageBreakFunc(func() bool {
+		auto, _ := g.pdf.GetAutoPageBreak()
+		if !auto {
+			return false
+		}
+		if g.nextColumn() {
+			return false
+		}
+		g.finishColumnPage()
+		left, _, _, _ := g.pdf.GetMargins()
+		nextLeft, _ := g.cfg.Layout.Margins.Horizontal(g.cfg.Layout.Duplex, g.pdf.PageNo()+1)
+		g.pdf.SetX(g.pdf.GetX() - left + nextLeft)
+		return true
+	})
 }
 
 // renderFooterAt rendert den Footer an einer bestimmten Y-Position oder am Seitenende.
 func (g *Generator) renderFooterAt(y float64) {
-	left, _, right, bottom := g.pdf.GetMargins()
+	// Seitenränder ohne Verschiebung durch umflossene Bilder
+	_, _, _, bottom := g.pdf.GetMargins()
+	left, right := g.cfg.Layout.Margins.Horizontal(g.cfg.Layout.Duplex, g.pdf.PageNo())
 	w, h := g.pdf.GetPageSize()
 	width := w - left - right
 
+	// Im Duplex-Modus steht das Bild auf linken (geraden) Seiten außen rechts
+	imgX := left
+	if g.isVerso() {
+		imgX = w - right - 15
+	}
+
 	if y == 0 {
 		// Footer-Y setzen (Abstand vom unteren Rand)
 		footerY := -(bottom * 0.8)
@@ -79,55 +130,32 @@ func (g *Generator) renderFooterAt(y float64) {
 		g.pdf.SetY(y)
 	}
 
-	g.safeSetFont("main", "", 8)
 	g.pdf.SetTextColor(128, 128, 128)
+	footerY := g.pdf.GetY()
 
 	if g.cfg.Footer.Image != "" {
 		imgY := h - bottom + 2
 		if y != 0 {
 			imgY = y + 2
 		}
-		g.pdf.Image(g.cfg.Footer.Image, left, imgY, 15, 0, false, "", 0, "")
+		g.pdf.Image(g.cfg.Footer.Image, imgX, imgY, 15, 0, false, "", 0, "")
 	}
 
-	// Zonen rendern
-	if g.cfg.Footer.Left != "" {
-		g.pdf.SetX(left)
-		g.pdf.CellFormat(width, 10, g.prepareText(g.replacePlaceholders(g.cfg.Footer.Left)), "", 0, "L", false, 0, "")
-	}
-	if g.cfg.Footer.Center != "" {
-		g.pdf.SetX(left)
-		g.pdf.CellFormat(width, 10, g.prepareText(g.replacePlaceholders(g.cfg.Footer.Center)), "", 0, "C", false, 0, "")
-	}
-	if g.cfg.Footer.Right != "" {
-		g.pdf.SetX(left)
-		g.pdf.CellFormat(width, 10, g.prepareText(g.replacePlaceholders(g.cfg.Footer.Right)), "", 0, "R", false, 0, "")
-	}
-}
-
-// replacePlaceholders ersetzt Variablen wie {page}, {total}, {title} durch ihre aktuellen Werte.
-func (g *Generator) replacePlaceholders(text string) string {
-	displayPage := g.pdf.PageNo() - g.cfg.PageNumbers.StartPage + 1
-	totalDisplayPages := g.totalPages - g.cfg.PageNumbers.StartPage + 1
-	if totalDisplayPages < 1 {
-		totalDisplayPages = 1
-	}
-
-	if strings.Contains(text, "{page}") && strings.Contains(text, "{total}") {
-		// fmt.Printf("Rendering Page %d of %d\n", displayPage, totalDisplayPages)
+	if g.cfg.Footer.Rule {
+		r, green, b := hexToRGB(g.cfg.Colors.Header)
+		g.pdf.SetDrawColor(r, green, b)
+		g.pdf.SetLineWidth(0.2)
+		g.pdf.Line(left, footerY, w-right, footerY)
 	}
 
-	text = strings.ReplaceAll(text, "{page}", fmt.Sprintf("%d", displayPage))
-	text = strings.ReplaceAll(text, "{total}", fmt.Sprintf("%d", totalDisplayPages))
-	text = strings.ReplaceAll(text, "{title}", g.cfg.Title)
-	text = strings.ReplaceAll(text, "{author}", g.cfg.Author)
-	text = strings.ReplaceAll(text, "{date}", time.Now().Format("02.01.2006"))
-	return text
+	// Zonen rendern
+	g.renderZones(g.cfg.Footer.PageZones, "", left, footerY, width)
 }
 
 // renderFrontPage rendert das Deckblatt des Dokuments.
 func (g *Generator) renderFrontPage() {
 	g.pdf.AddPage()
+	_, pageH := g.pdf.GetPageSize()
 
 	r, green, b := hexToRGB(g.cfg.Colors.Title)
 
@@ -139,7 +167,7 @@ func (g *Generator) renderFrontPage() {
 		if !g.cfg.Gradient.Enabled && g.cfg.Colors.Background == "" {
 		} else if !g.cfg.Gradient.Enabled {
 			g.pdf.SetFillColor(r, green, b)
-			g.pdf.Rect(0, 0, 10, 297, "F")
+			g.pdf.Rect(0, 0, 10, pageH, "F")
 		}
 		g.pdf.SetTextColor(r, green, b)
 	}
@@ -168,17 +196,18 @@ func (g *Generator) renderFrontPage() {
 		if !g.cfg.Gradient.Enabled {
 			g.pdf.SetTextColor(120, 120, 120)
 		}
-		g.pdf.MultiCell(0, 10, g.prepareText(fmt.Sprintf("Autor: %s", g.cfg.Author)), "", align, false)
+		g.pdf.MultiCell(0, 10, g.prepareText(g.locale.T(i18n.CoverAuthor, g.cfg.Author)), "", align, false)
 	}
 
-	g.pdf.SetY(250)
+	// Autor und Datum am unteren Seitenrand (auf A4 bei 250 mm)
+	g.pdf.SetY(pageH - 47)
 	g.pdf.SetX(left)
 	g.safeSetFont("main", "", 12)
 	if !g.cfg.Gradient.Enabled {
 		g.pdf.SetTextColor(128, 128, 128)
 	}
 	if g.cfg.Author != "" {
-		g.pdf.MultiCell(0, 10, g.prepareText(fmt.Sprintf("Erstellt von: %s", g.cfg.Author)), "", align, false)
+		g.pdf.MultiCell(0, 10, g.prepareText(g.locale.T(i18n.CoverCreatedBy, g.cfg.Author)), "", align, false)
 	}
-	g.pdf.MultiCell(0, 10, g.prepareText(fmt.Sprintf("Datum: %s", time.Now().Format("02.01.2006"))), "", align, false)
+	g.pdf.MultiCell(0, 10, g.prepareText(g.locale.T(i18n.CoverDate, g.locale.FormatDate(g.build.Time, g.cfg.DateFormat))), "", align, false)
 }
@@ -0,0 +1,71 @@
+package pdf
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/hyphen"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// setupHyphenation lädt die Trennmuster der Dokumentsprache oder die eigene Musterdatei des Projekts.
+func (g *Generator) setupHyphenation() error {
+	g.hyphens = nil
+	opts := g.cfg.Layout.Hyphenation
+	if !opts.Enabled {
+		return nil
+	}
+
+	var h *hyphen.Hyphenator
+	if opts.Patterns != "" {
+		path := opts.Patterns
+		if !filepath.IsAbs(path) && g.build.ProjectDir != "" {
+			path = filepath.Join(g.build.ProjectDir, path)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("Trennmuster konnten nicht geladen werden: %w", err)
+		}
+		defer f.Close()
+		if h, err = hyphen.Parse(f); err != nil {
+			return fmt.Errorf("Trennmuster %s: %w", opts.Patterns, err)
+		}
+	} else {
+		var err error
+		if h, err = hyphen.ForLanguage(g.cfg.Language); err != nil {
+			return err
+		}
+		if h == nil {
+			fmt.Printf("Warnung: Keine Trennmuster für die Sprache %q vorhanden (eingebettet: %s), Silbentrennung deaktiviert.\n",
+				g.cfg.Language, strings.Join(hyphen.Languages(), ", "))
+			return nil
+		}
+	}
+
+	for _, word := range opts.Exceptions {
+		h.AddException(word)
+	}
+	g.hyphens = h
+	return nil
+}
+
+// hyphenateSegments fügt weiche Trennzeichen in den Fließtext ein. Inline-Code und Links bleiben unverändert.
+func (g *Generator) hyphenateSegments(segs []blocks.TextSegment) []blocks.TextSegment {
+	if g.hyphens == nil {
+		return segs
+	}
+	result := make([]blocks.TextSegment, len(segs))
+	for i, seg := range segs {
+		if !seg.Code && seg.Link == "" {
+			seg.Text = g.hyphens.HyphenateText(seg.Text, g.cfg.Layout.Hyphenation.MinWord)
+		}
+		result[i] = seg
+	}
+	return result
+}
+
+// stripSoftHyphens entfernt weiche Trennzeichen, an denen nicht umbrochen wurde.
+func stripSoftHyphens(text string) string {
+	return strings.ReplaceAll(text, hyphen.SoftHyphen, "")
+}
@@ -0,0 +1,123 @@
+package pdf
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+)
+
+// PlacedImageSize liefert die Größe in mm, in der renderImage ein Bild mit den
+// Quellmaßen srcW x srcH (beliebige Einheit, nur das Seitenverhältnis zählt) platziert.
+// Der Builder nutzt sie, um Rasterbilder vor dem Einbetten auf die Ziel-DPI zu verkleinern.
+func PlacedImageSize(cfg *config.Config, img blocks.ImageBlock, srcW, srcH float64, landscape bool) (float64, float64) {
+	m := cfg.Layout.Margins
+	left, right := m.Horizontal(cfg.Layout.Duplex, 1)
+	pageW, pageH := PageSize(cfg, landscape)
+	return imageSize(img, srcW, srcH, pageW-left-right, pageH-m.Top-m.Bottom)
+}
+
+// imageSize berechnet Breite und Höhe eines Bildes für die verfügbare Textbreite und Seitenhöhe.
+func imageSize(i blocks.ImageBlock, srcW, srcH, maxWidth, pageHeight float64) (float64, float64) {
+	maxPageHeight := pageHeight - 40
+
+	// Bilder mit Rahmen lassen Platz für den Container
+	limit := maxWidth - 20
+	if i.NoBorder {
+		limit = maxWidth
+	}
+
+	height := i.Height
+	if i.HeightPercent > 0 {
+		height = pageHeight * i.HeightPercent / 100
+	}
+
+	// Berechne Bildbreite basierend auf Konfiguration
+	var widthOnPage float64
+	if i.WidthPercent > 0 {
+		// Breite relativ zur Textbreite
+		widthOnPage = maxWidth * i.WidthPercent / 100
+	} else if i.Width > 0 {
+		// Explizite Breite angegeben
+		widthOnPage = i.Width
+	} else if height > 0 && srcH > 0 {
+		// Nur Höhe angegeben: Breite folgt dem Seitenverhältnis
+		widthOnPage = (srcW / srcH) * height
+	} else if i.Scale > 0 && i.Scale != 1.0 {
+		// Skalierungsfaktor angegeben
+		widthOnPage = limit * i.Scale
+	} else if i.Float != "" {
+		// Umflossene Bilder nehmen standardmäßig 40% der Textbreite ein
+		widthOnPage = maxWidth * 0.4
+	} else {
+		// Standard: fast volle Breite
+		widthOnPage = limit
+	}
+	if widthOnPage > limit {
+		widthOnPage = limit
+		if i.WidthPercent == 0 && i.Width == 0 {
+			// Breite wurde aus der Höhe abgeleitet, daher Höhe mitskalieren
+			height = 0
+		}
+	}
+
+	var h float64
+	if height > 0 {
+		h = height
+	} else if srcW > 0 {
+		h = (srcH / srcW) * widthOnPage
+	} else {
+		h = 60.0
+	}
+
+	titleHeight := 0.0
+	if i.Title != "" {
+		titleHeight = 10.0
+	}
+
+	// Zu hohe Bilder auf die Seitenhöhe begrenzen, die Breite folgt dem Seitenverhältnis
+	if h+titleHeight > maxPageHeight {
+		h = maxPageHeight - titleHeight
+		widthOnPage = 0
+		if srcH > 0 {
+			widthOnPage = (srcW / srcH) * h
+		}
+	}
+
+	return widthOnPage, h
+}
+
+// floatState beschreibt ein umflossenes Bild, dessen Platz über die Seitenränder freigehalten wird.
+type floatState struct {
+	bottom float64 // Y-Position unterhalb des Bildes
+	left   float64 // Ursprünglicher linker Rand
+	right  float64 // Ursprünglicher rechter Rand
+}
+
+// startFloat verschiebt den linken bzw. rechten Rand um die Bildbreite, damit folgende
+// Absätze neben dem Bild umbrechen.
+func (g *Generator) startFloat(side string, width, bottom float64) {
+	left, _, right, _ := g.pdf.GetMargins()
+	g.float = &floatState{bottom: bottom, left: left, right: right}
+	gap := 5.0
+	if side == "left" {
+		g.pdf.SetLeftMargin(left + width + gap)
+	} else {
+		g.pdf.SetRightMargin(right + width + gap)
+	}
+}
+
+// endFloat stellt die Ränder wieder her. Mit below wird die Position unter das Bild gesetzt,
+// falls der Text neben dem Bild kürzer war.
+func (g *Generator) endFloat(below bool) {
+	if g.float == nil {
+		return
+	}
+	f := g.float
+	g.float = nil
+	g.pdf.SetLeftMargin(f.left)
+	g.pdf.SetRightMargin(f.right)
+	if below && g.pdf.GetY() < f.bottom {
+		g.pdf.SetY(f.bottom)
+	} else {
+		g.pdf.SetX(f.left)
+	}
+}
@@ -0,0 +1,215 @@
+package pdf
+
+import (
+	"godocgen/internal/blocks"
+	"math"
+	"strings"
+)
+
+// Umbruchregeln: Absätze halten die konfigurierten Mindestzeilen am Seitenende (orphans) und am
+// Anfang der neuen Seite (widows) zusammen. Überschriften und Einleitungssätze bleiben mit dem
+// Anfang des folgenden Blocks auf einer Seite, Gruppen aus <!-- keep-together --> möglichst ganz.
+// Die Höhe der folgenden Blöcke wird vorab in einem eigenen Mess-Dokument bestimmt, daher treffen
+// beide Durchgänge dieselben Entscheidungen.
+
+// blockBreakSpace ist der Abstand zum unteren Rand, den Code, Bilder, Diagramme und Tabellen bei
+// ihrer eigenen Umbruchprüfung zusätzlich zu ihrer Höhe verlangen.
+const blockBreakSpace = 10.0
+
+// breakPage setzt den Inhalt in der nächsten Spalte oder auf einer neuen Seite fort.
+func (g *Generator) breakPage() {
+	if g.nextColumn() {
+		return
+	}
+	g.addPage()
+}
+
+// contentTop liefert die Y-Position, an der der Inhalt der aktuellen Seite oder Spalte beginnt.
+func (g *Generator) contentTop() float64 {
+	if g.columns != nil {
+		return g.columns.top
+	}
+	return g.pageTopY
+}
+
+// atColumnTop prüft, ob in der aktuellen Spalte (bzw. auf der Seite) noch kein Inhalt steht.
+func (g *Generator) atColumnTop() bool {
+	return g.pdf.GetY() <= g.contentTop()+0.01
+}
+
+// spaceLeft liefert die verbleibende Höhe bis zum unteren Rand.
+func (g *Generator) spaceLeft() float64 {
+	_, _, _, bottom := g.pdf.GetMargins()
+	_, pageH := g.pdf.GetPageSize()
+	return pageH - bottom - g.pdf.GetY()
+}
+
+// spaceTotal liefert die Höhe einer leeren Seite oder Spalte.
+func (g *Generator) spaceTotal() float64 {
+	_, _, _, bottom := g.pdf.GetMargins()
+	_, pageH := g.pdf.GetPageSize()
+	return pageH - bottom - g.contentTop()
+}
+
+// keepLines liefert, wie viele der restlichen Zeilen eines Absatzes auf der aktuellen Seite oder
+// Spalte stehen. 0 bedeutet, dass der Absatz erst nach einem Umbruch beginnt. Auf einer leeren
+// Seite (fresh) steht immer mindestens eine Zeile, damit der Absatz nicht endlos weiterwandert.
+func (g *Generator) keepLines(done, total int, lineHeight float64, fresh bool) int {
+	remaining := total - done
+	fit := int(math.Floor(g.spaceLeft()/lineHeight + 1e-6))
+	if fit >= remaining {
+		return remaining
+	}
+	if fresh || g.atColumnTop() {
+		return max(fit, 1)
+	}
+
+	rules := g.cfg.Layout.Breaks
+	if remaining-fit < rules.Widows {
+		fit = remaining - rules.Widows
+	}
+	if done == 0 && fit < rules.Orphans {
+		return 0
+	}
+	return max(fit, 0)
+}
+
+// keepBlocks wendet vor dem Setzen eines Blocks die Regeln für zusammengehörige Blöcke an:
+// Einleitungs- und Beschriftungsabsätze (mit Doppelpunkt am Ende) sowie der Block nach
+// <!-- keep-with-next --> bleiben mit dem Anfang des folgenden Blocks auf einer Seite, Gruppen
+// aus <!-- keep-together --> beginnen auf einer neuen Seite, wenn sie dort ganz Platz finden.
+// Überschriften prüft renderHeading selbst, nachdem Kapitel ggf. eine neue Seite begonnen haben.
+func (g *Generator) keepBlocks(block blocks.DocBlock, following []blocks.DocBlock) {
+	switch b := block.(type) {
+	case blocks.ParagraphBlock:
+		if isIntroduction(b) {
+			g.keepWithNext(g.measureBlocks([]blocks.DocBlock{b}), following)
+		}
+	case blocks.KeepBlock:
+		switch b.Keep {
+		case "next":
+			if len(following) > 0 {
+				g.keepWithNext(g.measureBlocks(following[:1]), following[1:])
+			}
+		case "together":
+			end := len(following)
+			for i, next := range following {
+				if k, ok := next.(blocks.KeepBlock); ok && k.Keep == "end" {
+					end = i
+					break
+				}
+			}
+			g.keepTogether(g.measureBlocks(following[:end]))
+		}
+	}
+}
+
+// isIntroduction erkennt Absätze, die den folgenden Block einleiten ("Die Tabelle zeigt:").
+func isIntroduction(p blocks.ParagraphBlock) bool {
+	var text strings.Builder
+	for _, seg := range p.Content {
+		text.WriteString(seg.Text)
+	}
+	return strings.HasSuffix(strings.TrimSpace(text.String()), ":")
+}
+
+// keepWithNext hält einen Inhalt der Höhe h mit dem Anfang der folgenden Blöcke zusammen. Passt
+// beides nicht einmal auf eine leere Seite, genügen die Mindestzeilen eines Absatzes.
+func (g *Generator) keepWithNext(h float64, following []blocks.DocBlock) {
+	needed := h + g.leadHeight(following)
+	if needed > g.spaceTotal() {
+		needed = h + float64(g.cfg.Layout.Breaks.Orphans)*g.getLineHeight()
+	}
+	g.keepTogether(needed)
+}
+
+// keepTogether beginnt eine neue Seite oder Spalte, wenn ein Inhalt der Höhe h nicht mehr auf die
+// aktuelle, aber auf eine leere passt. Längere Inhalte werden ohnehin umbrochen und bleiben stehen.
+func (g *Generator) keepTogether(h float64) {
+	if g.atColumnTop() || h > g.spaceTotal() {
+		return
+	}
+	if h > g.spaceLeft() {
+		g.breakPage()
+	}
+}
+
+// leadHeight liefert die Höhe, die vom Anfang der folgenden Blöcke mindestens auf derselben Seite
+// stehen muss: die ersten Zeilen eines Absatzes oder einer Liste, Bilder und Diagramme ganz,
+// Überschriften zusammen mit ihrem eigenen Folgeblock. Teilbare Blöcke, die nicht auf eine Seite
+// passen, werden ohnehin umbrochen und verlangen nur die Mindestzeilen.
+func (g *Generator) leadHeight(following []blocks.DocBlock) float64 {
+	minLines := float64(g.cfg.Layout.Breaks.Orphans) * g.getLineHeight()
+	for i, block := range following {
+		switch b := block.(type) {
+		case blocks.KeepBlock, blocks.DiagramBlock, blocks.ColumnsBlock:
+			continue
+		case blocks.PageBreakBlock, blocks.OrientationBlock, blocks.MatterBlock:
+			return 0
+		case blocks.ParagraphBlock:
+			return g.paragraphLead(b)
+		case blocks.HeadingBlock:
+			return g.measureBlocks(following[i:i+1]) + g.leadHeight(following[i+1:])
+		case blocks.ListBlock, blocks.BlockquoteBlock:
+			return math.Min(g.measureBlocks(following[i:i+1]), minLines)
+		default:
+			h := g.measureBlocks(following[i:i+1]) + blockBreakSpace
+			if h > g.spaceTotal() {
+				return minLines
+			}
+			return h
+		}
+	}
+	return 0
+}
+
+// paragraphLead liefert die Höhe der Zeilen, die ein Absatz am Seitenende mindestens belegt.
+// Absätze, die sich nach den Umbruchregeln nicht teilen lassen, zählen ganz.
+func (g *Generator) paragraphLead(p blocks.ParagraphBlock) float64 {
+	left, _, right, _ := g.pdf.GetMargins()
+	pageW, _ := g.pdf.GetPageSize()
+	lineWidth := pageW - left - right - 2*g.pdf.GetCellMargin()
+	align := g.getAlign(g.cfg.Layout.Body)
+	ragged := 0.0
+	if align != "J" {
+		ragged = raggedStretch
+	}
+	items := g.paragraphItems(g.hyphenateSegments(p.Content), lineWidth, align == "J")
+	lines := len(breakParagraph(items, fixedWidth(lineWidth), ragged))
+
+	rules := g.cfg.Layout.Breaks
+	if lines >= rules.Orphans+rules.Widows {
+		lines = rules.Orphans
+	}
+	return float64(lines) * g.getLineHeight()
+}
+
+// measureBlocks misst die Höhe, die Blöcke mit den aktuellen Rändern ab dem Anfang einer leeren
+// Seite einnehmen. Gesetzt wird in einem eigenen Dokument, das nie gespeichert wird; Umbrüche
+// zählen mit der vollen Höhe der übersprungenen Seiten.
+func (g *Generator) measureBlocks(blks []blocks.DocBlock) float64 {
+	if g.measurer == nil {
+		cfg := *g.cfg
+		cfg.Layout.Duplex = false
+		g.measurer = NewGenerator(&cfg, nil, g.fontDir)
+	}
+	m := g.measurer
+	m.build, m.locale, m.hyphens, m.landscape = g.build, g.locale, g.hyphens, g.landscape
+	copy(m.headingCounts, g.headingCounts)
+
+	left, _, right, bottom := g.pdf.GetMargins()
+	top := g.contentTop()
+	m.addPage()
+	m.pdf.SetMargins(left, top, right)
+	m.pdf.SetAutoPageBreak(true, bottom)
+	m.pdf.SetXY(left, top)
+	m.pageTopY = top
+	start := m.pdf.PageNo()
+	for _, block := range blks {
+		m.renderBlock(block, true)
+	}
+	m.endFloat(true)
+
+	_, pageH := m.pdf.GetPageSize()
+	return float64(m.pdf.PageNo()-start)*(pageH-bottom-top) + m.pdf.GetY() - top
+}
@@ -56,8 +56,10 @@ func (g *Generator) getAlign(align string) string {
 // checkPageBreak prüft, ob die verbleibende Höhe auf der Seite ausreicht, und fügt ggf. eine neue Seite hinzu.
 func (g *Generator) checkPageBreak(h float64) {
 	_, _, _, bottom := g.pdf.GetMargins()
-	if g.pdf.GetY()+h > 297-bottom {
-		g.pdf.AddPage()
+	_, pageH := g.pdf.GetPageSize()
+	if g.pdf.GetY()+h > pageH-bottom {
+		// In mehrspaltigen Abschnitten zuerst in die nächste Spalte wechseln
+		g.breakPage()
 	}
 }
 
@@ -66,27 +68,28 @@ func (g *Generator) drawGradient(startColor, endColor string, orientation string
 	sr, sg, sb := hexToRGB(startColor)
 	er, eg, eb := hexToRGB(endColor)
 
+	pageW, pageH := g.pdf.GetPageSize()
 	steps := 100
 	if orientation == "horizontal" {
-		w := 210.0 / float64(steps)
+		w := pageW / float64(steps)
 		for i := 0; i < steps; i++ {
 			ratio := float64(i) / float64(steps)
 			currR := int(float64(sr) + ratio*float64(er-sr))
 			currG := int(float64(sg) + ratio*float64(eg-sg))
 			currB := int(float64(sb) + ratio*float64(eb-sb))
 			g.pdf.SetFillColor(currR, currG, currB)
-			g.pdf.Rect(float64(i)*w, 0, w+0.1, 297, "F")
+			g.pdf.Rect(float64(i)*w, 0, w+0.1, pageH, "F")
 		}
 	} else {
 		// Vertikal (Standard)
-		h := 297.0 / float64(steps)
+		h := pageH / float64(steps)
 		for i := 0; i < steps; i++ {
 			ratio := float64(i) / float64(steps)
 			currR := int(float64(sr) + ratio*float64(er-sr))
 			currG := int(float64(sg) + ratio*float64(eg-sg))
 			currB := int(float64(sb) + ratio*float64(eb-sb))
 			g.pdf.SetFillColor(currR, currG, currB)
-			g.pdf.Rect(0, float64(i)*h, 210, h+0.1, "F")
+			g.pdf.Rect(0, float64(i)*h, pageW, h+0.1, "F")
 		}
 	}
 }
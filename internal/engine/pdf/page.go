@@ -0,0 +1,87 @@
+package pdf
+
+import (
+	"godocgen/internal/config"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// newDocument erstellt ein gofpdf-Dokument im konfigurierten Seitenformat.
+func newDocument(cfg *config.Config) *gofpdf.Fpdf {
+	w, h, _ := cfg.Layout.Page.Dimensions()
+	orientation := "P"
+	if cfg.Layout.Page.Landscape() {
+		orientation = "L"
+	}
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "mm",
+		Size:           gofpdf.SizeType{Wd: w, Ht: h},
+	})
+	pdf.SetCompression(true)
+	left, right := cfg.Layout.Margins.Horizontal(cfg.Layout.Duplex, 1)
+	pdf.SetMargins(left, cfg.Layout.Margins.Top, right)
+	pdf.SetAutoPageBreak(true, cfg.Layout.Margins.Bottom)
+	return pdf
+}
+
+// PageSize liefert Breite und Höhe der Seite in mm, im Hoch- oder Querformat.
+func PageSize(cfg *config.Config, landscape bool) (float64, float64) {
+	w, h, _ := cfg.Layout.Page.Dimensions()
+	if landscape {
+		return h, w
+	}
+	return w, h
+}
+
+// addPage beginnt eine neue Inhaltsseite in der aktuellen Ausrichtung (Querformat-Abschnitte
+// bleiben über Seitenumbrüche hinweg erhalten).
+func (g *Generator) addPage() {
+	g.finishColumnPage()
+	w, h, _ := g.cfg.Layout.Page.Dimensions()
+	orientation := "P"
+	if g.landscape {
+		orientation = "L"
+	}
+	g.pdf.AddPageFormat(orientation, gofpdf.SizeType{Wd: w, Ht: h})
+}
+
+// setOrientation wechselt für die folgenden Inhalte ins Hoch- oder Querformat.
+// Da sich das Format einer begonnenen Seite nicht ändern lässt, beginnt eine neue Seite.
+func (g *Generator) setOrientation(orientation string) {
+	landscape := orientation == "landscape"
+	if landscape == g.landscape {
+		return
+	}
+	g.endFloat(true)
+	g.landscape = landscape
+	g.addPage()
+}
+
+// applyPageMargins setzt die seitenabhängigen Ränder (gespiegelt im Duplex-Modus).
+func (g *Generator) applyPageMargins() {
+	left, right := g.cfg.Layout.Margins.Horizontal(g.cfg.Layout.Duplex, g.pdf.PageNo())
+	g.pdf.SetLeftMargin(left)
+	g.pdf.SetRightMargin(right)
+	g.pdf.SetX(left)
+}
+
+// isVerso prüft, ob die aktuelle Seite im Duplex-Modus eine linke (gerade) Seite ist.
+func (g *Generator) isVerso() bool {
+	return g.cfg.Layout.Duplex && g.pdf.PageNo()%2 == 0
+}
+
+// atPageTop prüft, ob auf der aktuellen Seite noch kein Inhalt gesetzt wurde.
+func (g *Generator) atPageTop() bool {
+	return g.pdf.GetY() <= g.pageTopY+0.01
+}
+
+// addRightPage beginnt eine neue Seite. Im Duplex-Modus ist das immer eine rechte (ungerade)
+// Seite, davor wird bei Bedarf eine leere Rückseite ohne Kopf- und Fußzeile eingefügt.
+func (g *Generator) addRightPage() {
+	if g.cfg.Layout.Duplex && (g.pdf.PageNo()+1)%2 == 0 {
+		g.blankPages[g.pdf.PageNo()+1] = true
+		g.addPage()
+	}
+	g.addPage()
+}
@@ -0,0 +1,305 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"godocgen/internal/blocks"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pageSection beschreibt einen Dokumentteil mit eigener Seitennummerierung
+// (Deckblatt, Vorspann, Hauptteil, Anhangskapitel). Jeder Teil beginnt bei 1.
+type pageSection struct {
+	style  string // "arabic", "roman", "Roman", "alpha", "Alpha" oder "none" (ohne Nummer)
+	prefix string // Präfix vor der Nummer, z.B. "A-" für Anhänge
+}
+
+// label formatiert die n-te Seite des Dokumentteils.
+func (s pageSection) label(n int) string {
+	if s.style == "none" {
+		return ""
+	}
+	return s.prefix + formatPageNumber(s.style, n)
+}
+
+// formatPageNumber formatiert eine Seitenzahl im angegebenen Stil.
+// Buchstaben folgen der PDF-Konvention: a-z, dann aa-zz usw.
+func formatPageNumber(style string, n int) string {
+	switch style {
+	case "roman":
+		return strings.ToLower(toRoman(n))
+	case "Roman":
+		return toRoman(n)
+	case "alpha":
+		return strings.ToLower(toAlpha(n))
+	case "Alpha":
+		return toAlpha(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// toRoman wandelt eine Zahl in römische Ziffern um (z.B. 14 -> XIV).
+func toRoman(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	var sb strings.Builder
+	for i, v := range values {
+		for n >= v {
+			sb.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return sb.String()
+}
+
+// toAlpha wandelt eine Zahl in Großbuchstaben um (1 -> A, 26 -> Z, 27 -> AA).
+func toAlpha(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	return strings.Repeat(string(rune('A'+(n-1)%26)), (n-1)/26+1)
+}
+
+// frontMatterSection liefert die Nummerierung für Inhaltsverzeichnis und Vorspann.
+func (g *Generator) frontMatterSection() pageSection {
+	return pageSection{style: g.cfg.PageNumbers.FrontMatter}
+}
+
+// mainMatterSection liefert die Nummerierung für den Hauptteil.
+func (g *Generator) mainMatterSection() pageSection {
+	return pageSection{style: g.cfg.PageNumbers.Style}
+}
+
+// beginPageSection lässt den Dokumentteil s auf der nächsten Seite beginnen.
+// Eine feste Startseite (page_numbers.start_page) ersetzt die automatische Einteilung.
+func (g *Generator) beginPageSection(s pageSection) {
+	if g.cfg.PageNumbers.StartPage > 1 {
+		return
+	}
+	g.pendingSection = &s
+}
+
+// applyPendingSection ordnet einen angekündigten Dokumentteil der gerade begonnenen Seite zu.
+// Leere Rückseiten gehören noch zum vorherigen Teil.
+func (g *Generator) applyPendingSection() {
+	if g.pendingSection == nil || g.blankPages[g.pdf.PageNo()] {
+		return
+	}
+	g.pageSections[g.pdf.PageNo()] = *g.pendingSection
+	g.pendingSection = nil
+}
+
+// startPageSection beginnt den Dokumentteil s auf einer neuen (im Duplex-Modus rechten) Seite.
+// Ist die aktuelle Seite noch leer, beginnt der Teil bereits auf ihr. Folgt s auf einen
+// gleichartigen Teil (z.B. Vorwort direkt nach dem Inhaltsverzeichnis), läuft die Zählung weiter.
+func (g *Generator) startPageSection(s pageSection) {
+	page := g.pdf.PageNo()
+	if g.atPageTop() && !g.isVerso() {
+		if prev, _ := g.pageSectionAt(page - 1); prev == s {
+			delete(g.pageSections, page)
+		} else {
+			g.pageSections[page] = s
+		}
+		return
+	}
+	if cur, _ := g.pageSectionAt(page); cur == s {
+		g.addRightPage()
+		return
+	}
+	g.pendingSection = &s
+	g.addRightPage()
+}
+
+// renderMatter verarbeitet die Direktiven für Vorspann, Hauptteil und Anhang.
+func (g *Generator) renderMatter(m blocks.MatterBlock) {
+	switch m.Matter {
+	case "front":
+		g.appendix = false
+		g.startPageSection(g.frontMatterSection())
+	case "main":
+		g.appendix = false
+		g.startPageSection(g.mainMatterSection())
+	case "appendix":
+		// Die Anhangskapitel beginnen mit ihrer H1-Überschrift
+		g.appendix = g.cfg.PageNumbers.Appendix == "letter"
+	}
+}
+
+// startAppendixChapter beginnt ein Anhangskapitel mit eigener Zählung (A-1, B-1, ...).
+func (g *Generator) startAppendixChapter() {
+	g.appendixCount++
+	g.startPageSection(pageSection{
+		style:  g.cfg.PageNumbers.Style,
+		prefix: toAlpha(g.appendixCount) + "-",
+	})
+}
+
+// pageSectionAt liefert den Dokumentteil einer Seite und dessen erste Seite.
+func (g *Generator) pageSectionAt(page int) (pageSection, int) {
+	start := 0
+	for p := range g.pageSections {
+		if p <= page && p > start {
+			start = p
+		}
+	}
+	if start == 0 {
+		return g.mainMatterSection(), 1
+	}
+	return g.pageSections[start], start
+}
+
+// pageLabel liefert die gedruckte Seitenzahl einer physischen Seite (leer für unnummerierte Seiten).
+func (g *Generator) pageLabel(page int) string {
+	s, start := g.pageSectionAt(page)
+	return s.label(page - start + 1)
+}
+
+// pageNumbered prüft, ob eine Seite eine Seitenzahl trägt (nicht z.B. das Deckblatt).
+func (g *Generator) pageNumbered(page int) bool {
+	s, _ := g.pageSectionAt(page)
+	return s.style != "none"
+}
+
+// sectionTotal liefert die letzte Seitenzahl des Dokumentteils, zu dem die Seite gehört.
+func (g *Generator) sectionTotal(page int) string {
+	s, start := g.pageSectionAt(page)
+	end := g.totalPages
+	for p := range g.pageSections {
+		if p > start && p-1 < end {
+			end = p - 1
+		}
+	}
+	if end < page {
+		end = page
+	}
+	return s.label(end - start + 1)
+}
+
+// shiftPageSections verschiebt die Dokumentteile ab Seite from um offset Seiten
+// (Korrektur nach der Messung des Inhaltsverzeichnisses).
+func (g *Generator) shiftPageSections(from, offset int) {
+	if g.cfg.PageNumbers.StartPage > 1 {
+		return
+	}
+	g.pageSections = shiftPages(g.pageSections, from, offset)
+}
+
+// shiftPages verschiebt alle Seitenschlüssel ab Seite from um offset Seiten.
+func shiftPages[T any](pages map[int]T, from, offset int) map[int]T {
+	if offset == 0 {
+		return pages
+	}
+	shifted := make(map[int]T, len(pages))
+	for p, v := range pages {
+		if p >= from {
+			p += offset
+		}
+		shifted[p] = v
+	}
+	return shifted
+}
+
+// pageLabelNums erzeugt den Inhalt des /Nums-Arrays für das /PageLabels-Wörterbuch.
+func (g *Generator) pageLabelNums() string {
+	pages := make([]int, 0, len(g.pageSections))
+	for p := range g.pageSections {
+		pages = append(pages, p)
+	}
+	sort.Ints(pages)
+
+	styles := map[string]string{"arabic": "/D", "roman": "/r", "Roman": "/R", "alpha": "/a", "Alpha": "/A"}
+	var sb strings.Builder
+	for _, p := range pages {
+		s := g.pageSections[p]
+		sb.WriteString(fmt.Sprintf("%d <<", p-1))
+		if style, ok := styles[s.style]; ok {
+			sb.WriteString(" /S " + style)
+		}
+		if s.prefix != "" {
+			sb.WriteString(" /P (" + s.prefix + ")")
+		}
+		sb.WriteString(" >> ")
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// writePDF speichert das Dokument und ergänzt den Katalog um /PageLabels,
+// damit PDF-Betrachter dieselben Seitenzahlen anzeigen wie Fußzeilen und Inhaltsverzeichnis.
+func (g *Generator) writePDF(outputPath string) error {
+	var buf bytes.Buffer
+	if err := g.pdf.Output(&buf); err != nil {
+		return err
+	}
+	data, err := addPageLabels(buf.Bytes(), g.pageLabelNums())
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, data, 0644)
+}
+
+var (
+	trailerRootRe = regexp.MustCompile(`/Root (\d+) 0 R`)
+	trailerInfoRe = regexp.MustCompile(`/Info (\d+) 0 R`)
+	trailerSizeRe = regexp.MustCompile(`/Size (\d+)`)
+	startxrefRe   = regexp.MustCompile(`startxref\s+(\d+)`)
+)
+
+// addPageLabels hängt ein inkrementelles Update an das PDF an, das den Katalog
+// um /PageLabels erweitert. gofpdf bietet dafür keine eigene Schnittstelle.
+func addPageLabels(data []byte, nums string) ([]byte, error) {
+	if nums == "" {
+		return data, nil
+	}
+	trailerPos := bytes.LastIndex(data, []byte("trailer"))
+	if trailerPos < 0 {
+		return nil, fmt.Errorf("PDF-Trailer nicht gefunden")
+	}
+	trailer := data[trailerPos:]
+	root := trailerRootRe.FindSubmatch(trailer)
+	size := trailerSizeRe.FindSubmatch(trailer)
+	prev := startxrefRe.FindSubmatch(trailer)
+	if root == nil || size == nil || prev == nil {
+		return nil, fmt.Errorf("PDF-Trailer unvollständig")
+	}
+
+	// Bisherigen Katalog kopieren und um die Seitenbeschriftungen ergänzen
+	objStart := bytes.Index(data, []byte("\n"+string(root[1])+" 0 obj\n"))
+	if objStart < 0 {
+		return nil, fmt.Errorf("PDF-Katalog %s nicht gefunden", root[1])
+	}
+	objStart += len(root[1]) + len(" 0 obj\n") + 1
+	objEnd := bytes.Index(data[objStart:], []byte("\nendobj"))
+	if objEnd < 0 {
+		return nil, fmt.Errorf("PDF-Katalog %s unvollständig", root[1])
+	}
+	catalog := bytes.TrimSpace(data[objStart : objStart+objEnd])
+	if !bytes.HasSuffix(catalog, []byte(">>")) {
+		return nil, fmt.Errorf("PDF-Katalog %s unvollständig", root[1])
+	}
+	catalog = catalog[:len(catalog)-2]
+
+	var out bytes.Buffer
+	out.Write(data)
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		out.WriteByte('\n')
+	}
+	offset := out.Len()
+	fmt.Fprintf(&out, "%s 0 obj\n%s/PageLabels << /Nums [%s] >>\n>>\nendobj\n", root[1], catalog, nums)
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 1\n0000000000 65535 f \n%s 1\n%010d 00000 n \n", root[1], offset)
+	out.WriteString("trailer\n<<\n")
+	fmt.Fprintf(&out, "/Size %s\n/Root %s 0 R\n", size[1], root[1])
+	if info := trailerInfoRe.FindSubmatch(trailer); info != nil {
+		fmt.Fprintf(&out, "/Info %s 0 R\n", info[1])
+	}
+	fmt.Fprintf(&out, "/Prev %s\n>>\nstartxref\n%d\n%%%%EOF\n", prev[1], xref)
+	return out.Bytes(), nil
+}
@@ -0,0 +1,658 @@
+package pdf
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/hyphen"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Absatzumbruch nach Knuth und Plass ("total fit"): Ein Absatz wird in Boxen (Wörter, Wortteile,
+// Inline-Code), dehnbare Zwischenräume (glue) und mögliche Trennstellen (penalty) zerlegt. Unter allen
+// zulässigen Umbrüchen wird derjenige gewählt, der über den ganzen Absatz die geringsten Kosten
+// (demerits) hat, statt jede Zeile einzeln so voll wie möglich zu machen.
+
+// itemKind unterscheidet die Elemente eines Absatzes.
+type itemKind int
+
+const (
+	boxItem     itemKind = iota // Unteilbarer Text: Wort, Wortteil oder Inline-Code
+	glueItem                    // Wortzwischenraum, im Blocksatz dehn- und stauchbar
+	penaltyItem                 // Mögliche (oder erzwungene) Umbruchstelle, z.B. an einem weichen Trennzeichen
+)
+
+// Parameter des Zeilenumbruchs (angelehnt an die Standardwerte von TeX).
+const (
+	linePenalty     = 10.0    // Grundkosten jeder Zeile
+	hyphenPenalty   = 50.0    // Kosten einer Trennung
+	flaggedDemerits = 3000.0  // Zusatzkosten für zwei getrennte Zeilen in Folge
+	fitnessDemerits = 3000.0  // Zusatzkosten für stark unterschiedlich gedehnte Nachbarzeilen
+	infPenalty      = 10000.0 // Ab diesem Wert ist kein Umbruch möglich, ab -infPenalty ist er erzwungen
+	overfullBadness = 1e6     // Bewertung einer überlangen Zeile im Notfall-Durchgang
+	raggedStretch   = 1.0     // Dehnung am Zeilenende bei Flattersatz (Anteil der Zeilenbreite)
+	chipPaddingH    = 2.5     // Horizontaler Innenabstand eines Inline-Code-Chips
+	chipPaddingV    = 1.2     // Vertikaler Innenabstand eines Inline-Code-Chips
+	chipGap         = 1.5     // Abstand nach einem Chip, wenn direkt Text folgt
+)
+
+// Toleranzen der Umbruch-Durchgänge (maximales Dehnungsverhältnis einer Zeile). Findet keiner eine
+// Lösung, läuft ein Notfall-Durchgang ohne Obergrenze, der im Zweifel auch überlange Zeilen zulässt.
+var lineTolerances = []float64{1, 3}
+
+// lineItem ist ein Element des Absatzmodells.
+type lineItem struct {
+	kind    itemKind
+	text    string             // Text einer Box
+	seg     blocks.TextSegment // Formatierung (Fett, Kursiv, Code, Link, ...)
+	width   float64            // Natürliche Breite; bei penalty die Breite des Trennstrichs
+	stretch float64            // Maximale Dehnung eines Zwischenraums
+	shrink  float64            // Maximale Stauchung eines Zwischenraums
+	penalty float64            // Kosten eines Umbruchs an dieser Stelle
+	flagged bool               // Umbruch erzeugt einen Trennstrich
+}
+
+// breakNode ist ein möglicher Zeilenumbruch mit den günstigsten Kosten bis dorthin.
+type breakNode struct {
+	position int        // Index des Elements, an dem umbrochen wird
+	line     int        // Nummer der Zeile, die hier endet
+	fitness  int        // Dehnungsklasse der Zeile (0 = gestaucht ... 3 = sehr locker)
+	width    float64    // Summe der Breiten bis zum Beginn der nächsten Zeile
+	stretch  float64    // Summe der Dehnungen bis zum Beginn der nächsten Zeile
+	shrink   float64    // Summe der Stauchungen bis zum Beginn der nächsten Zeile
+	demerits float64    // Gesamtkosten bis hier
+	prev     *breakNode // Vorheriger Umbruch
+}
+
+// renderSegments setzt einen formatierten Absatz mit der gewünschten Ausrichtung ("L", "C", "R", "J").
+// Bei width 0 reicht der Absatz von der aktuellen X-Position bis zum rechten Rand.
+func (g *Generator) renderSegments(segs []blocks.TextSegment, width, lineHeight float64, align string) {
+	left, _, right, _ := g.pdf.GetMargins()
+	x := g.pdf.GetX()
+	if width == 0 {
+		pageW, _ := g.pdf.GetPageSize()
+		width = pageW - right - x
+	}
+	indent := x - left
+
+	// Neben einem umflossenen Bild sind die Ränder verschoben. Nur die Zeilen bis zur Unterkante
+	// des Bildes sind schmaler, darunter läuft der Text wieder über die volle Breite.
+	narrow, extra := 0, 0.0
+	if f := g.float; f != nil {
+		extra = left - f.left + right - f.right
+		narrow = max(int(math.Ceil((f.bottom-g.pdf.GetY())/lineHeight-0.001)), 0)
+		if narrow == 0 {
+			g.endFloat(false)
+			left = f.left
+		}
+	}
+
+	// Wie bei MultiCell bleibt links und rechts der Zellenabstand frei
+	margin := g.pdf.GetCellMargin()
+	widths := lineWidths{narrow: narrow, narrowWidth: width - 2*margin, width: width + extra - 2*margin}
+	g.pdf.SetCellMargin(0)
+	defer g.pdf.SetCellMargin(margin)
+
+	// Im Flattersatz bleiben die Zwischenräume fest, stattdessen darf jede Zeile am Ende kürzer sein
+	ragged := 0.0
+	if align != "J" {
+		ragged = raggedStretch
+	}
+	items := g.paragraphItems(g.hyphenateSegments(segs), widths.at(0), align == "J")
+	breaks := breakParagraph(items, widths, ragged)
+	if len(breaks) == 0 {
+		g.pdf.Ln(lineHeight)
+		return
+	}
+
+	// Zeilen seitenweise setzen, dabei Hurenkinder und Schusterjungen vermeiden
+	start := 0
+	fresh := false
+	for i := 0; i < len(breaks); {
+		n := g.keepLines(i, len(breaks), lineHeight, fresh)
+		for end := i + n; i < end; i++ {
+			g.checkPageBreak(lineHeight)
+			left, _, _, _ = g.pdf.GetMargins()
+			y := g.pdf.GetY()
+			g.renderLine(items, start, breaks[i], left+indent+margin, y, widths.at(i), lineHeight, align, i == len(breaks)-1)
+			g.pdf.SetXY(left, y+lineHeight)
+			start = nextLineStart(items, breaks[i])
+			if g.float != nil && i+1 == narrow {
+				g.endFloat(false)
+			}
+		}
+		if i < len(breaks) {
+			g.breakPage()
+			fresh = true
+		}
+	}
+
+	g.safeSetFont("main", "", g.cfg.FontSize)
+	g.setPrimaryTextColor()
+}
+
+// segmentFont liefert Schriftfamilie, Stil und Größe eines Segments.
+func (g *Generator) segmentFont(seg blocks.TextSegment) (string, string, float64) {
+	if seg.Code {
+		family := "main"
+		if g.cfg.Fonts.Mono != "" {
+			family = "mono"
+		}
+		// Inline-Code verwendet eine etwas kleinere Schriftgröße als normaler Text
+		size := g.cfg.Code.FontSize
+		if size <= 0 {
+			size = g.cfg.FontSize * 0.9
+		}
+		return family, "", size
+	}
+	style := ""
+	if seg.Bold {
+		style += "B"
+	}
+	if seg.Italic {
+		style += "I"
+	}
+	return "main", style, g.cfg.FontSize
+}
+
+// measure misst einen Text in der Schrift eines Segments.
+func (g *Generator) measure(seg blocks.TextSegment, text string) float64 {
+	family, style, size := g.segmentFont(seg)
+	g.safeSetFont(family, style, size)
+	return g.pdf.GetStringWidth(g.prepareText(text))
+}
+
+// paragraphItems zerlegt die Segmente eines Absatzes in Boxen, Zwischenräume und Trennstellen.
+func (g *Generator) paragraphItems(segs []blocks.TextSegment, lineWidth float64, justify bool) []lineItem {
+	var items []lineItem
+	addGlue := func(seg blocks.TextSegment) {
+		if len(items) == 0 || items[len(items)-1].kind == glueItem {
+			return
+		}
+		w := g.measure(seg, " ")
+		glue := lineItem{kind: glueItem, seg: seg, width: w}
+		if justify {
+			glue.stretch, glue.shrink = w/2, w/3
+		}
+		items = append(items, glue)
+	}
+
+	for _, seg := range segs {
+		if seg.Code {
+			items = g.codeItems(items, seg, lineWidth, addGlue)
+			continue
+		}
+		word := ""
+		flush := func() {
+			if word != "" {
+				items = g.wordItems(items, seg, word, lineWidth)
+				word = ""
+			}
+		}
+		for _, r := range seg.Text {
+			// Geschützte Leerzeichen (&nbsp;) verbinden Wörter und sind keine Umbruchstelle
+			if unicode.IsSpace(r) && r != '\u00a0' {
+				flush()
+				addGlue(seg)
+				continue
+			}
+			word += string(r)
+		}
+		flush()
+	}
+
+	// Zwischenräume am Absatzende entfallen, die letzte Zeile wird mit einem unendlich dehnbaren Rest aufgefüllt
+	for len(items) > 0 && items[len(items)-1].kind == glueItem {
+		items = items[:len(items)-1]
+	}
+	if len(items) == 0 {
+		return nil
+	}
+
+	// Chips erhalten einen kleinen Abstand, wenn direkt Text folgt
+	for i := 0; i < len(items)-1; i++ {
+		if items[i].kind == boxItem && items[i].seg.Code && items[i+1].kind == boxItem {
+			items[i].width += chipGap
+		}
+	}
+
+	return append(items,
+		lineItem{kind: penaltyItem, penalty: infPenalty},
+		lineItem{kind: glueItem, stretch: infPenalty * lineWidth},
+		lineItem{kind: penaltyItem, penalty: -infPenalty},
+	)
+}
+
+// wordItems fügt ein Wort als Boxen ein. Weiche Trennzeichen und Bindestriche zwischen Buchstaben werden
+// zu Trennstellen, Wortteile breiter als die Zeile (z.B. lange URLs) werden notfalls hart geteilt.
+func (g *Generator) wordItems(items []lineItem, seg blocks.TextSegment, word string, lineWidth float64) []lineItem {
+	hyphenWidth := g.measure(seg, "-")
+	for i, part := range strings.Split(word, hyphen.SoftHyphen) {
+		if i > 0 {
+			items = append(items, lineItem{kind: penaltyItem, seg: seg, width: hyphenWidth, penalty: hyphenPenalty, flagged: true})
+		}
+		for j, piece := range splitAfterHyphens(part) {
+			if j > 0 {
+				items = append(items, lineItem{kind: penaltyItem, seg: seg, penalty: hyphenPenalty, flagged: true})
+			}
+			for k, chunk := range g.splitToWidth(seg, piece, lineWidth) {
+				if k > 0 {
+					items = append(items, lineItem{kind: penaltyItem, seg: seg})
+				}
+				items = append(items, lineItem{kind: boxItem, seg: seg, text: chunk, width: g.measure(seg, chunk)})
+			}
+		}
+	}
+	return items
+}
+
+// codeItems fügt Inline-Code als Chip ein. Passt er nicht in eine Zeile, wird er an Leerzeichen in mehrere Chips geteilt.
+func (g *Generator) codeItems(items []lineItem, seg blocks.TextSegment, lineWidth float64, addGlue func(blocks.TextSegment)) []lineItem {
+	if seg.Text == "" {
+		return items
+	}
+	chip := func(text string) lineItem {
+		return lineItem{kind: boxItem, seg: seg, text: text, width: g.measure(seg, text) + 2*chipPaddingH}
+	}
+	if box := chip(seg.Text); box.width <= lineWidth || !strings.Contains(strings.TrimSpace(seg.Text), " ") {
+		return append(items, box)
+	}
+	for i, word := range strings.Fields(seg.Text) {
+		if i > 0 {
+			addGlue(blocks.TextSegment{})
+		}
+		items = append(items, chip(word))
+	}
+	return items
+}
+
+// splitAfterHyphens teilt ein Wort hinter Bindestrichen zwischen zwei Buchstaben ("E-Mail-Adresse").
+func splitAfterHyphens(word string) []string {
+	runes := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(runes)-1; i++ {
+		if runes[i] == '-' && unicode.IsLetter(runes[i-1]) && unicode.IsLetter(runes[i+1]) {
+			parts = append(parts, string(runes[start:i+1]))
+			start = i + 1
+		}
+	}
+	return append(parts, string(runes[start:]))
+}
+
+// splitToWidth teilt einen Text, der allein breiter als die Zeile ist, in passende Stücke.
+func (g *Generator) splitToWidth(seg blocks.TextSegment, text string, lineWidth float64) []string {
+	if g.measure(seg, text) <= lineWidth {
+		return []string{text}
+	}
+	var chunks []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		n := 1
+		for n < len(runes) && g.measure(seg, string(runes[:n+1])) <= lineWidth {
+			n++
+		}
+		chunks = append(chunks, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return chunks
+}
+
+// lineWidths beschreibt die Zeilenbreiten eines Absatzes: Die ersten narrow Zeilen stehen neben
+// einem umflossenen Bild und haben die Breite narrowWidth, alle weiteren die Breite width.
+type lineWidths struct {
+	narrow      int
+	narrowWidth float64
+	width       float64
+}
+
+// fixedWidth liefert Zeilenbreiten ohne umflossenes Bild.
+func fixedWidth(width float64) lineWidths {
+	return lineWidths{width: width}
+}
+
+// at liefert die Breite der Zeile line (ab 0).
+func (w lineWidths) at(line int) float64 {
+	if line < w.narrow {
+		return w.narrowWidth
+	}
+	return w.width
+}
+
+// breakParagraph bestimmt die Umbruchstellen eines Absatzes und liefert die Indizes der Elemente,
+// an denen die Zeilen enden. ragged ist die zusätzliche Dehnbarkeit jeder Zeile im Verhältnis zu
+// ihrer Breite (Flattersatz).
+func breakParagraph(items []lineItem, widths lineWidths, ragged float64) []int {
+	if len(items) == 0 {
+		return nil
+	}
+	for _, tolerance := range lineTolerances {
+		if breaks := findBreaks(items, widths, ragged, tolerance, false); breaks != nil {
+			return breaks
+		}
+	}
+	return findBreaks(items, widths, ragged, math.Inf(1), true)
+}
+
+// findBreaks ist ein Durchgang des Knuth-Plass-Algorithmus. Im Notfall-Durchgang (emergency) werden
+// auch überlange Zeilen akzeptiert, damit immer eine Lösung existiert.
+// Solange die Zeilen unterschiedlich breit sind, werden Umbrüche nur bei gleicher Zeilennummer
+// zusammengefasst, danach wie üblich nach Dehnungsklasse.
+func findBreaks(items []lineItem, widths lineWidths, ragged, tolerance float64, emergency bool) []int {
+	var sumWidth, sumStretch, sumShrink float64
+	active := []*breakNode{{position: -1}}
+
+	for b, item := range items {
+		legal := false
+		switch item.kind {
+		case glueItem:
+			legal = b > 0 && items[b-1].kind == boxItem
+		case penaltyItem:
+			legal = item.penalty < infPenalty
+		}
+
+		if legal {
+			candidates := make([][4]*breakNode, widths.narrow+1)
+			var next []*breakNode
+			for _, a := range active {
+				width := sumWidth - a.width
+				if item.kind == penaltyItem {
+					width += item.width
+				}
+				lineWidth := widths.at(a.line)
+				ratio := adjustmentRatio(width, sumStretch-a.stretch+ragged*lineWidth, sumShrink-a.shrink, lineWidth)
+
+				forced := item.kind == penaltyItem && item.penalty <= -infPenalty
+				if ratio >= -1 && !forced {
+					next = append(next, a)
+				}
+				if ratio < -1 && !emergency {
+					continue
+				}
+				if ratio > tolerance {
+					continue
+				}
+
+				badness := 100 * math.Pow(math.Abs(ratio), 3)
+				if ratio < -1 {
+					badness = overfullBadness // Überlange Zeile nur als letzter Ausweg
+				}
+				demerits := math.Pow(linePenalty+badness, 2)
+				switch {
+				case item.kind == penaltyItem && item.penalty >= 0:
+					demerits += item.penalty * item.penalty
+				case item.kind == penaltyItem && item.penalty > -infPenalty:
+					demerits -= item.penalty * item.penalty
+				}
+				if a.position >= 0 && items[a.position].flagged && item.flagged {
+					demerits += flaggedDemerits
+				}
+				fitness := fitnessClass(ratio)
+				if a.position >= 0 && absInt(fitness-a.fitness) > 1 {
+					demerits += fitnessDemerits
+				}
+				demerits += a.demerits
+
+				class := min(a.line+1, widths.narrow)
+				if c := candidates[class][fitness]; c == nil || demerits < c.demerits {
+					candidates[class][fitness] = &breakNode{position: b, line: a.line + 1, fitness: fitness, demerits: demerits, prev: a}
+				}
+			}
+
+			// Summen ab dem Beginn der nächsten Zeile: Zwischenräume und Trennstellen nach dem Umbruch entfallen
+			w, y, z := sumWidth, sumStretch, sumShrink
+			for j := b; j < len(items) && items[j].kind != boxItem; j++ {
+				if items[j].kind == glueItem {
+					w, y, z = w+items[j].width, y+items[j].stretch, z+items[j].shrink
+				} else if j > b && items[j].penalty <= -infPenalty {
+					break
+				}
+			}
+			for _, class := range candidates {
+				for _, c := range class {
+					if c != nil {
+						c.width, c.stretch, c.shrink = w, y, z
+						next = append(next, c)
+					}
+				}
+			}
+			active = next
+			if len(active) == 0 {
+				return nil
+			}
+		}
+
+		if item.kind != penaltyItem {
+			sumWidth += item.width
+			sumStretch += item.stretch
+			sumShrink += item.shrink
+		}
+	}
+
+	// Der günstigste Umbruch am erzwungenen Absatzende
+	var best *breakNode
+	for _, a := range active {
+		if a.position == len(items)-1 && (best == nil || a.demerits < best.demerits) {
+			best = a
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	breaks := make([]int, best.line)
+	for n := best; n.position >= 0; n = n.prev {
+		breaks[n.line-1] = n.position
+	}
+	return breaks
+}
+
+// adjustmentRatio berechnet, wie stark die Zwischenräume einer Zeile gedehnt (> 0) oder gestaucht (< 0)
+// werden müssen, damit sie genau die Zeilenbreite füllt.
+func adjustmentRatio(width, stretch, shrink, lineWidth float64) float64 {
+	switch {
+	case width < lineWidth:
+		if stretch <= 0 {
+			return math.Inf(1)
+		}
+		return (lineWidth - width) / stretch
+	case width > lineWidth:
+		if shrink <= 0 {
+			return math.Inf(-1)
+		}
+		return (lineWidth - width) / shrink
+	}
+	return 0
+}
+
+// fitnessClass ordnet eine Zeile nach ihrer Dehnung ein.
+func fitnessClass(ratio float64) int {
+	switch {
+	case ratio < -0.5:
+		return 0
+	case ratio <= 0.5:
+		return 1
+	case ratio <= 1:
+		return 2
+	}
+	return 3
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// nextLineStart liefert den Index der ersten Box nach einem Umbruch.
+func nextLineStart(items []lineItem, b int) int {
+	i := b
+	for i < len(items) && items[i].kind != boxItem {
+		i++
+	}
+	return i
+}
+
+// lineRun ist ein zusammenhängend gesetzter Teil einer Zeile mit einheitlicher Formatierung.
+type lineRun struct {
+	seg    blocks.TextSegment
+	text   string
+	width  float64 // Breite nach Dehnung der Zwischenräume
+	spaces int     // Anzahl der Leerzeichen im Text
+	after  float64 // Zwischenraum nach dem Lauf (vor einem Formatwechsel)
+}
+
+// renderLine setzt die Elemente start bis end (Umbruchstelle) als eine Zeile.
+func (g *Generator) renderLine(items []lineItem, start, end int, x, y, lineWidth, lineHeight float64, align string, last bool) {
+	// Natürliche Breite und Dehnbarkeit der Zeile
+	var width, stretch, shrink float64
+	for i := start; i < end; i++ {
+		if items[i].kind != penaltyItem {
+			width += items[i].width
+			stretch += items[i].stretch
+			shrink += items[i].shrink
+		}
+	}
+	hyphenated := items[end].kind == penaltyItem && items[end].flagged
+	if hyphenated {
+		width += items[end].width
+	}
+
+	ratio := 0.0
+	switch {
+	case align == "J" && !last:
+		ratio = adjustmentRatio(width, stretch, shrink, lineWidth)
+	case width > lineWidth:
+		ratio = adjustmentRatio(width, stretch, shrink, lineWidth)
+	}
+	if math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		ratio = 0
+	}
+	ratio = math.Max(ratio, -1)
+
+	// Zeile in Läufe gleicher Formatierung zerlegen
+	var runs []lineRun
+	for i := start; i < end; i++ {
+		item := items[i]
+		switch item.kind {
+		case boxItem:
+			if n := len(runs); n > 0 && runs[n-1].after == 0 && !item.seg.Code && !runs[n-1].seg.Code && sameFormat(runs[n-1].seg, item.seg) {
+				runs[n-1].text += item.text
+				runs[n-1].width += item.width
+				continue
+			}
+			runs = append(runs, lineRun{seg: item.seg, text: item.text, width: item.width})
+		case glueItem:
+			w := item.width
+			if ratio > 0 {
+				w += ratio * item.stretch
+			} else {
+				w += ratio * item.shrink
+			}
+			if len(runs) == 0 {
+				continue
+			}
+			r := &runs[len(runs)-1]
+			if next := nextBox(items, i, end); next != nil && r.after == 0 && !r.seg.Code && !next.seg.Code && sameFormat(r.seg, next.seg) {
+				r.text += " "
+				r.spaces++
+				r.width += w
+			} else {
+				r.after += w
+			}
+		}
+	}
+	if hyphenated && len(runs) > 0 {
+		r := &runs[len(runs)-1]
+		r.text += "-"
+		r.width += items[end].width
+	}
+
+	used := 0.0
+	for _, r := range runs {
+		used += r.width + r.after
+	}
+	switch align {
+	case "C":
+		x += (lineWidth - used) / 2
+	case "R":
+		x += lineWidth - used
+	}
+
+	for _, r := range runs {
+		g.renderRun(r, x, y, lineHeight)
+		x += r.width + r.after
+	}
+}
+
+// nextBox liefert die nächste Box vor end.
+func nextBox(items []lineItem, i, end int) *lineItem {
+	for j := i + 1; j < end; j++ {
+		if items[j].kind == boxItem {
+			return &items[j]
+		}
+	}
+	return nil
+}
+
+// sameFormat prüft, ob zwei Segmente gleich formatiert sind.
+func sameFormat(a, b blocks.TextSegment) bool {
+	return a.Bold == b.Bold && a.Italic == b.Italic && a.Strikethrough == b.Strikethrough && a.Code == b.Code && a.Link == b.Link
+}
+
+// renderRun zeichnet einen Lauf: Text mit ggf. gedehnten Leerzeichen, Link, Durchstreichung oder Code-Chip.
+func (g *Generator) renderRun(r lineRun, x, y, lineHeight float64) {
+	family, style, size := g.segmentFont(r.seg)
+	g.safeSetFont(family, style, size)
+	text := g.prepareText(r.text)
+
+	if r.seg.Code {
+		// Moderner Hintergrund-Chip (sanftes Grau, ohne sichtbaren Rahmen)
+		textWidth := g.pdf.GetStringWidth(text)
+		chipHeight := size * 0.5
+		g.pdf.SetFillColor(243, 244, 246)
+		g.pdf.RoundedRect(x, y+(lineHeight-chipHeight-2*chipPaddingV)/2, textWidth+2*chipPaddingH, chipHeight+2*chipPaddingV, 1.5, "1234", "F")
+		g.pdf.SetTextColor(55, 65, 81)
+		g.pdf.SetXY(x+chipPaddingH, y)
+		g.pdf.CellFormat(textWidth, lineHeight, text, "", 0, "L", false, 0, "")
+		g.setPrimaryTextColor()
+		return
+	}
+
+	g.setPrimaryTextColor()
+	g.pdf.SetXY(x, y)
+	natural := g.pdf.GetStringWidth(text)
+	if r.spaces > 0 && math.Abs(r.width-natural) > 0.01 {
+		if g.currentFontIsUTF8 {
+			// gofpdf verteilt bei "J" den Platz bis zur Zellbreite auf die Leerzeichen
+			g.pdf.CellFormat(r.width, lineHeight, text, "", 0, "J", false, 0, "")
+		} else {
+			g.pdf.SetWordSpacing((r.width - natural) / float64(r.spaces))
+			g.pdf.CellFormat(r.width, lineHeight, text, "", 0, "L", false, 0, "")
+			g.pdf.SetWordSpacing(0)
+		}
+	} else {
+		g.pdf.CellFormat(r.width, lineHeight, text, "", 0, "L", false, 0, "")
+	}
+
+	if r.seg.Link != "" {
+		g.addLink(r.seg.Link, x, y, r.width, lineHeight)
+	}
+	if r.seg.Strikethrough {
+		strikeY := y + g.cfg.FontSize*0.35
+		g.pdf.SetDrawColor(0, 0, 0)
+		g.pdf.SetLineWidth(0.3)
+		g.pdf.Line(x, strikeY, x+r.width, strikeY)
+	}
+}
+
+// addLink legt einen klickbaren Bereich an: Anker (#id) verweisen auf Überschriften, alles andere gilt als URL.
+func (g *Generator) addLink(link string, x, y, w, h float64) {
+	if anchorID, ok := strings.CutPrefix(link, "#"); ok {
+		if linkID, ok := g.anchorLinks[anchorID]; ok {
+			g.pdf.Link(x, y, w, h, linkID)
+		}
+		return
+	}
+	g.pdf.LinkString(x, y, w, h, link)
+}
@@ -5,25 +5,50 @@ import (
 	"fmt"
 	"godocgen/internal/blocks"
 	"godocgen/internal/config"
+	"godocgen/internal/engine/hyphen"
+	"godocgen/internal/engine/svg"
+	"godocgen/internal/i18n"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
 // Generator ist die zentrale Komponente zur Erzeugung des PDF-Dokuments.
 type Generator struct {
-	pdf               *gofpdf.Fpdf      // Die zugrunde liegende PDF-Bibliothek
-	cfg               *config.Config    // Die Projektkonfiguration
-	blocks            []blocks.DocBlock // Die zu rendernden Inhaltsblöcke
-	toc               []TOCEntry        // Gesammelte Inhaltsverzeichniseinträge
-	fontDir           string            // Verzeichnis der extrahierten Schriftarten
-	totalPages        int               // Gesamtanzahl der Seiten (nach Pass 1)
-	headingCounts     []int             // Zähler für die Nummerierung von Überschriften
-	registeredFonts   map[string]bool   // Verfolgt bereits registrierte Schriftarten
-	inTOC             bool              // Status, ob gerade das Inhaltsverzeichnis gerendert wird
-	currentFontIsUTF8 bool              // Status, ob die aktuelle Schriftart UTF-8 unterstützt
-	anchorLinks       map[string]int    // Map von AnchorID zu PDF-Link-ID für interne Verlinkungen
+	pdf               *gofpdf.Fpdf             // Die zugrunde liegende PDF-Bibliothek
+	cfg               *config.Config           // Die Projektkonfiguration
+	blocks            []blocks.DocBlock        // Die zu rendernden Inhaltsblöcke
+	toc               []TOCEntry               // Gesammelte Inhaltsverzeichniseinträge
+	fontDir           string                   // Verzeichnis der extrahierten Schriftarten
+	totalPages        int                      // Gesamtanzahl der Seiten (nach Pass 1)
+	headingCounts     []int                    // Zähler für die Nummerierung von Überschriften
+	registeredFonts   map[string]bool          // Verfolgt bereits registrierte Schriftarten
+	inTOC             bool                     // Status, ob gerade das Inhaltsverzeichnis gerendert wird
+	currentFontIsUTF8 bool                     // Status, ob die aktuelle Schriftart UTF-8 unterstützt
+	anchorLinks       map[string]int           // Map von AnchorID zu PDF-Link-ID für interne Verlinkungen
+	svgDocs           map[string]*svg.Document // Geparste SVG-Bilder (nil = nicht darstellbar)
+	float             *floatState              // Aktuell umflossenes Bild (nil = keines)
+	landscape         bool                     // Status, ob gerade ein Querformat-Abschnitt gesetzt wird
+	blankPages        map[int]bool             // Eingefügte leere Rückseiten (Duplex), ohne Kopf- und Fußzeile
+	pageTopY          float64                  // Y-Position, an der der Inhalt der aktuellen Seite beginnt
+	tocStartPage      int                      // Seite vor dem Inhaltsverzeichnis (für die Seitenberechnung im Duplex-Modus)
+	columns           *columnState             // Aktiver mehrspaltiger Abschnitt (nil = einspaltig)
+	columnSections    int                      // Zähler der mehrspaltigen Abschnitte im aktuellen Durchgang
+	columnBalances    map[int]columnBalance    // Spaltenausgleich je Abschnitt aus dem Mess-Durchgang
+	pageSections      map[int]pageSection      // Nummerierte Dokumentteile je erster Seite (aus dem Mess-Durchgang)
+	pendingSection    *pageSection             // Dokumentteil, der auf der nächsten Seite beginnt
+	appendix          bool                     // Status, ob H1-Überschriften Anhangskapitel beginnen
+	appendixCount     int                      // Zähler der Anhangskapitel (A, B, ...)
+	running           runningHead              // Aktive Überschriften während des Renderns
+	runningHeads      map[int]runningHead      // Kolumnentitel je Seite (aus dem Mess-Durchgang)
+	build             BuildInfo                // Build-Angaben für Header- und Footer-Vorlagen
+	zones             zoneState                // Übersetzte Header- und Footer-Vorlagen
+	locale            *i18n.Catalog            // Eingebaute Texte und Formate in der Dokumentsprache
+	hyphens           *hyphen.Hyphenator       // Silbentrennung für Fließtext (nil = aus)
+	following         []blocks.DocBlock        // Blöcke nach dem aktuellen (für Keep-with-next von Überschriften)
+	measurer          *Generator               // Mess-Dokument für die Höhe folgender Blöcke (Umbruchregeln)
 }
 
 // TOCEntry repräsentiert einen Eintrag im Inhaltsverzeichnis.
@@ -38,19 +63,20 @@ type TOCEntry struct {
 
 // NewGenerator erstellt einen neuen PDF-Generator.
 func NewGenerator(cfg *config.Config, blocks []blocks.DocBlock, fontDir string) *Generator {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetCompression(true)
-	pdf.SetMargins(cfg.Layout.Margins.Left, cfg.Layout.Margins.Top, cfg.Layout.Margins.Right)
-	pdf.SetAutoPageBreak(true, cfg.Layout.Margins.Bottom)
-
 	g := &Generator{
-		pdf:             pdf,
+		pdf:             newDocument(cfg),
 		cfg:             cfg,
 		blocks:          blocks,
 		fontDir:         fontDir,
 		headingCounts:   make([]int, 6),
 		registeredFonts: make(map[string]bool),
 		anchorLinks:     make(map[string]int),
+		svgDocs:         make(map[string]*svg.Document),
+		columnBalances:  make(map[int]columnBalance),
+		pageSections:    make(map[int]pageSection),
+		runningHeads:    make(map[int]runningHead),
+		build:           BuildInfo{Time: time.Now()},
+		locale:          i18n.New(cfg.Language, cfg.Messages),
 	}
 
 	// Schriften beim Initialisieren registrieren
@@ -61,6 +87,8 @@ func NewGenerator(cfg *config.Config, blocks []blocks.DocBlock, fontDir string)
 
 // prepareText konvertiert Text je nach Schriftart (UTF-8 oder CP1252).
 func (g *Generator) prepareText(text string) string {
+	// Weiche Trennzeichen sind nur Umbruchstellen und werden nie gedruckt
+	text = stripSoftHyphens(text)
 	if g.currentFontIsUTF8 {
 		return text
 	}
@@ -215,15 +243,19 @@ func (g *Generator) registerFonts(fontDir string) {
 
 // Generate führt den zweistufigen Rendering-Prozess aus und speichert das Ergebnis.
 func (g *Generator) Generate(outputPath string) error {
+	if err := g.parseZones(); err != nil {
+		return err
+	}
+	if err := g.setupHyphenation(); err != nil {
+		return err
+	}
+
 	// Durchgang 1: Messen und Sammeln des Inhaltsverzeichnisses
 	g.headingCounts = make([]int, 6)
 	g.renderAll(true)
 
 	// Zurücksetzen für Durchgang 2
-	g.pdf = gofpdf.New("P", "mm", "A4", "")
-	g.pdf.SetCompression(true)
-	g.pdf.SetMargins(g.cfg.Layout.Margins.Left, g.cfg.Layout.Margins.Top, g.cfg.Layout.Margins.Right)
-	g.pdf.SetAutoPageBreak(true, g.cfg.Layout.Margins.Bottom)
+	g.pdf = newDocument(g.cfg)
 	g.registeredFonts = make(map[string]bool)
 	g.registerFonts(g.fontDir)
 	g.headingCounts = make([]int, 6)
@@ -237,26 +269,64 @@ func (g *Generator) Generate(outputPath string) error {
 		return err
 	}
 
-	return g.pdf.OutputFileAndClose(outputPath)
+	return g.writePDF(outputPath)
 }
 
 // renderAll steuert das Rendern aller Dokumententeile.
 func (g *Generator) renderAll(isMeasurement bool) {
 	g.setupHeaderFooter()
+	g.landscape = g.cfg.Layout.Page.Landscape()
+	g.blankPages = make(map[int]bool)
+	g.columns = nil
+	g.columnSections = 0
+	g.appendix = false
+	g.appendixCount = 0
+	g.measurer = nil
+
+	// Seitennummerierung: Deckblatt ohne Nummer, danach Vorspann (Inhaltsverzeichnis) und Hauptteil.
+	// Im finalen Durchgang stehen die Dokumentteile bereits aus dem Mess-Durchgang fest.
+	if isMeasurement {
+		g.pageSections = make(map[int]pageSection)
+		g.runningHeads = make(map[int]runningHead)
+	}
+	g.running = runningHead{}
+	g.pageSections[1] = pageSection{style: "none"}
+	if start := g.cfg.PageNumbers.StartPage; start > 1 {
+		// Feste Startseite: alle Seiten nach dem Umschlag gehören zum Vorspann
+		front := 2
+		if g.cfg.Cover.Inside != nil {
+			front = 3
+		}
+		g.pageSections[front] = g.frontMatterSection()
+		g.pageSections[start] = g.mainMatterSection()
+	}
+	g.pendingSection = nil
 
 	// Titelseite
-	g.renderFrontPage()
+	g.renderCover()
+
+	if g.cfg.TOC.Enabled {
+		g.beginPageSection(g.frontMatterSection())
+	} else {
+		g.beginPageSection(g.mainMatterSection())
+	}
 
 	tocPages := 0
 	// Inhaltsverzeichnis
 	if g.cfg.TOC.Enabled {
 		tocPages = g.renderTOC(isMeasurement)
 	}
+	contentStart := g.pdf.PageNo()
 
 	// Inhalt (Blöcke)
-	for _, block := range g.blocks {
+	for i, block := range g.blocks {
+		g.following = g.blocks[i+1:]
+		g.keepBlocks(block, g.following)
 		g.renderBlock(block, isMeasurement)
 	}
+	g.following = nil
+	g.endColumns(isMeasurement)
+	g.endFloat(true)
 
 	// Inline-Footer am Ende des Contents
 	if g.cfg.Layout.FooterStyle == "inline" {
@@ -265,6 +335,7 @@ func (g *Generator) renderAll(isMeasurement bool) {
 			g.renderFooterAt(g.pdf.GetY())
 		}
 	}
+	g.renderBackCover()
 
 	// Wenn wir im Mess-Durchgang sind, korrigieren wir nun die Seitenzahlen im TOC,
 	// falls das TOC mehr als eine Seite (den initialen Platzhalter) beansprucht.
@@ -280,6 +351,8 @@ func (g *Generator) renderAll(isMeasurement bool) {
 				for i := range g.toc {
 					g.toc[i].Page += offset
 				}
+				g.shiftPageSections(contentStart, offset)
+				g.runningHeads = shiftPages(g.runningHeads, contentStart, offset)
 				g.totalPages += offset
 			}
 		}
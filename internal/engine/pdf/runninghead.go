@@ -0,0 +1,64 @@
+package pdf
+
+import (
+	"godocgen/internal/blocks"
+	"strings"
+)
+
+// runningHead beschreibt die Überschriften, die als Kolumnentitel einer Seite erscheinen.
+type runningHead struct {
+	chapter       string // Aktuelles Kapitel (H1)
+	chapterNumber string // Nummer des Kapitels ohne abschließenden Punkt (z.B. "3")
+	section       string // Aktueller Abschnitt (H2)
+	file          string // Markdown-Datei der zuletzt gesetzten Überschrift
+	chapterSet    bool   // Das Kapitel beginnt auf dieser Seite
+	sectionSet    bool   // Der Abschnitt beginnt auf dieser Seite
+}
+
+// recordRunningHead hält beim Beginn einer Seite fest, welche Überschriften gerade aktiv sind.
+// Im finalen Durchgang bleiben die Einträge aus dem Mess-Durchgang erhalten, damit Kopfzeilen
+// auch Kapitel kennen, die erst weiter unten auf der Seite beginnen.
+func (g *Generator) recordRunningHead() {
+	page := g.pdf.PageNo()
+	if _, ok := g.runningHeads[page]; ok {
+		return
+	}
+	head := g.running
+	head.chapterSet, head.sectionSet = false, false
+	g.runningHeads[page] = head
+}
+
+// updateRunningHead übernimmt eine gesetzte Überschrift als Kolumnentitel. Für die Seite gilt
+// wie in gedruckten Büchern das erste Kapitel bzw. der erste Abschnitt, der auf ihr beginnt.
+func (g *Generator) updateRunningHead(h blocks.HeadingBlock, numbering, text string, isMeasurement bool) {
+	number := strings.TrimSuffix(strings.TrimSpace(numbering), ".")
+	switch h.Level {
+	case 1:
+		g.running.chapter = text
+		g.running.chapterNumber = number
+		g.running.section = ""
+	case 2:
+		g.running.section = text
+	}
+	if h.File != "" {
+		g.running.file = h.File
+	}
+	if !isMeasurement {
+		return
+	}
+
+	page := g.pdf.PageNo()
+	head := g.runningHeads[page]
+	switch {
+	case h.Level == 1 && !head.chapterSet:
+		head.chapter = g.running.chapter
+		head.chapterNumber = g.running.chapterNumber
+		head.section = ""
+		head.chapterSet = true
+		head.file = g.running.file
+	case h.Level == 2 && !head.sectionSet:
+		head.section = g.running.section
+		head.sectionSet = true
+	}
+	g.runningHeads[page] = head
+}
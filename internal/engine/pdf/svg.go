@@ -0,0 +1,55 @@
+package pdf
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"godocgen/internal/engine/svg"
+)
+
+// svgFonts stellt dem SVG-Renderer die registrierten Schriften des Generators bereit.
+type svgFonts struct {
+	g *Generator
+}
+
+// SetFont wählt "mono" oder "main" im passenden Schnitt.
+func (f svgFonts) SetFont(mono, bold, italic bool, sizePt float64) {
+	family := "main"
+	if mono {
+		family = "mono"
+	}
+	style := ""
+	if bold {
+		style += "B"
+	}
+	if italic {
+		style += "I"
+	}
+	f.g.safeSetFont(family, style, sizePt)
+}
+
+// PrepareText kodiert Text passend zur aktuellen Schrift.
+func (f svgFonts) PrepareText(text string) string {
+	return f.g.prepareText(text)
+}
+
+// isSVG prüft, ob ein Bildpfad auf eine SVG-Datei zeigt.
+func isSVG(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".svg")
+}
+
+// loadSVG liest eine SVG-Datei einmalig ein (der Generator rendert das Dokument zweimal).
+// Bei Fehlern wird einmalig eine Warnung ausgegeben und nil geliefert.
+func (g *Generator) loadSVG(path string) *svg.Document {
+	if doc, ok := g.svgDocs[path]; ok {
+		return doc
+	}
+	doc, err := svg.ParseFile(path)
+	if err != nil {
+		fmt.Printf("Warnung: SVG %s kann nicht als Vektorgrafik gezeichnet werden: %v\n", path, err)
+		doc = nil
+	}
+	g.svgDocs[path] = doc
+	return doc
+}
@@ -1,6 +1,6 @@
 package pdf
 
-import "fmt"
+import "godocgen/internal/i18n"
 
 // renderTOC rendert das Inhaltsverzeichnis mit klickbaren Links und Seitenzahlen.
 // Im Measurement-Modus gibt es die Anzahl der benötigten Seiten zurück.
@@ -10,8 +10,9 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 	}
 
 	startPage := g.pdf.PageNo()
+	g.tocStartPage = startPage
 	g.inTOC = true
-	g.pdf.AddPage()
+	g.addRightPage()
 	g.inTOC = false
 
 	// Berechne Zeilenhöhe basierend auf Konfiguration
@@ -21,7 +22,8 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 	if isMeasurement {
 		if len(g.toc) == 0 {
 			// Falls noch keine Einträge da sind (erster Lauf), reservieren wir eine Seite
-			g.pdf.AddPage()
+			g.beginPageSection(g.mainMatterSection())
+			g.addRightPage()
 			return g.pdf.PageNo() - startPage
 		}
 
@@ -39,7 +41,8 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 			g.checkPageBreak(h)
 			g.pdf.Ln(h)
 		}
-		g.pdf.AddPage()
+		g.beginPageSection(g.mainMatterSection())
+		g.addRightPage()
 		return g.pdf.PageNo() - startPage
 	}
 
@@ -48,7 +51,7 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 	g.safeSetFont("main", "B", 24)
 	r, green, b := hexToRGB(g.cfg.Colors.Title)
 	g.pdf.SetTextColor(r, green, b)
-	g.pdf.CellFormat(0, 15, g.prepareText("Inhaltsverzeichnis"), "", 1, "L", false, 0, "")
+	g.pdf.CellFormat(0, 15, g.prepareText(g.locale.T(i18n.TOCTitle)), "", 1, "L", false, 0, "")
 
 	// Dekorative Linie
 	left, _, right, _ := g.pdf.GetMargins()
@@ -91,12 +94,20 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 		// Eintragstext als Link
 		g.safeWriteLinkID(h, text, "main", style, entry.Link)
 
+		// Seitenzahl im Stil des Dokumentteils (z.B. "iii", "12" oder "A-2")
+		label := g.pageLabel(entry.Page)
+		g.safeSetFont("main", "", fontSize)
+		labelW := g.pdf.GetStringWidth(label) + 1
+		if labelW < 8 {
+			labelW = 8
+		}
+
 		// Punkte zwischen Text und Seitenzahl
 		if g.cfg.TOC.ShowDots {
 			g.safeSetFont("main", "", fontSize-1)
 			g.pdf.SetTextColor(180, 180, 180)
 			dotX := g.pdf.GetX() + 2
-			dotEndX := w - right - 10
+			dotEndX := w - right - labelW - 2
 			remaining := dotEndX - dotX
 			if remaining > 0 {
 				dots := ""
@@ -111,16 +122,15 @@ func (g *Generator) renderTOC(isMeasurement bool) int {
 		// Seitenzahl
 		g.setPrimaryTextColor()
 		g.safeSetFont("main", "", fontSize)
-		g.pdf.SetX(w - right - 8)
-		displayPage := entry.Page - g.cfg.PageNumbers.StartPage + 1
-		if displayPage < 1 {
-			displayPage = 1
-		}
-		g.pdf.CellFormat(8, h, fmt.Sprintf("%d", displayPage), "", 1, "R", false, entry.Link, "")
+		g.pdf.SetX(w - right - labelW)
+		g.pdf.CellFormat(labelW, h, g.prepareText(label), "", 1, "R", false, entry.Link, "")
 	}
 
-	// Sicherstellen, dass der nächste Inhalt auf einer neuen Seite beginnt
-	g.pdf.AddPage()
+	// Der Hauptteil beginnt mit der nächsten Seite
+	g.beginPageSection(g.mainMatterSection())
+
+	// Sicherstellen, dass der nächste Inhalt auf einer neuen (im Duplex-Modus rechten) Seite beginnt
+	g.addRightPage()
 	return g.pdf.PageNo() - startPage
 }
 
@@ -161,5 +171,19 @@ func (g *Generator) measureTOC() int {
 		}
 	}
 
+	if g.cfg.Layout.Duplex {
+		// Leere Rückseiten vor dem Inhaltsverzeichnis und vor dem Inhalt mitzählen,
+		// damit beide Durchgänge dieselben Seiten als rechte Seiten setzen
+		first := g.tocStartPage + 1
+		if first%2 == 0 {
+			first++
+		}
+		content := first + pages
+		if content%2 == 0 {
+			content++
+		}
+		return content - g.tocStartPage
+	}
+
 	return pages + 1
 }
@@ -0,0 +1,295 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"godocgen/internal/config"
+	"godocgen/internal/util"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// BuildInfo enthält Angaben zum Build, die in Header- und Footer-Vorlagen erscheinen können.
+type BuildInfo struct {
+	Time       time.Time    // Zeitpunkt des Builds
+	Version    int          // Versionsnummer der PDF-Datei (_vN)
+	Git        util.GitInfo // Stand des Git-Repositories
+	ProjectDir string       // Projektverzeichnis zum Auflösen relativer Bildpfade
+}
+
+// SetBuildInfo setzt die Build-Angaben für Header- und Footer-Vorlagen.
+func (g *Generator) SetBuildInfo(info BuildInfo) {
+	g.build = info
+}
+
+// zoneState hält die übersetzten Vorlagen der Header- und Footer-Zonen.
+type zoneState struct {
+	templates map[string]*template.Template // Vorlage je Zoneninhalt
+	warned    map[string]bool               // Bereits gemeldete fehlende Bilder
+}
+
+// zoneData enthält die Werte, auf die Header- und Footer-Vorlagen zugreifen können.
+type zoneData struct {
+	Page           string           // Seitenzahl im Stil des Dokumentteils
+	Total          string           // Letzte Seitenzahl des Dokumentteils
+	Title          string           // Titel des Dokuments
+	Subtitle       string           // Untertitel des Dokuments
+	Author         string           // Autor des Dokuments
+	Version        string           // Dokumentversion (cover.version)
+	Classification string           // Einstufung des Dokuments (cover.classification)
+	Date           string           // Build-Datum im Format date_format
+	Chapter        string           // Aktuelles Kapitel (H1)
+	ChapterNumber  string           // Nummer des aktuellen Kapitels
+	Section        string           // Aktueller Abschnitt (H2)
+	File           string           // Markdown-Datei der aktuellen Überschrift
+	Git            util.GitInfo     // Commit, Tag und Branch des Projekts
+	Build          zoneBuild        // Datum, Zeitpunkt und Version des Builds
+	Vars           config.Variables // Eigene Variablen aus der Konfiguration
+}
+
+// zoneBuild beschreibt den Build innerhalb der Vorlagen ({{.Build.Date}}, {{.Build.Version}}).
+type zoneBuild struct {
+	Date    string    // Build-Datum im Format date_format
+	Time    time.Time // Zeitpunkt des Builds für eigene Formate ({{.Build.Time.Format "2006"}})
+	Version int       // Versionsnummer der PDF-Datei
+}
+
+// legacyPlaceholders übersetzt die früheren Platzhalter wie {page} in Template-Ausdrücke.
+var legacyPlaceholders = strings.NewReplacer(
+	"{page}", "{{.Page}}",
+	"{total}", "{{.Total}}",
+	"{title}", "{{.Title}}",
+	"{author}", "{{.Author}}",
+	"{date}", "{{.Date}}",
+	"{chapter_number}", "{{.ChapterNumber}}",
+	"{chapter}", "{{.Chapter}}",
+	"{section}", "{{.Section}}",
+	"{file}", "{{.File}}",
+)
+
+// Markierung für Bilder im Ergebnis einer Vorlage (Zeichen aus dem Private-Use-Bereich)
+const (
+	zoneImageMark = "\ue000" // umschließt Pfad und Höhe eines Bildes
+	zoneImageSep  = "\ue001" // trennt Pfad und Höhe
+)
+
+// zoneFuncs liefert die zusätzlichen Funktionen für Header- und Footer-Vorlagen.
+func (g *Generator) zoneFuncs() template.FuncMap {
+	return template.FuncMap{
+		// {{image "logo.png"}} oder {{image "logo.png" 6}} (Höhe in mm)
+		"image": func(path string, height ...float64) string {
+			h := 0.0
+			if len(height) > 0 {
+				h = height[0]
+			}
+			return zoneImageMark + path + zoneImageSep + strconv.FormatFloat(h, 'f', -1, 64) + zoneImageMark
+		},
+		// {{date "2006-01-02"}} formatiert den Build-Zeitpunkt
+		"date":  func(layout string) string { return g.locale.FormatDate(g.build.Time, layout) },
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
+
+// parseZones übersetzt alle Header- und Footer-Zonen sowie die Textfelder der Umschlagseiten
+// in Vorlagen, damit Syntaxfehler vor dem Rendern gemeldet werden.
+func (g *Generator) parseZones() error {
+	g.zones = zoneState{
+		templates: make(map[string]*template.Template),
+		warned:    make(map[string]bool),
+	}
+	zones := []struct {
+		name string
+		src  string
+	}{
+		{"header.text", g.cfg.Header.Text},
+		{"header.left", g.cfg.Header.Left},
+		{"header.center", g.cfg.Header.Center},
+		{"header.right", g.cfg.Header.Right},
+		{"footer.left", g.cfg.Footer.Left},
+		{"footer.center", g.cfg.Footer.Center},
+		{"footer.right", g.cfg.Footer.Right},
+	}
+	for _, cover := range g.coverPages() {
+		for i, field := range cover.page.Fields {
+			zones = append(zones, struct {
+				name string
+				src  string
+			}{fmt.Sprintf("%s.fields[%d]", cover.name, i), field.Text})
+		}
+	}
+	for _, z := range zones {
+		if z.src == "" || g.zones.templates[z.src] != nil {
+			continue
+		}
+		tmpl, err := template.New(z.name).Funcs(g.zoneFuncs()).Option("missingkey=zero").Parse(legacyPlaceholders.Replace(z.src))
+		if err != nil {
+			return fmt.Errorf("Vorlage %s ungültig: %w", z.name, err)
+		}
+		g.zones.templates[z.src] = tmpl
+	}
+	return nil
+}
+
+// currentZoneData stellt die Werte für die Vorlagen der aktuellen Seite zusammen.
+func (g *Generator) currentZoneData() zoneData {
+	page := g.pdf.PageNo()
+	head, ok := g.runningHeads[page]
+	if !ok {
+		head = g.running
+	}
+	date := g.locale.FormatDate(g.build.Time, g.cfg.DateFormat)
+	return zoneData{
+		Page:           g.pageLabel(page),
+		Total:          g.sectionTotal(page),
+		Title:          g.cfg.Title,
+		Subtitle:       g.cfg.Subtitle,
+		Author:         g.cfg.Author,
+		Version:        g.cfg.Cover.Version,
+		Classification: g.cfg.Cover.Classification,
+		Date:           date,
+		Chapter:        head.chapter,
+		ChapterNumber:  head.chapterNumber,
+		Section:        head.section,
+		File:           head.file,
+		Git:            g.build.Git,
+		Build:          zoneBuild{Date: date, Time: g.build.Time, Version: g.build.Version},
+		Vars:           g.cfg.Vars,
+	}
+}
+
+// executeZone wertet die Vorlage einer Zone für die aktuelle Seite aus.
+func (g *Generator) executeZone(src string) string {
+	tmpl := g.zones.templates[src]
+	if tmpl == nil {
+		return src
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, g.currentZoneData()); err != nil {
+		g.pdf.SetErrorf("Vorlage %s: %v", tmpl.Name(), err)
+		return ""
+	}
+	return buf.String()
+}
+
+// renderZones zeichnet die linke, mittlere und rechte Zone einer Header- oder Footer-Zeile.
+// Ohne Zonen wird fallback links gesetzt. Im Duplex-Modus tauschen linke und rechte Zone
+// auf linken (geraden) Seiten die Seiten.
+func (g *Generator) renderZones(z config.PageZones, fallback string, x, y, width float64) {
+	leftZone, rightZone := z.Left, z.Right
+	if leftZone == "" && z.Center == "" && rightZone == "" {
+		leftZone = fallback
+	}
+	if g.isVerso() {
+		leftZone, rightZone = rightZone, leftZone
+	}
+
+	g.safeSetFont("main", "", z.FontSize)
+	g.drawZone(leftZone, "L", x, y, width, z.Height)
+	g.drawZone(z.Center, "C", x, y, width, z.Height)
+	g.drawZone(rightZone, "R", x, y, width, z.Height)
+}
+
+// zonePart ist ein Text- oder Bildstück innerhalb einer Zone.
+type zonePart struct {
+	text   string
+	image  string
+	height float64
+	width  float64
+}
+
+// drawZone wertet eine Zone aus und setzt sie ausgerichtet in die Zeile.
+func (g *Generator) drawZone(src, align string, x, y, width, height float64) {
+	if src == "" {
+		return
+	}
+	text := g.executeZone(src)
+	if !strings.Contains(text, zoneImageMark) {
+		g.pdf.SetXY(x, y)
+		g.pdf.CellFormat(width, height, g.prepareText(text), "", 0, align, false, 0, "")
+		return
+	}
+
+	// Text und Bilder nebeneinander setzen
+	var parts []zonePart
+	total := 0.0
+	for i, chunk := range strings.Split(text, zoneImageMark) {
+		part := zonePart{text: chunk}
+		if i%2 == 1 {
+			part = g.zoneImage(chunk, height)
+			if part.image == "" {
+				continue
+			}
+		} else if chunk == "" {
+			continue
+		} else {
+			part.text = g.prepareText(chunk)
+			part.width = g.pdf.GetStringWidth(part.text) + 2*g.pdf.GetCellMargin()
+		}
+		parts = append(parts, part)
+		total += part.width
+	}
+
+	cx := x
+	switch align {
+	case "C":
+		cx = x + (width-total)/2
+	case "R":
+		cx = x + width - total
+	}
+	for _, part := range parts {
+		if part.image != "" {
+			g.pdf.ImageOptions(part.image, cx, y+(height-part.height)/2, part.width, part.height, false, gofpdf.ImageOptions{}, 0, "")
+		} else {
+			g.pdf.SetXY(cx, y)
+			g.pdf.CellFormat(part.width, height, part.text, "", 0, "L", false, 0, "")
+		}
+		cx += part.width
+	}
+}
+
+// zoneImage löst ein Bild aus {{image "pfad" höhe}} auf und berechnet seine Breite.
+func (g *Generator) zoneImage(spec string, lineHeight float64) zonePart {
+	path, heightText, _ := strings.Cut(spec, zoneImageSep)
+	path, ok := g.projectImage(path, "Header/Footer")
+	if !ok {
+		return zonePart{}
+	}
+
+	height, _ := strconv.ParseFloat(heightText, 64)
+	if height <= 0 {
+		height = lineHeight - 2
+	}
+	info := g.pdf.RegisterImageOptions(path, gofpdf.ImageOptions{})
+	if info == nil || info.Height() == 0 {
+		return zonePart{}
+	}
+	return zonePart{image: path, height: height, width: height * info.Width() / info.Height()}
+}
+
+// projectImage löst den Pfad eines Bildes aus der Konfiguration auf. Relative Pfade beziehen
+// sich auf das Projekt- bzw. assets-Verzeichnis. Fehlende Bilder werden einmal gemeldet.
+func (g *Generator) projectImage(path, usage string) (string, bool) {
+	if !filepath.IsAbs(path) && g.build.ProjectDir != "" {
+		for _, candidate := range []string{filepath.Join(g.build.ProjectDir, path), filepath.Join(g.build.ProjectDir, "assets", path)} {
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+	if _, err := os.Stat(path); err != nil {
+		if !g.zones.warned[path] {
+			g.zones.warned[path] = true
+			fmt.Printf("Warnung: Bild für %s nicht gefunden: %s\n", usage, path)
+		}
+		return "", false
+	}
+	return path, true
+}
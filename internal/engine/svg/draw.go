@@ -0,0 +1,703 @@
+package svg
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Fonts wählt Schriftarten für SVG-Texte und bereitet Texte für die PDF-Kodierung vor.
+// Der PDF-Generator stellt die Implementierung bereit, damit SVG-Texte dieselben
+// registrierten Schriften nutzen wie der Fließtext.
+type Fonts interface {
+	SetFont(mono, bold, italic bool, sizePt float64)
+	PrepareText(text string) string
+}
+
+// maxUseDepth begrenzt verschachtelte use-Verweise (Schutz vor Zyklen).
+const maxUseDepth = 8
+
+// mmPerPt rechnet Punkt in Millimeter um.
+const mmPerPt = 25.4 / 72
+
+// skippedElements werden nicht direkt gezeichnet (Definitionen, Metadaten, nicht unterstützte Effekte).
+var skippedElements = map[string]bool{
+	"defs": true, "marker": true, "clipPath": true, "mask": true, "symbol": true, "title": true,
+	"desc": true, "style": true, "metadata": true, "linearGradient": true, "radialGradient": true,
+	"pattern": true, "filter": true, "script": true, "image": true,
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// drawer hält den Zustand während des Zeichnens einer Grafik.
+type drawer struct {
+	doc   *Document
+	pdf   *gofpdf.Fpdf
+	fonts Fonts
+	depth int
+}
+
+// Draw zeichnet die Grafik in das Rechteck (x, y, w, h) in Millimetern. Das Seitenverhältnis
+// bleibt erhalten, die Grafik wird im Rechteck zentriert (preserveAspectRatio="xMidYMid meet").
+// Texte werden als echter PDF-Text ausgegeben und sind damit durchsuchbar.
+func (d *Document) Draw(pdf *gofpdf.Fpdf, fonts Fonts, x, y, w, h float64) {
+	scale := math.Min(w/d.viewW, h/d.viewH)
+	offX := x + (w-d.viewW*scale)/2
+	offY := y + (h-d.viewH*scale)/2
+	ctm := translate(offX, offY).mul(scaling(scale, scale)).mul(translate(-d.viewX, -d.viewY))
+
+	// Zustand sichern, damit das umgebende Dokument unverändert bleibt
+	lineWidth := pdf.GetLineWidth()
+	dr, dg, db := pdf.GetDrawColor()
+	fr, fg, fb := pdf.GetFillColor()
+	tr, tg, tb := pdf.GetTextColor()
+	alpha, blend := pdf.GetAlpha()
+
+	dw := &drawer{doc: d, pdf: pdf, fonts: fonts}
+	for _, c := range d.root.children {
+		dw.drawNode(c, ctm, 1)
+	}
+
+	pdf.SetLineWidth(lineWidth)
+	pdf.SetDrawColor(dr, dg, db)
+	pdf.SetFillColor(fr, fg, fb)
+	pdf.SetTextColor(tr, tg, tb)
+	pdf.SetAlpha(alpha, blend)
+	pdf.SetDashPattern([]float64{}, 0)
+	pdf.SetLineCapStyle("butt")
+	pdf.SetLineJoinStyle("miter")
+}
+
+// drawNode zeichnet ein Element und seine Kinder mit der Transformation ctm.
+func (dw *drawer) drawNode(n *node, ctm matrix, opacity float64) {
+	if n.name == "#text" || skippedElements[n.name] || n.style["display"] == "none" {
+		return
+	}
+	m := ctm.mul(parseTransform(n.attr("transform")))
+	opacity *= parseOpacity(n.style["opacity"])
+	if opacity <= 0 {
+		return
+	}
+	visible := n.style["visibility"] != "hidden" && n.style["visibility"] != "collapse"
+
+	switch n.name {
+	case "svg":
+		// Verschachteltes svg: Position und eigene viewBox
+		x, _ := parseLength(n.attr("x"))
+		y, _ := parseLength(n.attr("y"))
+		m = m.mul(translate(x, y))
+		if vb := parseNumbers(n.attr("viewBox")); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+			w, wOK := parseLength(n.attr("width"))
+			h, hOK := parseLength(n.attr("height"))
+			if wOK && hOK {
+				s := math.Min(w/vb[2], h/vb[3])
+				m = m.mul(scaling(s, s))
+			}
+			m = m.mul(translate(-vb[0], -vb[1]))
+		}
+		dw.drawChildren(n, m, opacity)
+	case "g", "a", "switch":
+		dw.drawChildren(n, m, opacity)
+	case "use":
+		dw.drawUse(n, m, opacity)
+	case "text":
+		if visible {
+			dw.drawText(n, m, opacity)
+		}
+	case "foreignObject":
+		if visible {
+			dw.drawForeignObject(n, m, opacity)
+		}
+	default:
+		segs := shapePath(n)
+		if len(segs) == 0 || !visible {
+			return
+		}
+		dw.drawPath(n.style, segs, m, opacity)
+		dw.drawMarkers(n, segs, m, opacity)
+	}
+}
+
+func (dw *drawer) drawChildren(n *node, m matrix, opacity float64) {
+	for _, c := range n.children {
+		dw.drawNode(c, m, opacity)
+	}
+}
+
+// drawUse zeichnet das über href referenzierte Element an der Position (x, y).
+func (dw *drawer) drawUse(n *node, m matrix, opacity float64) {
+	target := dw.doc.ids[strings.TrimPrefix(n.attr("href"), "#")]
+	if target == nil || dw.depth >= maxUseDepth {
+		return
+	}
+	x, _ := parseLength(n.attr("x"))
+	y, _ := parseLength(n.attr("y"))
+	m = m.mul(translate(x, y))
+
+	dw.depth++
+	defer func() { dw.depth-- }()
+	if target.name == "symbol" {
+		if vb := parseNumbers(target.attr("viewBox")); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+			w, wOK := parseLength(n.attr("width"))
+			h, hOK := parseLength(n.attr("height"))
+			if wOK && hOK {
+				s := math.Min(w/vb[2], h/vb[3])
+				m = m.mul(scaling(s, s))
+			}
+			m = m.mul(translate(-vb[0], -vb[1]))
+		}
+		dw.drawChildren(target, m, opacity)
+		return
+	}
+	dw.drawNode(target, m, opacity)
+}
+
+// emitPath überträgt die Segmente transformiert in den aktuellen PDF-Pfad.
+func (dw *drawer) emitPath(segs []segment, m matrix) {
+	started := false
+	for _, s := range segs {
+		switch s.op {
+		case 'M':
+			p := m.apply(s.pts[0])
+			dw.pdf.MoveTo(p.x, p.y)
+			started = true
+		case 'L':
+			if !started {
+				continue
+			}
+			p := m.apply(s.pts[0])
+			dw.pdf.LineTo(p.x, p.y)
+		case 'C':
+			if !started {
+				continue
+			}
+			c1, c2, p := m.apply(s.pts[0]), m.apply(s.pts[1]), m.apply(s.pts[2])
+			dw.pdf.CurveBezierCubicTo(c1.x, c1.y, c2.x, c2.y, p.x, p.y)
+		case 'Z':
+			if started {
+				dw.pdf.ClosePath()
+			}
+		}
+	}
+}
+
+// drawPath füllt und/oder umrandet einen Pfad entsprechend des berechneten Stils.
+func (dw *drawer) drawPath(style map[string]string, segs []segment, m matrix, opacity float64) {
+	fillValue, ok := style["fill"]
+	if !ok {
+		fillValue = "black"
+	}
+	fill, hasFill := dw.doc.parsePaint(fillValue, style)
+	stroke, hasStroke := dw.doc.parsePaint(style["stroke"], style)
+
+	strokeWidth := 1.0
+	if v, ok := parseLength(style["stroke-width"]); ok {
+		strokeWidth = v
+	}
+	if strokeWidth <= 0 {
+		hasStroke = false
+	}
+	if !hasFill && !hasStroke {
+		return
+	}
+
+	fillAlpha := opacity * parseOpacity(style["fill-opacity"]) * fill.a
+	strokeAlpha := opacity * parseOpacity(style["stroke-opacity"]) * stroke.a
+	evenOdd := style["fill-rule"] == "evenodd"
+
+	if hasStroke {
+		dw.pdf.SetDrawColor(stroke.r, stroke.g, stroke.b)
+		dw.pdf.SetLineWidth(strokeWidth * m.scale())
+		dw.applyStrokeStyle(style, m)
+	}
+	if hasFill {
+		dw.pdf.SetFillColor(fill.r, fill.g, fill.b)
+	}
+
+	fillOp := "F"
+	bothOp := "FD"
+	if evenOdd {
+		fillOp, bothOp = "F*", "FD*"
+	}
+
+	switch {
+	case hasFill && hasStroke && fillAlpha == strokeAlpha:
+		dw.pdf.SetAlpha(fillAlpha, "Normal")
+		dw.emitPath(segs, m)
+		dw.pdf.DrawPath(bothOp)
+	default:
+		if hasFill {
+			dw.pdf.SetAlpha(fillAlpha, "Normal")
+			dw.emitPath(segs, m)
+			dw.pdf.DrawPath(fillOp)
+		}
+		if hasStroke {
+			dw.pdf.SetAlpha(strokeAlpha, "Normal")
+			dw.emitPath(segs, m)
+			dw.pdf.DrawPath("D")
+		}
+	}
+	dw.pdf.SetAlpha(1, "Normal")
+	if hasStroke {
+		dw.pdf.SetDashPattern([]float64{}, 0)
+	}
+}
+
+// applyStrokeStyle setzt Strichmuster, Linienenden und Verbindungen.
+func (dw *drawer) applyStrokeStyle(style map[string]string, m matrix) {
+	dashes := parseNumbers(strings.ReplaceAll(style["stroke-dasharray"], "px", ""))
+	total := 0.0
+	for i := range dashes {
+		total += dashes[i]
+		dashes[i] *= m.scale()
+	}
+	if total > 0 && style["stroke-dasharray"] != "none" {
+		if len(dashes)%2 == 1 {
+			dashes = append(dashes, dashes...)
+		}
+		dw.pdf.SetDashPattern(dashes, 0)
+	} else {
+		dw.pdf.SetDashPattern([]float64{}, 0)
+	}
+
+	switch style["stroke-linecap"] {
+	case "round", "square":
+		dw.pdf.SetLineCapStyle(style["stroke-linecap"])
+	default:
+		dw.pdf.SetLineCapStyle("butt")
+	}
+	switch style["stroke-linejoin"] {
+	case "round", "bevel":
+		dw.pdf.SetLineJoinStyle(style["stroke-linejoin"])
+	default:
+		dw.pdf.SetLineJoinStyle("miter")
+	}
+}
+
+// drawMarkers zeichnet Marker (z.B. Pfeilspitzen) am Anfang und Ende eines Pfads.
+func (dw *drawer) drawMarkers(n *node, segs []segment, m matrix, opacity float64) {
+	startRef, endRef := markerRef(n.style["marker-start"]), markerRef(n.style["marker-end"])
+	if startRef == "" && endRef == "" {
+		return
+	}
+	strokeWidth := 1.0
+	if v, ok := parseLength(n.style["stroke-width"]); ok {
+		strokeWidth = v
+	}
+
+	// Startpunkt und Richtung des ersten Abschnitts
+	if marker := dw.doc.ids[startRef]; marker != nil && len(segs) > 1 && segs[0].op == 'M' {
+		start := segs[0].pts[0]
+		next := segs[1]
+		dir := next.end()
+		if next.op == 'C' {
+			dir = firstDistinct(start, next.pts[0], next.pts[1], next.pts[2])
+		}
+		angle := math.Atan2(dir.y-start.y, dir.x-start.x)
+		dw.drawMarker(marker, m, start, angle, strokeWidth, true, opacity)
+	}
+
+	// Endpunkt und Richtung des letzten Abschnitts (closepath ignorieren)
+	last := len(segs) - 1
+	for last > 0 && segs[last].op == 'Z' {
+		last--
+	}
+	if marker := dw.doc.ids[endRef]; marker != nil && last > 0 {
+		end := segs[last].end()
+		prev := segs[last-1].end()
+		if segs[last].op == 'C' {
+			prev = firstDistinct(end, segs[last].pts[1], segs[last].pts[0], prev)
+		}
+		angle := math.Atan2(end.y-prev.y, end.x-prev.x)
+		dw.drawMarker(marker, m, end, angle, strokeWidth, false, opacity)
+	}
+}
+
+// firstDistinct liefert den ersten Kandidaten, der sich von p unterscheidet.
+func firstDistinct(p point, candidates ...point) point {
+	for _, c := range candidates {
+		if math.Abs(c.x-p.x) > 1e-9 || math.Abs(c.y-p.y) > 1e-9 {
+			return c
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// markerRef liefert die ID aus einer Angabe wie url(#arrowhead).
+func markerRef(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "url(") {
+		return ""
+	}
+	ref := strings.TrimSuffix(strings.TrimPrefix(value, "url("), ")")
+	return strings.TrimPrefix(strings.Trim(strings.TrimSpace(ref), `'"`), "#")
+}
+
+// drawMarker zeichnet den Inhalt eines marker-Elements an einem Pfadpunkt.
+func (dw *drawer) drawMarker(marker *node, m matrix, at point, angle, strokeWidth float64, isStart bool, opacity float64) {
+	switch orient := marker.attr("orient"); orient {
+	case "auto":
+	case "auto-start-reverse":
+		if isStart {
+			angle += math.Pi
+		}
+	default:
+		deg, _ := strconv.ParseFloat(strings.TrimSuffix(orient, "deg"), 64)
+		angle = deg * math.Pi / 180
+	}
+
+	mm := m.mul(translate(at.x, at.y)).mul(rotation(angle * 180 / math.Pi))
+	if marker.attr("markerUnits") != "userSpaceOnUse" {
+		mm = mm.mul(scaling(strokeWidth, strokeWidth))
+	}
+	if vb := parseNumbers(marker.attr("viewBox")); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+		w, ok := parseLength(marker.attr("markerWidth"))
+		if !ok {
+			w = 3
+		}
+		h, ok := parseLength(marker.attr("markerHeight"))
+		if !ok {
+			h = 3
+		}
+		s := math.Min(w/vb[2], h/vb[3])
+		mm = mm.mul(scaling(s, s))
+	}
+	refX, _ := parseLength(marker.attr("refX"))
+	refY, _ := parseLength(marker.attr("refY"))
+	mm = mm.mul(translate(-refX, -refY))
+
+	dw.drawChildren(marker, mm, opacity)
+}
+
+// fontSize liefert die Schriftgröße eines Elements in Benutzereinheiten (Standard 16).
+func fontSize(style map[string]string) float64 {
+	value := strings.TrimSpace(style["font-size"])
+	if strings.HasSuffix(value, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err == nil {
+			return 16 * v / 100
+		}
+	}
+	if v, ok := parseLength(value); ok && v > 0 {
+		return v
+	}
+	return 16
+}
+
+// lengthWithFont liest eine Länge, bei der em-Angaben relativ zur Schriftgröße sind.
+func lengthWithFont(s string, size float64) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "em") && !strings.HasSuffix(s, "rem") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(s, "em"), 64)
+		return v * size, err == nil
+	}
+	return parseLength(s)
+}
+
+// firstLength liest den ersten Wert einer Längenliste (z.B. dx="1.1em 2").
+func firstLength(s string, size float64) (float64, bool) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	return lengthWithFont(fields[0], size)
+}
+
+// setFont wählt die Schrift für einen Textabschnitt und liefert die Größe in Millimetern.
+func (dw *drawer) setFont(style map[string]string, m matrix) float64 {
+	family := strings.ToLower(style["font-family"])
+	mono := strings.Contains(family, "mono") || strings.Contains(family, "courier") || strings.Contains(family, "consolas")
+
+	weight := strings.ToLower(style["font-weight"])
+	bold := weight == "bold" || weight == "bolder"
+	if w, err := strconv.Atoi(weight); err == nil && w >= 600 {
+		bold = true
+	}
+	fontStyle := strings.ToLower(style["font-style"])
+	italic := fontStyle == "italic" || fontStyle == "oblique"
+
+	sizeMM := fontSize(style) * m.scale()
+	dw.fonts.SetFont(mono, bold, italic, sizeMM/mmPerPt)
+	return sizeMM
+}
+
+// baselineShift liefert die vertikale Verschiebung für dominant-baseline in Benutzereinheiten.
+func baselineShift(style map[string]string, size float64) float64 {
+	baseline := style["dominant-baseline"]
+	if baseline == "" {
+		baseline = style["alignment-baseline"]
+	}
+	switch baseline {
+	case "middle", "central":
+		return 0.35 * size
+	case "hanging", "text-before-edge":
+		return 0.8 * size
+	case "text-after-edge", "ideographic":
+		return -0.2 * size
+	}
+	return 0
+}
+
+// textRun ist ein zusammenhängender Textabschnitt mit einheitlichem Stil.
+type textRun struct {
+	text   string
+	style  map[string]string
+	x, y   *float64 // Neue absolute Position (beginnt einen neuen Textblock)
+	dx, dy float64
+}
+
+// collectRuns sammelt die Textabschnitte eines text-Elements inklusive verschachtelter tspans.
+func collectRuns(n *node, runs *[]textRun, pending *textRun) {
+	size := fontSize(n.style)
+	if v := parseNumbers(n.attr("x")); len(v) > 0 {
+		x := v[0]
+		pending.x = &x
+	}
+	if v := parseNumbers(n.attr("y")); len(v) > 0 {
+		y := v[0]
+		pending.y = &y
+	}
+	if v, ok := firstLength(n.attr("dx"), size); ok {
+		pending.dx += v
+	}
+	if v, ok := firstLength(n.attr("dy"), size); ok {
+		pending.dy += v
+	}
+
+	for _, c := range n.children {
+		switch {
+		case c.name == "#text":
+			text := whitespace.ReplaceAllString(c.text, " ")
+			if text == "" {
+				continue
+			}
+			run := *pending
+			run.text = text
+			run.style = n.style
+			*runs = append(*runs, run)
+			*pending = textRun{}
+		case c.name == "tspan" || c.name == "a" || c.name == "textPath":
+			if c.style["display"] == "none" {
+				continue
+			}
+			collectRuns(c, runs, pending)
+		}
+	}
+}
+
+// drawText zeichnet ein text-Element als PDF-Text.
+func (dw *drawer) drawText(n *node, m matrix, opacity float64) {
+	var runs []textRun
+	pending := textRun{}
+	collectRuns(n, &runs, &pending)
+	if len(runs) == 0 {
+		return
+	}
+
+	// Führende und doppelte Leerzeichen entfernen, wie beim SVG-Standardverhalten
+	runs[0].text = strings.TrimLeft(runs[0].text, " ")
+	for i := 1; i < len(runs); i++ {
+		if strings.HasSuffix(runs[i-1].text, " ") && runs[i].x == nil {
+			runs[i].text = strings.TrimLeft(runs[i].text, " ")
+		}
+	}
+	runs[len(runs)-1].text = strings.TrimRight(runs[len(runs)-1].text, " ")
+
+	// Textblöcke (chunks) bilden: jede absolute x-Position beginnt einen neuen Block,
+	// text-anchor richtet den gesamten Block aus
+	curX, curY := 0.0, 0.0
+	for start := 0; start < len(runs); {
+		end := start + 1
+		for end < len(runs) && runs[end].x == nil && runs[end].y == nil {
+			end++
+		}
+		chunk := runs[start:end]
+		start = end
+
+		if chunk[0].x != nil {
+			curX = *chunk[0].x
+		}
+		if chunk[0].y != nil {
+			curY = *chunk[0].y
+		}
+
+		// Gesamtbreite des Blocks in Benutzereinheiten messen
+		width := 0.0
+		for i, run := range chunk {
+			if i > 0 {
+				width += run.dx
+			}
+			dw.setFont(run.style, m)
+			width += dw.pdf.GetStringWidth(dw.fonts.PrepareText(run.text)) / m.scale()
+		}
+
+		anchorShift := 0.0
+		switch chunk[0].style["text-anchor"] {
+		case "middle":
+			anchorShift = -width / 2
+		case "end":
+			anchorShift = -width
+		}
+
+		for i, run := range chunk {
+			curX += run.dx
+			curY += run.dy
+			x := curX
+			if i == 0 {
+				x += anchorShift
+			}
+			advance := dw.drawRun(run, m, x, curY, opacity)
+			curX = x + advance
+		}
+	}
+}
+
+// drawRun zeichnet einen Textabschnitt an (x, y) und liefert seine Breite in Benutzereinheiten.
+func (dw *drawer) drawRun(run textRun, m matrix, x, y float64, opacity float64) float64 {
+	sizeMM := dw.setFont(run.style, m)
+	text := dw.fonts.PrepareText(run.text)
+	advance := dw.pdf.GetStringWidth(text) / m.scale()
+	if strings.TrimSpace(run.text) == "" {
+		return advance
+	}
+
+	fillValue, ok := run.style["fill"]
+	if !ok {
+		fillValue = "black"
+	}
+	fill, hasFill := dw.doc.parsePaint(fillValue, run.style)
+	if !hasFill {
+		return advance
+	}
+
+	size := sizeMM / m.scale()
+	p := m.apply(point{x, y + baselineShift(run.style, size)})
+	dw.drawString(text, p, m, fill, opacity*parseOpacity(run.style["fill-opacity"]))
+	return advance
+}
+
+// drawString gibt vorbereiteten Text an der Seitenposition p aus (inklusive Rotation).
+func (dw *drawer) drawString(text string, p point, m matrix, fill color, alpha float64) {
+	dw.pdf.SetTextColor(fill.r, fill.g, fill.b)
+	dw.pdf.SetAlpha(alpha*fill.a, "Normal")
+	angle := math.Atan2(m.b, m.a) * 180 / math.Pi
+	if math.Abs(angle) > 0.01 {
+		dw.pdf.TransformBegin()
+		dw.pdf.TransformRotate(-angle, p.x, p.y)
+		dw.pdf.Text(p.x, p.y, text)
+		dw.pdf.TransformEnd()
+	} else {
+		dw.pdf.Text(p.x, p.y, text)
+	}
+	dw.pdf.SetAlpha(1, "Normal")
+}
+
+// foLine ist eine Zeile einer HTML-Beschriftung in einem foreignObject.
+type foLine struct {
+	text  string
+	style map[string]string
+}
+
+// collectForeignLines extrahiert Textzeilen aus dem HTML-Inhalt eines foreignObject.
+// Block-Elemente und <br> beginnen neue Zeilen.
+func collectForeignLines(n *node, lines *[]foLine) {
+	newLine := func() {
+		if len(*lines) == 0 || strings.TrimSpace((*lines)[len(*lines)-1].text) != "" {
+			*lines = append(*lines, foLine{})
+		}
+	}
+	for _, c := range n.children {
+		switch c.name {
+		case "#text":
+			text := whitespace.ReplaceAllString(c.text, " ")
+			if len(*lines) == 0 {
+				*lines = append(*lines, foLine{})
+			}
+			line := &(*lines)[len(*lines)-1]
+			if line.style == nil && strings.TrimSpace(text) != "" {
+				line.style = n.style
+			}
+			line.text += text
+		case "br":
+			*lines = append(*lines, foLine{})
+		case "div", "p", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6":
+			newLine()
+			collectForeignLines(c, lines)
+			newLine()
+		default:
+			if c.style["display"] != "none" {
+				collectForeignLines(c, lines)
+			}
+		}
+	}
+}
+
+// drawForeignObject zeichnet HTML-Beschriftungen (wie sie Mermaid erzeugt) als
+// zentrierte Textzeilen innerhalb des foreignObject-Rechtecks.
+func (dw *drawer) drawForeignObject(n *node, m matrix, opacity float64) {
+	x, _ := parseLength(n.attr("x"))
+	y, _ := parseLength(n.attr("y"))
+	w, _ := parseLength(n.attr("width"))
+	h, _ := parseLength(n.attr("height"))
+
+	var raw []foLine
+	collectForeignLines(n, &raw)
+	var lines []foLine
+	for _, l := range raw {
+		l.text = strings.TrimSpace(l.text)
+		if l.text == "" {
+			continue
+		}
+		if l.style == nil {
+			l.style = n.style
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	lineHeight := 1.5 * fontSize(lines[0].style)
+	top := y + (h-lineHeight*float64(len(lines)))/2
+	for i, line := range lines {
+		sizeMM := dw.setFont(line.style, m)
+		text := dw.fonts.PrepareText(line.text)
+		width := dw.pdf.GetStringWidth(text) / m.scale()
+
+		// Abweichende Schriftmetriken: zu breite Zeilen verkleinern statt überlaufen zu lassen
+		size := sizeMM / m.scale()
+		if w > 0 && width > w*1.02 {
+			factor := w / width
+			sizeMM *= factor
+			size *= factor
+			width = w
+			dw.setFontScaled(line.style, m, factor)
+		}
+
+		colorValue := line.style["color"]
+		if colorValue == "" {
+			colorValue = "black"
+		}
+		c, ok := parseColor(colorValue, line.style)
+		if !ok {
+			continue
+		}
+
+		baseline := top + lineHeight*float64(i) + lineHeight/2 + 0.35*size
+		p := m.apply(point{x + (w-width)/2, baseline})
+		dw.drawString(text, p, m, c, opacity)
+	}
+}
+
+// setFontScaled setzt die Schrift eines Stils mit einem zusätzlichen Skalierungsfaktor.
+func (dw *drawer) setFontScaled(style map[string]string, m matrix, factor float64) {
+	scaled := make(map[string]string, len(style)+1)
+	for k, v := range style {
+		scaled[k] = v
+	}
+	scaled["font-size"] = strconv.FormatFloat(fontSize(style)*factor, 'f', -1, 64) + "px"
+	dw.setFont(scaled, m)
+}
@@ -0,0 +1,489 @@
+package svg
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// point ist ein Punkt im Koordinatensystem der Grafik.
+type point struct{ x, y float64 }
+
+// segment ist ein Abschnitt eines Pfads in absoluten Koordinaten.
+// Alle Kurven werden in kubische Bézierkurven umgewandelt.
+type segment struct {
+	op  byte     // 'M' (moveto), 'L' (lineto), 'C' (kubische Kurve), 'Z' (schließen)
+	pts [3]point // L/M: pts[0]; C: Kontrollpunkte pts[0], pts[1] und Endpunkt pts[2]
+}
+
+// end liefert den Endpunkt des Segments.
+func (s segment) end() point {
+	if s.op == 'C' {
+		return s.pts[2]
+	}
+	return s.pts[0]
+}
+
+// matrix ist eine affine Transformation [a c e; b d f; 0 0 1] wie in SVG.
+type matrix struct{ a, b, c, d, e, f float64 }
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul liefert m * n (n wird zuerst angewendet).
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+// apply transformiert einen Punkt.
+func (m matrix) apply(p point) point {
+	return point{m.a*p.x + m.c*p.y + m.e, m.b*p.x + m.d*p.y + m.f}
+}
+
+// scale liefert den mittleren Skalierungsfaktor (für Linienbreiten und Schriftgrößen).
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m.a*m.d - m.b*m.c))
+}
+
+func translate(x, y float64) matrix { return matrix{1, 0, 0, 1, x, y} }
+func scaling(x, y float64) matrix   { return matrix{x, 0, 0, y, 0, 0} }
+func rotation(deg float64) matrix {
+	rad := deg * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	return matrix{cos, sin, -sin, cos, 0, 0}
+}
+
+// parseTransform liest ein transform-Attribut (matrix, translate, scale, rotate, skewX, skewY).
+func parseTransform(s string) matrix {
+	m := identity
+	for {
+		s = strings.TrimLeft(s, " \t\r\n,")
+		open := strings.IndexByte(s, '(')
+		closing := strings.IndexByte(s, ')')
+		if open == -1 || closing < open {
+			return m
+		}
+		name := strings.TrimSpace(s[:open])
+		args := parseNumbers(s[open+1 : closing])
+		s = s[closing+1:]
+
+		arg := func(i int, def float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return def
+		}
+		var t matrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				continue
+			}
+			t = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case "translate":
+			t = translate(arg(0, 0), arg(1, 0))
+		case "scale":
+			sx := arg(0, 1)
+			t = scaling(sx, arg(1, sx))
+		case "rotate":
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = translate(cx, cy).mul(rotation(arg(0, 0))).mul(translate(-cx, -cy))
+		case "skewX":
+			t = matrix{1, 0, math.Tan(arg(0, 0) * math.Pi / 180), 1, 0, 0}
+		case "skewY":
+			t = matrix{1, math.Tan(arg(0, 0) * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			continue
+		}
+		m = m.mul(t)
+	}
+}
+
+// scanner liest Zahlen und Befehle aus Pfad- und Listenangaben.
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (sc *scanner) skipSeparators() {
+	for sc.pos < len(sc.s) {
+		switch sc.s[sc.pos] {
+		case ' ', '\t', '\r', '\n', ',':
+			sc.pos++
+		default:
+			return
+		}
+	}
+}
+
+// number liest die nächste Zahl (inklusive Exponent, ohne Trennzeichen wie in "1.5.5").
+func (sc *scanner) number() (float64, bool) {
+	sc.skipSeparators()
+	start := sc.pos
+	i := sc.pos
+	if i < len(sc.s) && (sc.s[i] == '+' || sc.s[i] == '-') {
+		i++
+	}
+	digits, dot := false, false
+	for i < len(sc.s) {
+		c := sc.s[i]
+		if c >= '0' && c <= '9' {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		i++
+	}
+	if !digits {
+		return 0, false
+	}
+	if i < len(sc.s) && (sc.s[i] == 'e' || sc.s[i] == 'E') {
+		j := i + 1
+		if j < len(sc.s) && (sc.s[j] == '+' || sc.s[j] == '-') {
+			j++
+		}
+		if j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+			for j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+				j++
+			}
+			i = j
+		}
+	}
+	v, err := strconv.ParseFloat(sc.s[start:i], 64)
+	if err != nil {
+		return 0, false
+	}
+	sc.pos = i
+	return v, true
+}
+
+// flag liest ein Arc-Flag (0 oder 1), das ohne Trennzeichen folgen darf.
+func (sc *scanner) flag() (bool, bool) {
+	sc.skipSeparators()
+	if sc.pos < len(sc.s) && (sc.s[sc.pos] == '0' || sc.s[sc.pos] == '1') {
+		sc.pos++
+		return sc.s[sc.pos-1] == '1', true
+	}
+	return false, false
+}
+
+// parsePath wandelt eine Pfadangabe (d-Attribut) in absolute Segmente um.
+func parsePath(d string) []segment {
+	var segs []segment
+	sc := scanner{s: d}
+	var cur, start, lastCtrl point
+	var cmd, prevCmd byte
+
+	for {
+		sc.skipSeparators()
+		if sc.pos >= len(sc.s) {
+			break
+		}
+		c := sc.s[sc.pos]
+		if strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) != -1 {
+			cmd = c
+			sc.pos++
+		} else if cmd == 0 || cmd == 'Z' || cmd == 'z' {
+			// Zahlen ohne Befehl bzw. nach closepath sind ungültig
+			break
+		}
+		rel := cmd >= 'a'
+		upper := cmd &^ 0x20
+		offset := func(p point) point {
+			if rel {
+				return point{p.x + cur.x, p.y + cur.y}
+			}
+			return p
+		}
+		readPoint := func() (point, bool) {
+			x, ok1 := sc.number()
+			y, ok2 := sc.number()
+			return point{x, y}, ok1 && ok2
+		}
+
+		ok := true
+		switch upper {
+		case 'Z':
+			segs = append(segs, segment{op: 'Z'})
+			cur = start
+		case 'M':
+			var p point
+			if p, ok = readPoint(); ok {
+				cur = offset(p)
+				start = cur
+				segs = append(segs, segment{op: 'M', pts: [3]point{cur}})
+				// Weitere Koordinatenpaare gelten als lineto
+				if rel {
+					cmd = 'l'
+				} else {
+					cmd = 'L'
+				}
+			}
+		case 'L':
+			var p point
+			if p, ok = readPoint(); ok {
+				cur = offset(p)
+				segs = append(segs, segment{op: 'L', pts: [3]point{cur}})
+			}
+		case 'H':
+			var x float64
+			if x, ok = sc.number(); ok {
+				if rel {
+					x += cur.x
+				}
+				cur = point{x, cur.y}
+				segs = append(segs, segment{op: 'L', pts: [3]point{cur}})
+			}
+		case 'V':
+			var y float64
+			if y, ok = sc.number(); ok {
+				if rel {
+					y += cur.y
+				}
+				cur = point{cur.x, y}
+				segs = append(segs, segment{op: 'L', pts: [3]point{cur}})
+			}
+		case 'C', 'S':
+			var c1, c2, p point
+			if upper == 'C' {
+				c1, ok = readPoint()
+				c1 = offset(c1)
+			} else {
+				// Gespiegelter Kontrollpunkt der vorherigen Kurve
+				c1 = cur
+				if pu := prevCmd &^ 0x20; pu == 'C' || pu == 'S' {
+					c1 = point{2*cur.x - lastCtrl.x, 2*cur.y - lastCtrl.y}
+				}
+			}
+			if ok {
+				c2, ok = readPoint()
+				c2 = offset(c2)
+			}
+			if ok {
+				p, ok = readPoint()
+				p = offset(p)
+			}
+			if ok {
+				segs = append(segs, segment{op: 'C', pts: [3]point{c1, c2, p}})
+				lastCtrl, cur = c2, p
+			}
+		case 'Q', 'T':
+			var q, p point
+			if upper == 'Q' {
+				q, ok = readPoint()
+				q = offset(q)
+			} else {
+				q = cur
+				if pu := prevCmd &^ 0x20; pu == 'Q' || pu == 'T' {
+					q = point{2*cur.x - lastCtrl.x, 2*cur.y - lastCtrl.y}
+				}
+			}
+			if ok {
+				p, ok = readPoint()
+				p = offset(p)
+			}
+			if ok {
+				// Quadratische in kubische Kurve umwandeln
+				c1 := point{cur.x + 2.0/3*(q.x-cur.x), cur.y + 2.0/3*(q.y-cur.y)}
+				c2 := point{p.x + 2.0/3*(q.x-p.x), p.y + 2.0/3*(q.y-p.y)}
+				segs = append(segs, segment{op: 'C', pts: [3]point{c1, c2, p}})
+				lastCtrl, cur = q, p
+			}
+		case 'A':
+			rx, ok1 := sc.number()
+			ry, ok2 := sc.number()
+			angle, ok3 := sc.number()
+			large, ok4 := sc.flag()
+			sweep, ok5 := sc.flag()
+			p, ok6 := readPoint()
+			ok = ok1 && ok2 && ok3 && ok4 && ok5 && ok6
+			if ok {
+				p = offset(p)
+				segs = append(segs, arcToCurves(cur, p, rx, ry, angle, large, sweep)...)
+				cur = p
+			}
+		}
+		if !ok {
+			// Ungültige Daten: bis hierhin gelesenen Pfad verwenden
+			break
+		}
+		prevCmd = cmd
+	}
+	return segs
+}
+
+// arcToCurves wandelt einen elliptischen Bogen (SVG-Endpunktnotation) in kubische Kurven um.
+func arcToCurves(from, to point, rx, ry, angle float64, large, sweep bool) []segment {
+	if from == to {
+		return nil
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		return []segment{{op: 'L', pts: [3]point{to}}}
+	}
+
+	phi := angle * math.Pi / 180
+	cosPhi, sinPhi := math.Cos(phi), math.Sin(phi)
+
+	// Umrechnung in Mittelpunktnotation (SVG 1.1, Anhang F.6.5)
+	dx, dy := (from.x-to.x)/2, (from.y-to.y)/2
+	x1 := cosPhi*dx + sinPhi*dy
+	y1 := -sinPhi*dx + cosPhi*dy
+
+	// Zu kleine Radien vergrößern
+	lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry)
+	if lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx, ry = rx*s, ry*s
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := 0.0
+	if den != 0 && num > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if large == sweep {
+		coef = -coef
+	}
+	cx1 := coef * rx * y1 / ry
+	cy1 := -coef * ry * x1 / rx
+	cx := cosPhi*cx1 - sinPhi*cy1 + (from.x+to.x)/2
+	cy := sinPhi*cx1 + cosPhi*cy1 + (from.y+to.y)/2
+
+	vecAngle := func(ux, uy, vx, vy float64) float64 {
+		a := math.Atan2(uy, ux)
+		b := math.Atan2(vy, vx)
+		return b - a
+	}
+	theta1 := vecAngle(1, 0, (x1-cx1)/rx, (y1-cy1)/ry)
+	delta := vecAngle((x1-cx1)/rx, (y1-cy1)/ry, (-x1-cx1)/rx, (-y1-cy1)/ry)
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+
+	// In Teilbögen von höchstens 90° zerlegen
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	k := 4.0 / 3 * math.Tan(step/4)
+
+	ellipse := func(t float64) (point, point) {
+		cosT, sinT := math.Cos(t), math.Sin(t)
+		p := point{
+			cx + rx*cosT*cosPhi - ry*sinT*sinPhi,
+			cy + rx*cosT*sinPhi + ry*sinT*cosPhi,
+		}
+		deriv := point{
+			-rx*sinT*cosPhi - ry*cosT*sinPhi,
+			-rx*sinT*sinPhi + ry*cosT*cosPhi,
+		}
+		return p, deriv
+	}
+
+	segs := make([]segment, 0, n)
+	t := theta1
+	p0, d0 := ellipse(t)
+	for i := 0; i < n; i++ {
+		t += step
+		p1, d1 := ellipse(t)
+		if i == n-1 {
+			p1 = to
+		}
+		segs = append(segs, segment{op: 'C', pts: [3]point{
+			{p0.x + k*d0.x, p0.y + k*d0.y},
+			{p1.x - k*d1.x, p1.y - k*d1.y},
+			p1,
+		}})
+		p0, d0 = p1, d1
+	}
+	return segs
+}
+
+// shapePath wandelt Grundformen (rect, circle, ellipse, line, polyline, polygon) und
+// path-Elemente in Segmente um. Gibt nil für andere Elemente zurück.
+func shapePath(n *node) []segment {
+	num := func(name string) float64 {
+		v, _ := parseLength(n.attr(name))
+		return v
+	}
+	switch n.name {
+	case "path":
+		return parsePath(n.attr("d"))
+	case "rect":
+		x, y, w, h := num("x"), num("y"), num("width"), num("height")
+		if w <= 0 || h <= 0 {
+			return nil
+		}
+		rx, rxOK := parseLength(n.attr("rx"))
+		ry, ryOK := parseLength(n.attr("ry"))
+		if !rxOK {
+			rx = ry
+		}
+		if !ryOK {
+			ry = rx
+		}
+		rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+		if rx <= 0 || ry <= 0 {
+			return []segment{
+				{op: 'M', pts: [3]point{{x, y}}},
+				{op: 'L', pts: [3]point{{x + w, y}}},
+				{op: 'L', pts: [3]point{{x + w, y + h}}},
+				{op: 'L', pts: [3]point{{x, y + h}}},
+				{op: 'Z'},
+			}
+		}
+		segs := []segment{{op: 'M', pts: [3]point{{x + rx, y}}}}
+		segs = append(segs, segment{op: 'L', pts: [3]point{{x + w - rx, y}}})
+		segs = append(segs, arcToCurves(point{x + w - rx, y}, point{x + w, y + ry}, rx, ry, 0, false, true)...)
+		segs = append(segs, segment{op: 'L', pts: [3]point{{x + w, y + h - ry}}})
+		segs = append(segs, arcToCurves(point{x + w, y + h - ry}, point{x + w - rx, y + h}, rx, ry, 0, false, true)...)
+		segs = append(segs, segment{op: 'L', pts: [3]point{{x + rx, y + h}}})
+		segs = append(segs, arcToCurves(point{x + rx, y + h}, point{x, y + h - ry}, rx, ry, 0, false, true)...)
+		segs = append(segs, segment{op: 'L', pts: [3]point{{x, y + ry}}})
+		segs = append(segs, arcToCurves(point{x, y + ry}, point{x + rx, y}, rx, ry, 0, false, true)...)
+		return append(segs, segment{op: 'Z'})
+	case "circle", "ellipse":
+		cx, cy := num("cx"), num("cy")
+		rx, ry := num("rx"), num("ry")
+		if n.name == "circle" {
+			rx, ry = num("r"), num("r")
+		}
+		if rx <= 0 || ry <= 0 {
+			return nil
+		}
+		segs := []segment{{op: 'M', pts: [3]point{{cx + rx, cy}}}}
+		segs = append(segs, arcToCurves(point{cx + rx, cy}, point{cx - rx, cy}, rx, ry, 0, false, true)...)
+		segs = append(segs, arcToCurves(point{cx - rx, cy}, point{cx + rx, cy}, rx, ry, 0, false, true)...)
+		return append(segs, segment{op: 'Z'})
+	case "line":
+		return []segment{
+			{op: 'M', pts: [3]point{{num("x1"), num("y1")}}},
+			{op: 'L', pts: [3]point{{num("x2"), num("y2")}}},
+		}
+	case "polyline", "polygon":
+		nums := parseNumbers(n.attr("points"))
+		var segs []segment
+		for i := 0; i+1 < len(nums); i += 2 {
+			op := byte('L')
+			if i == 0 {
+				op = 'M'
+			}
+			segs = append(segs, segment{op: op, pts: [3]point{{nums[i], nums[i+1]}}})
+		}
+		if n.name == "polygon" && len(segs) > 0 {
+			segs = append(segs, segment{op: 'Z'})
+		}
+		return segs
+	}
+	return nil
+}
@@ -0,0 +1,452 @@
+package svg
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// inheritedProperties werden von Eltern- an Kindelemente vererbt.
+var inheritedProperties = map[string]bool{
+	"fill": true, "fill-opacity": true, "fill-rule": true,
+	"stroke": true, "stroke-width": true, "stroke-opacity": true, "stroke-dasharray": true,
+	"stroke-linecap": true, "stroke-linejoin": true,
+	"font-family": true, "font-size": true, "font-weight": true, "font-style": true,
+	"text-anchor": true, "dominant-baseline": true, "visibility": true, "color": true,
+	"marker-start": true, "marker-mid": true, "marker-end": true,
+}
+
+// presentationAttributes sind Attribute, die wie CSS-Eigenschaften wirken.
+var presentationAttributes = []string{
+	"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-opacity", "stroke-dasharray",
+	"stroke-linecap", "stroke-linejoin", "opacity", "font-family", "font-size", "font-weight",
+	"font-style", "text-anchor", "dominant-baseline", "alignment-baseline", "visibility", "display", "color",
+	"marker-start", "marker-mid", "marker-end",
+}
+
+// cssRule ist eine einzelne Regel (ein Selektor) eines Stylesheets.
+type cssRule struct {
+	selector    []compound // Nachfahren-Kette, das letzte Element muss auf den Knoten passen
+	specificity int
+	order       int
+	decls       map[string]string
+}
+
+// compound ist ein einfacher Selektor wie "rect", ".node", "g.cluster" oder "#id".
+type compound struct {
+	tag     string
+	id      string
+	classes []string
+}
+
+var cssComments = regexp.MustCompile(`(?s)/\*.*?\*/`)
+
+// parseStylesheets sammelt die Regeln aller <style>-Elemente.
+func parseStylesheets(root *node) []cssRule {
+	var rules []cssRule
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.name == "style" {
+			var text strings.Builder
+			for _, c := range n.children {
+				text.WriteString(c.text)
+			}
+			rules = append(rules, parseCSS(text.String(), len(rules))...)
+			return
+		}
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(root)
+	return rules
+}
+
+// parseCSS zerlegt ein Stylesheet in Regeln. @-Regeln (z.B. @keyframes, @media) sowie
+// Selektoren mit Pseudo-Klassen oder Attribut-Selektoren werden übersprungen.
+func parseCSS(css string, order int) []cssRule {
+	css = cssComments.ReplaceAllString(css, "")
+	var rules []cssRule
+
+	for i := 0; i < len(css); {
+		open := strings.IndexByte(css[i:], '{')
+		if open == -1 {
+			break
+		}
+		prelude := strings.TrimSpace(css[i : i+open])
+		// Passende schließende Klammer finden (verschachtelte Blöcke bei @-Regeln)
+		depth, end := 0, -1
+		for j := i + open; j < len(css); j++ {
+			if css[j] == '{' {
+				depth++
+			} else if css[j] == '}' {
+				depth--
+				if depth == 0 {
+					end = j
+					break
+				}
+			}
+		}
+		if end == -1 {
+			break
+		}
+		body := css[i+open+1 : end]
+		i = end + 1
+
+		if strings.HasPrefix(prelude, "@") {
+			continue
+		}
+		decls := parseDeclarations(body)
+		for _, sel := range strings.Split(prelude, ",") {
+			chain, spec, ok := parseSelector(sel)
+			if !ok {
+				continue
+			}
+			rules = append(rules, cssRule{selector: chain, specificity: spec, order: order, decls: decls})
+			order++
+		}
+	}
+	return rules
+}
+
+// parseDeclarations liest eine Deklarationsliste wie "fill:#fff;stroke-width:1px".
+func parseDeclarations(s string) map[string]string {
+	decls := map[string]string{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, ":")
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		if name != "" && value != "" {
+			decls[name] = value
+		}
+	}
+	return decls
+}
+
+// parseSelector zerlegt einen Selektor in eine Nachfahren-Kette. Kind-Kombinatoren (>)
+// werden wie Nachfahren behandelt.
+func parseSelector(sel string) ([]compound, int, bool) {
+	sel = strings.TrimSpace(strings.ReplaceAll(sel, ">", " "))
+	if sel == "" || strings.ContainsAny(sel, ":[+~") {
+		return nil, 0, false
+	}
+
+	var chain []compound
+	spec := 0
+	for _, part := range strings.Fields(sel) {
+		var c compound
+		if part == "*" {
+			chain = append(chain, c)
+			continue
+		}
+		// Teile an "." und "#" auftrennen
+		token, kind := "", byte(0)
+		flush := func() {
+			switch kind {
+			case 0:
+				c.tag = token
+				if token != "" {
+					spec++
+				}
+			case '.':
+				c.classes = append(c.classes, token)
+				spec += 10
+			case '#':
+				c.id = token
+				spec += 100
+			}
+		}
+		for i := 0; i < len(part); i++ {
+			if part[i] == '.' || part[i] == '#' {
+				flush()
+				token, kind = "", part[i]
+				continue
+			}
+			token += string(part[i])
+		}
+		flush()
+		chain = append(chain, c)
+	}
+	return chain, spec, true
+}
+
+// matches prüft, ob ein einfacher Selektor auf ein Element passt.
+func (c compound) matches(n *node) bool {
+	if c.tag != "" && c.tag != n.name {
+		return false
+	}
+	if c.id != "" && c.id != n.attr("id") {
+		return false
+	}
+	if len(c.classes) > 0 {
+		have := n.classes()
+		for _, want := range c.classes {
+			found := false
+			for _, cl := range have {
+				if cl == want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matches prüft, ob die Regel auf ein Element passt.
+func (r cssRule) matches(n *node) bool {
+	last := len(r.selector) - 1
+	if !r.selector[last].matches(n) {
+		return false
+	}
+	// Vorfahren von innen nach außen abarbeiten
+	i := last - 1
+	for p := n.parent; p != nil && i >= 0; p = p.parent {
+		if r.selector[i].matches(p) {
+			i--
+		}
+	}
+	return i < 0
+}
+
+// applyStyles berechnet für alle Elemente den Stil aus Vererbung, Präsentationsattributen,
+// CSS-Regeln und style-Attributen (in aufsteigender Priorität).
+func applyStyles(root *node, rules []cssRule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].specificity != rules[j].specificity {
+			return rules[i].specificity < rules[j].specificity
+		}
+		return rules[i].order < rules[j].order
+	})
+
+	var walk func(n *node, inherited map[string]string)
+	walk = func(n *node, inherited map[string]string) {
+		if n.name == "#text" {
+			return
+		}
+		style := make(map[string]string, len(inherited)+4)
+		for k, v := range inherited {
+			style[k] = v
+		}
+		for _, name := range presentationAttributes {
+			if v := n.attr(name); v != "" {
+				style[name] = v
+			}
+		}
+		for _, rule := range rules {
+			if rule.matches(n) {
+				for k, v := range rule.decls {
+					style[k] = v
+				}
+			}
+		}
+		for k, v := range parseDeclarations(n.attr("style")) {
+			style[k] = v
+		}
+		for k, v := range style {
+			if v == "inherit" {
+				if pv, ok := inherited[k]; ok {
+					style[k] = pv
+				} else {
+					delete(style, k)
+				}
+			}
+		}
+		n.style = style
+
+		childInherited := make(map[string]string, len(style))
+		for k, v := range style {
+			if inheritedProperties[k] {
+				childInherited[k] = v
+			}
+		}
+		for _, c := range n.children {
+			walk(c, childInherited)
+		}
+	}
+	walk(root, map[string]string{})
+}
+
+// color ist eine RGB-Farbe mit Deckkraft.
+type color struct {
+	r, g, b int
+	a       float64
+}
+
+// parsePaint wertet eine fill- bzw. stroke-Angabe aus. Verläufe (url(#id)) werden
+// durch die erste Stop-Farbe ersetzt. Gibt false für "none" oder unbekannte Werte zurück.
+func (d *Document) parsePaint(value string, style map[string]string) (color, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "url(") {
+		ref := strings.TrimSuffix(strings.TrimPrefix(value, "url("), ")")
+		ref = strings.Trim(strings.TrimSpace(ref), `'"`)
+		if target := d.ids[strings.TrimPrefix(ref, "#")]; target != nil {
+			for _, stop := range target.children {
+				if stop.name == "stop" {
+					stopColor := stop.style["stop-color"]
+					if stopColor == "" {
+						stopColor = stop.attr("stop-color")
+					}
+					if c, ok := parseColor(stopColor, style); ok {
+						return c, true
+					}
+				}
+			}
+		}
+		// Fallback-Farbe nach der URL (z.B. "url(#g) #fff")
+		if idx := strings.Index(value, ")"); idx != -1 {
+			return parseColor(strings.TrimSpace(value[idx+1:]), style)
+		}
+		return color{}, false
+	}
+	return parseColor(value, style)
+}
+
+// parseColor liest eine CSS-Farbe (#rgb, #rrggbb, rgb(), rgba(), hsl(), Farbnamen, currentColor).
+func parseColor(value string, style map[string]string) (color, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "none", "transparent":
+		return color{}, false
+	case "currentcolor":
+		if c := style["color"]; c != "" && strings.ToLower(c) != "currentcolor" {
+			return parseColor(c, nil)
+		}
+		return color{0, 0, 0, 1}, true
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		alpha := 1.0
+		if len(hex) == 4 || len(hex) == 8 {
+			n := len(hex) / 4
+			a, _ := strconv.ParseUint(strings.Repeat(hex[len(hex)-n:], 3-n), 16, 8)
+			alpha = float64(a) / 255
+			hex = hex[:len(hex)-n]
+		}
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return color{}, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return color{}, false
+		}
+		return color{int(v >> 16), int(v >> 8 & 0xff), int(v & 0xff), alpha}, true
+	}
+
+	if open := strings.IndexByte(value, '('); open != -1 && strings.HasSuffix(value, ")") {
+		fn := value[:open]
+		args := strings.FieldsFunc(value[open+1:len(value)-1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '/'
+		})
+		if len(args) < 3 {
+			return color{}, false
+		}
+		alpha := 1.0
+		if len(args) > 3 {
+			alpha = parseComponent(args[3], 1)
+		}
+		switch fn {
+		case "rgb", "rgba":
+			return color{
+				int(math.Round(parseComponent(args[0], 255))),
+				int(math.Round(parseComponent(args[1], 255))),
+				int(math.Round(parseComponent(args[2], 255))),
+				alpha,
+			}, true
+		case "hsl", "hsla":
+			h, _ := strconv.ParseFloat(strings.TrimSuffix(args[0], "deg"), 64)
+			r, g, b := hslToRGB(h, parseComponent(args[1], 1), parseComponent(args[2], 1))
+			return color{r, g, b, alpha}, true
+		}
+		return color{}, false
+	}
+
+	if hex, ok := namedColors[value]; ok {
+		return parseColor(hex, nil)
+	}
+	return color{}, false
+}
+
+// parseComponent liest eine Farbkomponente als Zahl (0..max) oder Prozentwert.
+func parseComponent(s string, max float64) float64 {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		v, _ := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		return math.Max(0, math.Min(max, v/100*max))
+	}
+	v, _ := strconv.ParseFloat(s, 64)
+	return math.Max(0, math.Min(max, v))
+}
+
+// hslToRGB wandelt HSL (h in Grad, s und l in 0..1) in RGB um.
+func hslToRGB(h, s, l float64) (int, int, int) {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 360
+	if s == 0 {
+		v := int(math.Round(l * 255))
+		return v, v, v
+	}
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	hue := func(t float64) int {
+		t = math.Mod(t+1, 1)
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 0.5:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return int(math.Round(v * 255))
+	}
+	return hue(h + 1.0/3), hue(h), hue(h - 1.0/3)
+}
+
+// parseOpacity liest eine Deckkraft (Zahl oder Prozent), Standard 1.
+func parseOpacity(s string) float64 {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 1
+	}
+	return parseComponent(s, 1)
+}
+
+// namedColors enthält gängige CSS-Farbnamen.
+var namedColors = map[string]string{
+	"black": "#000000", "white": "#ffffff", "red": "#ff0000", "green": "#008000", "blue": "#0000ff",
+	"yellow": "#ffff00", "orange": "#ffa500", "purple": "#800080", "gray": "#808080", "grey": "#808080",
+	"silver": "#c0c0c0", "maroon": "#800000", "olive": "#808000", "lime": "#00ff00", "aqua": "#00ffff",
+	"teal": "#008080", "navy": "#000080", "fuchsia": "#ff00ff", "magenta": "#ff00ff", "cyan": "#00ffff",
+	"lightgray": "#d3d3d3", "lightgrey": "#d3d3d3", "darkgray": "#a9a9a9", "darkgrey": "#a9a9a9",
+	"dimgray": "#696969", "dimgrey": "#696969", "gainsboro": "#dcdcdc", "whitesmoke": "#f5f5f5",
+	"lightblue": "#add8e6", "lightgreen": "#90ee90", "lightyellow": "#ffffe0", "lightpink": "#ffb6c1",
+	"darkblue": "#00008b", "darkgreen": "#006400", "darkred": "#8b0000", "darkorange": "#ff8c00",
+	"steelblue": "#4682b4", "skyblue": "#87ceeb", "royalblue": "#4169e1", "dodgerblue": "#1e90ff",
+	"coral": "#ff7f50", "tomato": "#ff6347", "salmon": "#fa8072", "gold": "#ffd700", "khaki": "#f0e68c",
+	"pink": "#ffc0cb", "violet": "#ee82ee", "indigo": "#4b0082", "brown": "#a52a2a", "beige": "#f5f5dc",
+	"ivory": "#fffff0", "lavender": "#e6e6fa", "linen": "#faf0e6", "snow": "#fffafa", "azure": "#f0ffff",
+	"honeydew": "#f0fff0", "mintcream": "#f5fffa", "aliceblue": "#f0f8ff", "ghostwhite": "#f8f8ff",
+	"slategray": "#708090", "slategrey": "#708090", "lightslategray": "#778899", "darkslategray": "#2f4f4f",
+	"crimson": "#dc143c", "firebrick": "#b22222", "orchid": "#da70d6", "plum": "#dda0dd", "tan": "#d2b48c",
+	"chocolate": "#d2691e", "sienna": "#a0522d", "peru": "#cd853f", "wheat": "#f5deb3", "seagreen": "#2e8b57",
+	"forestgreen": "#228b22", "limegreen": "#32cd32", "turquoise": "#40e0d0", "cornflowerblue": "#6495ed",
+	"mediumpurple": "#9370db", "rebeccapurple": "#663399", "lemonchiffon": "#fffacd", "papayawhip": "#ffefd5",
+}
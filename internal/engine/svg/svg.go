@@ -0,0 +1,203 @@
+// Package svg zeichnet SVG-Grafiken als Vektorgrafik in ein gofpdf-Dokument.
+//
+// Unterstützt wird die Teilmenge, die Mermaid und typische Icons erzeugen: Pfade und
+// Grundformen, Texte (inklusive foreignObject-Beschriftungen), Gruppen, use-Verweise,
+// Transformationen, Marker (Pfeilspitzen), Füllungen, Konturen und einfache CSS-Regeln.
+// Clipping, Masken, Filter und Verläufe werden ignoriert bzw. durch Volltonfarben ersetzt.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// node ist ein Element (oder Textknoten) des SVG-Baums.
+type node struct {
+	name     string            // Lokaler Elementname, "#text" für Textknoten
+	attrs    map[string]string // Attribute ohne Namespace-Präfix
+	children []*node
+	parent   *node
+	text     string            // Inhalt von Textknoten
+	style    map[string]string // Berechneter Stil (nach CSS und Vererbung)
+}
+
+// attr liefert ein Attribut oder einen leeren String.
+func (n *node) attr(name string) string {
+	return n.attrs[name]
+}
+
+// classes liefert die CSS-Klassen des Elements.
+func (n *node) classes() []string {
+	return strings.Fields(n.attrs["class"])
+}
+
+// Document ist eine geparste SVG-Grafik.
+type Document struct {
+	root   *node
+	ids    map[string]*node
+	viewX  float64 // viewBox-Ursprung
+	viewY  float64
+	viewW  float64 // viewBox-Größe (bzw. width/height ohne viewBox)
+	viewH  float64
+	width  float64 // Intrinsische Größe in px
+	height float64
+}
+
+// ParseFile liest eine SVG-Datei.
+func ParseFile(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(bytes.NewReader(data))
+}
+
+// Parse liest eine SVG-Grafik aus r.
+func Parse(r io.Reader) (*Document, error) {
+	decoder := xml.NewDecoder(r)
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	var root *node
+	var current *node
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ungültiges SVG: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr)), parent: current}
+			for _, a := range t.Attr {
+				// xlink:href und href gleich behandeln, andere Namespaces ignorieren
+				if a.Name.Space != "" && a.Name.Local != "href" && a.Name.Space != "xmlns" {
+					continue
+				}
+				n.attrs[a.Name.Local] = a.Value
+			}
+			if current == nil {
+				root = n
+			} else {
+				current.children = append(current.children, n)
+			}
+			current = n
+		case xml.EndElement:
+			if current != nil {
+				current = current.parent
+			}
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, &node{name: "#text", text: string(t), parent: current})
+			}
+		}
+	}
+
+	if root == nil || root.name != "svg" {
+		return nil, fmt.Errorf("ungültiges SVG: kein <svg>-Wurzelelement")
+	}
+
+	doc := &Document{root: root, ids: map[string]*node{}}
+	doc.indexIDs(root)
+	if err := doc.readSize(); err != nil {
+		return nil, err
+	}
+	applyStyles(root, parseStylesheets(root))
+	return doc, nil
+}
+
+// indexIDs sammelt alle Elemente mit id-Attribut (für use, marker und Verläufe).
+func (d *Document) indexIDs(n *node) {
+	if id := n.attr("id"); id != "" {
+		d.ids[id] = n
+	}
+	for _, c := range n.children {
+		d.indexIDs(c)
+	}
+}
+
+// readSize ermittelt viewBox und intrinsische Größe der Grafik.
+func (d *Document) readSize() error {
+	w, wOK := parseLength(d.root.attr("width"))
+	h, hOK := parseLength(d.root.attr("height"))
+
+	if vb := parseNumbers(d.root.attr("viewBox")); len(vb) == 4 && vb[2] > 0 && vb[3] > 0 {
+		d.viewX, d.viewY, d.viewW, d.viewH = vb[0], vb[1], vb[2], vb[3]
+		// Fehlende oder prozentuale Angaben aus dem Seitenverhältnis der viewBox ableiten
+		switch {
+		case wOK && hOK:
+		case wOK:
+			h = w * d.viewH / d.viewW
+		case hOK:
+			w = h * d.viewW / d.viewH
+		default:
+			w, h = d.viewW, d.viewH
+		}
+	} else {
+		if !wOK || !hOK {
+			return fmt.Errorf("ungültiges SVG: weder viewBox noch width/height angegeben")
+		}
+		d.viewW, d.viewH = w, h
+	}
+
+	if w <= 0 || h <= 0 {
+		return fmt.Errorf("ungültiges SVG: Größe %gx%g", w, h)
+	}
+	d.width, d.height = w, h
+	return nil
+}
+
+// Size liefert die intrinsische Größe der Grafik in px.
+func (d *Document) Size() (float64, float64) {
+	return d.width, d.height
+}
+
+// parseLength liest eine absolute Längenangabe und rechnet sie in px um.
+// Prozentangaben und leere Werte gelten als nicht gesetzt.
+func parseLength(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasSuffix(s, "%") {
+		return 0, false
+	}
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"px", 1}, {"pt", 96.0 / 72.0}, {"pc", 16}, {"mm", 96.0 / 25.4}, {"cm", 96.0 / 2.54}, {"in", 96}, {"em", 16}, {"ex", 8},
+	}
+	factor := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			factor = u.factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * factor, true
+}
+
+// parseNumbers liest eine durch Leerzeichen und/oder Kommas getrennte Zahlenliste.
+func parseNumbers(s string) []float64 {
+	var nums []float64
+	sc := scanner{s: s}
+	for {
+		v, ok := sc.number()
+		if !ok {
+			break
+		}
+		nums = append(nums, v)
+	}
+	return nums
+}
@@ -0,0 +1,260 @@
+// Package i18n enthält die Übersetzungen der Texte, die godocgen in die erzeugten Dokumente schreibt,
+// sowie die sprachabhängige Formatierung von Datum und Zahlen.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schlüssel der übersetzbaren Texte. Projekte können sie unter translations in docgen.yml überschreiben.
+const (
+	TOCTitle           = "toc.title"            // Überschrift des Inhaltsverzeichnisses
+	CoverAuthor        = "cover.author"         // Autor auf dem Standard-Deckblatt (%s = Name)
+	CoverCreatedBy     = "cover.created_by"     // Ersteller am unteren Rand des Standard-Deckblatts (%s = Name)
+	CoverDate          = "cover.date"           // Datum auf dem Standard-Deckblatt (%s = Datum)
+	CodePart           = "code.part"            // Fortsetzungsmarkierung geteilter Code-Blöcke (%d/%d)
+	TableContinued     = "table.continued"      // Hinweis über fortgesetzten Tabellen
+	TableUnreadable    = "table.unreadable"     // Hinweis für nicht lesbare Datentabellen (%s = Datei)
+	TableInvalid       = "table.invalid"        // Hinweis für ungültige Angaben einer Datentabelle (%s = Fehler)
+	ChartShare         = "chart.share"          // Legendeneintrag eines Tortendiagramms (%s = Label, %s = Anteil)
+	ImageUnreadable    = "image.unreadable"     // Hinweis für nicht lesbare Bilder (%s = Datei)
+	DiagramMissingTool = "diagram.missing_tool" // Hinweis für nicht renderbare Diagramme (%s = Programm)
+)
+
+// locale beschreibt die Texte und Formate einer Sprache.
+type locale struct {
+	messages   map[string]string
+	months     [12]string // Monatsnamen (January ... December)
+	days       [7]string  // Wochentage, beginnend mit Sonntag
+	dateFormat string     // Standard-Datumsformat als Go-Layout
+	decimal    string     // Dezimaltrennzeichen
+	group      string     // Tausendertrennzeichen
+}
+
+// DefaultLanguage ist die Sprache, wenn keine angegeben ist.
+const DefaultLanguage = "de"
+
+// fallbackLanguage liefert die Texte für Sprachen ohne eigenen Katalog.
+const fallbackLanguage = "en"
+
+var locales = map[string]locale{
+	"de": {
+		messages: map[string]string{
+			TOCTitle:           "Inhaltsverzeichnis",
+			CoverAuthor:        "Autor: %s",
+			CoverCreatedBy:     "Erstellt von: %s",
+			CoverDate:          "Datum: %s",
+			CodePart:           "... (Teil %d/%d)",
+			TableContinued:     "(Fortsetzung)",
+			TableUnreadable:    "[Tabelle konnte nicht gelesen werden: %s]",
+			TableInvalid:       "[Tabelle ungültig: %s]",
+			ChartShare:         "%s (%s %%)",
+			ImageUnreadable:    "[Bild konnte nicht gelesen werden: %s]",
+			DiagramMissingTool: "[Diagramm konnte nicht gerendert werden - %s fehlt]",
+		},
+		months:     [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		days:       [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		dateFormat: "02.01.2006",
+		decimal:    ",",
+		group:      ".",
+	},
+	"en": {
+		messages: map[string]string{
+			TOCTitle:           "Contents",
+			CoverAuthor:        "Author: %s",
+			CoverCreatedBy:     "Created by: %s",
+			CoverDate:          "Date: %s",
+			CodePart:           "... (part %d/%d)",
+			TableContinued:     "(continued)",
+			TableUnreadable:    "[Table could not be read: %s]",
+			TableInvalid:       "[Invalid table: %s]",
+			ChartShare:         "%s (%s%%)",
+			ImageUnreadable:    "[Image could not be read: %s]",
+			DiagramMissingTool: "[Diagram could not be rendered - %s is missing]",
+		},
+		months:     [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		days:       [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dateFormat: "January 2, 2006",
+		decimal:    ".",
+		group:      ",",
+	},
+	"fr": {
+		messages: map[string]string{
+			TOCTitle:           "Table des matières",
+			CoverAuthor:        "Auteur : %s",
+			CoverCreatedBy:     "Créé par : %s",
+			CoverDate:          "Date : %s",
+			CodePart:           "... (partie %d/%d)",
+			TableContinued:     "(suite)",
+			TableUnreadable:    "[Impossible de lire le tableau : %s]",
+			TableInvalid:       "[Tableau invalide : %s]",
+			ChartShare:         "%s (%s %%)",
+			ImageUnreadable:    "[Impossible de lire l'image : %s]",
+			DiagramMissingTool: "[Impossible de générer le diagramme - %s manquant]",
+		},
+		months:     [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		days:       [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		dateFormat: "02/01/2006",
+		decimal:    ",",
+		group:      "\u00a0",
+	},
+	"es": {
+		messages: map[string]string{
+			TOCTitle:           "Índice",
+			CoverAuthor:        "Autor: %s",
+			CoverCreatedBy:     "Creado por: %s",
+			CoverDate:          "Fecha: %s",
+			CodePart:           "... (parte %d/%d)",
+			TableContinued:     "(continuación)",
+			TableUnreadable:    "[No se pudo leer la tabla: %s]",
+			TableInvalid:       "[Tabla no válida: %s]",
+			ChartShare:         "%s (%s %%)",
+			ImageUnreadable:    "[No se pudo leer la imagen: %s]",
+			DiagramMissingTool: "[No se pudo generar el diagrama - falta %s]",
+		},
+		months:     [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		days:       [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		dateFormat: "02/01/2006",
+		decimal:    ",",
+		group:      ".",
+	},
+}
+
+// Languages liefert die Sprachen mit eingebautem Katalog.
+func Languages() []string {
+	langs := make([]string, 0, len(locales))
+	for lang := range locales {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// baseLanguage kürzt eine Sprachangabe wie "de-CH" oder "en_US" auf die Sprache.
+func baseLanguage(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return DefaultLanguage
+	}
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
+
+// lookup liefert den Katalog einer Sprache, für unbekannte Sprachen den englischen.
+func lookup(lang string) (locale, bool) {
+	l, ok := locales[baseLanguage(lang)]
+	if !ok {
+		return locales[fallbackLanguage], false
+	}
+	return l, true
+}
+
+// Known prüft, ob für die Sprache ein eingebauter Katalog existiert.
+func Known(lang string) bool {
+	_, ok := lookup(lang)
+	return ok
+}
+
+// DefaultDateFormat liefert das übliche Datumsformat einer Sprache als Go-Layout.
+func DefaultDateFormat(lang string) string {
+	l, _ := lookup(lang)
+	return l.dateFormat
+}
+
+// Catalog übersetzt die Texte eines Dokuments in dessen Sprache.
+type Catalog struct {
+	locale    locale
+	overrides map[string]string
+	names     *strings.Replacer
+}
+
+// New erstellt den Katalog für eine Sprache. overrides ersetzt einzelne Texte (Schlüssel wie toc.title).
+func New(lang string, overrides map[string]string) *Catalog {
+	l, _ := lookup(lang)
+	en := locales["en"]
+
+	// Englische Monats- und Tagesnamen aus time.Format durch die der Sprache ersetzen.
+	// Volle Namen stehen vor den Abkürzungen, damit "January" nicht als "Jan" ersetzt wird.
+	var pairs []string
+	for i, name := range en.months {
+		pairs = append(pairs, name, l.months[i])
+	}
+	for i, name := range en.days {
+		pairs = append(pairs, name, l.days[i])
+	}
+	for i, name := range en.months {
+		pairs = append(pairs, name[:3], abbreviate(l.months[i]))
+	}
+	for i, name := range en.days {
+		pairs = append(pairs, name[:3], abbreviate(l.days[i]))
+	}
+	return &Catalog{locale: l, overrides: overrides, names: strings.NewReplacer(pairs...)}
+}
+
+// abbreviate kürzt einen Monats- oder Tagesnamen auf drei Buchstaben.
+func abbreviate(name string) string {
+	runes := []rune(name)
+	if len(runes) <= 3 {
+		return name
+	}
+	return string(runes[:3])
+}
+
+// T liefert den übersetzten Text zu key. Mit Argumenten wird er wie bei fmt.Sprintf formatiert.
+func (c *Catalog) T(key string, args ...any) string {
+	msg, ok := c.overrides[key]
+	if !ok {
+		msg, ok = c.locale.messages[key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// FormatDate formatiert t mit einem Go-Layout und übersetzt dabei Monats- und Tagesnamen.
+func (c *Catalog) FormatDate(t time.Time, layout string) string {
+	return c.names.Replace(t.Format(layout))
+}
+
+// FormatFloat formatiert eine Zahl mit festen Nachkommastellen und dem Dezimaltrennzeichen der Sprache.
+func (c *Catalog) FormatFloat(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if c.locale.decimal != "." {
+		s = strings.Replace(s, ".", c.locale.decimal, 1)
+	}
+	return s
+}
+
+// FormatNumber formatiert eine Zahl wie FormatFloat, mit grouping zusätzlich mit Tausendertrennzeichen.
+func (c *Catalog) FormatNumber(v float64, decimals int, grouping bool) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, hasFrac := strings.Cut(s, ".")
+	if grouping {
+		var b strings.Builder
+		for i, d := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				b.WriteString(c.locale.group)
+			}
+			b.WriteRune(d)
+		}
+		intPart = b.String()
+	}
+	if hasFrac {
+		return sign + intPart + c.locale.decimal + frac
+	}
+	return sign + intPart
+}
@@ -140,6 +140,7 @@ func (m InitModel) finishInit() tea.Cmd {
 		configContent := fmt.Sprintf(`title: "Projektdokumentation"
 subtitle: "Betriebliche Projektarbeit"
 author: "Dein Name"
+language: "de"
 header:
   text: "Abschlussprüfung Sommer 2026"
 footer:
@@ -27,6 +27,23 @@ func RunCommand(name string, args ...string) (string, error) {
 	return stdout.String(), nil
 }
 
+// RunCommandWithInput führt einen externen Systembefehl aus, übergibt input über Stdin
+// und gibt die Standardausgabe zurück.
+func RunCommandWithInput(input string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("Befehl fehlgeschlagen: %s %v\nFehler: %w\nStderr: %s", name, args, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
 // OpenPath öffnet einen Pfad mit dem Standardprogramm des Betriebssystems.
 func OpenPath(path string) error {
 	var cmd *exec.Cmd
@@ -0,0 +1,37 @@
+package util
+
+import "strings"
+
+// GitInfo beschreibt den Stand eines Git-Repositories zum Zeitpunkt des Builds.
+type GitInfo struct {
+	Commit      string // Vollständiger Commit-Hash
+	ShortCommit string // Gekürzter Commit-Hash (7 Zeichen)
+	Tag         string // Letzter erreichbarer Tag (leer, wenn keiner existiert)
+	Branch      string // Aktueller Branch
+	Dirty       bool   // Es gibt nicht eingecheckte Änderungen
+}
+
+// ReadGitInfo liest Commit, Tag und Branch des Repositories, in dem dir liegt.
+// Ist Git nicht installiert oder dir kein Repository, bleiben alle Felder leer.
+func ReadGitInfo(dir string) GitInfo {
+	git := func(args ...string) string {
+		out, err := RunCommand("git", append([]string{"-C", dir}, args...)...)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(out)
+	}
+
+	info := GitInfo{Commit: git("rev-parse", "HEAD")}
+	if info.Commit == "" {
+		return GitInfo{}
+	}
+	info.ShortCommit = info.Commit
+	if len(info.ShortCommit) > 7 {
+		info.ShortCommit = info.ShortCommit[:7]
+	}
+	info.Tag = git("describe", "--tags", "--abbrev=0")
+	info.Branch = git("rev-parse", "--abbrev-ref", "HEAD")
+	info.Dirty = git("status", "--porcelain") != ""
+	return info
+}
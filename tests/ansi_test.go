@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"godocgen/internal/engine/code"
+	"testing"
+)
+
+func TestParseANSIColors(t *testing.T) {
+	input := "plain \x1b[1;31mred\x1b[0m \x1b[38;5;208morange\x1b[39m \x1b[48;2;10;20;30;4mtrue\x1b[m"
+	segments := code.ParseANSI(input)
+
+	expected := []code.Segment{
+		{Text: "plain "},
+		{Text: "red", Color: "#cd3131", Bold: true},
+		{Text: " "},
+		{Text: "orange", Color: "#ff8700"},
+		{Text: " "},
+		{Text: "true", Background: "#0a141e", Underline: true},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %d: %+v", len(expected), len(segments), segments)
+	}
+	for i, seg := range segments {
+		if seg != expected[i] {
+			t.Errorf("Segment %d: expected %+v, got %+v", i, expected[i], seg)
+		}
+	}
+}
+
+func TestParseANSITextualEscapes(t *testing.T) {
+	segments := code.ParseANSI(`\e[32mok\e[0m done\e[2K`)
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %d: %+v", len(segments), segments)
+	}
+	if segments[0].Text != "ok" || segments[0].Color != "#0dbc79" {
+		t.Errorf("Unexpected first segment: %+v", segments[0])
+	}
+	if segments[1].Text != " done" {
+		t.Errorf("Control sequence not stripped: %q", segments[1].Text)
+	}
+}
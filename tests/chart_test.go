@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"testing"
+)
+
+func TestChartFenceCSVAndYAML(t *testing.T) {
+	src := "```chart {type=line title=\"Latenz\" unit=ms}\nTag;p50;p95\nMo;12;40\nDi;14,5;38\n```\n\n" +
+		"```chart\ntype: stacked\nlabels: [A, B]\nseries:\n  - name: X\n    values: [1, 2]\n```\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(blks))
+	}
+
+	csvChart, ok := blks[0].(blocks.ChartBlock)
+	if !ok {
+		t.Fatalf("Expected ChartBlock, got %T", blks[0])
+	}
+	if csvChart.Type != "line" || csvChart.Title != "Latenz" || csvChart.Unit != "ms" {
+		t.Errorf("Attributes not applied: %+v", csvChart)
+	}
+	if len(csvChart.Labels) != 2 || len(csvChart.Series) != 2 || csvChart.Series[0].Values[1] != 14.5 {
+		t.Errorf("CSV data not parsed: %+v", csvChart)
+	}
+
+	yamlChart := blks[1].(blocks.ChartBlock)
+	if yamlChart.Type != "stacked" || yamlChart.Series[0].Name != "X" || len(yamlChart.Series[0].Values) != 2 {
+		t.Errorf("YAML data not parsed: %+v", yamlChart)
+	}
+}
+
+func TestChartCSVThousandsSeparators(t *testing.T) {
+	src := "```chart\nMonat;Umsatz;Kosten\nJan;1.234,5;1.000.000\nFeb;\"1,234.5\";1 234,25\n```\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chart, ok := blks[0].(blocks.ChartBlock)
+	if !ok {
+		t.Fatalf("Expected ChartBlock, got %T", blks[0])
+	}
+	want := [][]float64{{1234.5, 1234.5}, {1000000, 1234.25}}
+	for i, values := range want {
+		for j, v := range values {
+			if got := chart.Series[i].Values[j]; got != v {
+				t.Errorf("%s[%d] = %v, erwartet %v", chart.Series[i].Name, j, got, v)
+			}
+		}
+	}
+}
+
+func TestChartFenceInvalidType(t *testing.T) {
+	blks, err := markdown.Parse([]byte("```chart {type=donut}\na,b\nx,1\n```\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 1 {
+		t.Fatalf("Expected 1 block, got %d", len(blks))
+	}
+	if _, ok := blks[0].(blocks.ParagraphBlock); !ok {
+		t.Errorf("Expected placeholder paragraph, got %T", blks[0])
+	}
+}
@@ -0,0 +1,61 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"strings"
+	"testing"
+)
+
+func TestColumnDirectives(t *testing.T) {
+	src := "<!-- columns 3 gap=6 rule=false -->\n\nText\n\n<!-- /columns -->\n\n<!-- columns -->\n\n<!-- end-columns -->\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cols []blocks.ColumnsBlock
+	for _, b := range blks {
+		if c, ok := b.(blocks.ColumnsBlock); ok {
+			cols = append(cols, c)
+		}
+	}
+	if len(cols) != 4 {
+		t.Fatalf("Expected 4 column directives, got %d", len(cols))
+	}
+	if cols[0].Count != 3 || cols[0].Gap != 6 || cols[0].Rule == nil || *cols[0].Rule {
+		t.Errorf("Unexpected first directive: %+v", cols[0])
+	}
+	if cols[1].Count != 1 || cols[3].Count != 1 {
+		t.Errorf("End directives should reset to one column: %+v / %+v", cols[1], cols[3])
+	}
+	if cols[2].Count != 2 || cols[2].Rule != nil {
+		t.Errorf("Default directive should use two columns and the configured rule: %+v", cols[2])
+	}
+}
+
+func TestColumnsFlowBeforeNewPage(t *testing.T) {
+	content := `
+title: "Spalten"
+layout:
+  columns:
+    gap: 10
+    rule: true
+`
+
+	// Mehr als eine Spalte füllt: ohne Spaltenwechsel entstünde eine weitere Seite
+	text := strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 6)
+	blks := []blocks.DocBlock{
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel"},
+		blocks.ColumnsBlock{Count: 2},
+	}
+	for i := 0; i < 8; i++ {
+		blks = append(blks, blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: text}}})
+	}
+	blks = append(blks, blocks.ColumnsBlock{Count: 1})
+
+	out := renderPDF(t, content, blks...)
+	// Deckblatt und eine zweispaltige Inhaltsseite
+	if n := countPages(t, out); n != 2 {
+		t.Errorf("Expected 2 pages, got %d", n)
+	}
+}
@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/pdf"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCoverPages(t *testing.T) {
+	content := `
+title: "Handbuch"
+cover:
+  version: "2.1"
+  classification: "Vertraulich"
+  color: "#1e1e2e"
+  fields:
+    - text: "{{.Title}}"
+      y: 80
+      size: 36
+      style: B
+    - text: "{{with .Version}}Version {{.}}{{end}}"
+      y: -40
+    - text: "{{.Classification | upper}}"
+      x: -60
+      y: -30
+      width: 40
+      align: right
+  inside:
+    fields:
+      - text: "Innenseite"
+        y: 30
+  back:
+    fields:
+      - text: "Rückseite"
+        y: -20
+`
+
+	blks := []blocks.DocBlock{
+		blocks.PageBreakBlock{},
+		blocks.HeadingBlock{Level: 1, Text: "Einleitung"},
+		blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Inhalt"}}},
+	}
+	out := renderPDF(t, content, blks...)
+
+	streams := pageStreams(t, out)
+	if len(streams) != 4 {
+		t.Fatalf("Expected 4 pages with text, got %d", len(streams))
+	}
+	for _, want := range []string{"(Handbuch)", "(Version 2.1)", "(VERTRAULICH)"} {
+		if !strings.Contains(streams[0], want) {
+			t.Errorf("Deckblatt: Feld %s fehlt", want)
+		}
+	}
+	if !strings.Contains(streams[1], "(Innenseite)") {
+		t.Error("Innenseite des Umschlags fehlt")
+	}
+	if !strings.Contains(streams[3], "(R\xfcckseite)") {
+		t.Error("Rückseite des Umschlags fehlt")
+	}
+
+	// Umschlagseiten tragen keine Seitenzahl
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "/Nums [0 << >> 2 << /S /D >> 3 << >>]") {
+		t.Error("Seitenbeschriftungen der Umschlagseiten fehlen")
+	}
+}
+
+func TestCoverFieldTemplateError(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Cover.Fields = []config.CoverField{{Text: "{{.Title"}}
+	gen := pdf.NewGenerator(cfg, nil, t.TempDir())
+	err := gen.Generate(filepath.Join(t.TempDir(), "out.pdf"))
+	if err == nil || !strings.Contains(err.Error(), "cover.fields[0]") {
+		t.Errorf("Expected template error for cover.fields[0], got %v", err)
+	}
+}
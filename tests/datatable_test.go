@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/datatable"
+	"godocgen/internal/engine/markdown"
+	"godocgen/internal/i18n"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// tableTexts liefert den Text aller Zellen einer Tabelle.
+func tableTexts(table blocks.TableBlock) [][]string {
+	var rows [][]string
+	for _, row := range table.Rows {
+		var cells []string
+		for _, cell := range row {
+			text := ""
+			for _, seg := range cell.Content {
+				text += seg.Text
+			}
+			cells = append(cells, text)
+		}
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+func TestDataTableDirective(t *testing.T) {
+	md := "```table {src=data/api.csv columns=name,price sort=-since filter=\"status=stable; since>=2\" headers=\"name:Feld\"}\n```\n\n" +
+		"```table\nsrc: data/api.json\nformat:\n  price: \"#,##0.00\"\n```\n"
+	blks, err := markdown.Parse([]byte(md), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(blks))
+	}
+	want := blocks.DataTableBlock{
+		Src:     "data/api.csv",
+		Columns: []string{"name", "price"},
+		Headers: map[string]string{"name": "Feld"},
+		Sort:    []string{"-since"},
+		Filter:  []string{"status=stable", "since>=2"},
+	}
+	if got, ok := blks[0].(blocks.DataTableBlock); !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("Attribute = %#v", blks[0])
+	}
+	if got, ok := blks[1].(blocks.DataTableBlock); !ok || got.Src != "data/api.json" || got.Format["price"] != "#,##0.00" {
+		t.Errorf("YAML = %#v", blks[1])
+	}
+
+	// Ohne src reicht der Parser den Fehler weiter, der Builder setzt den übersetzten Hinweis
+	blks, err = markdown.Parse([]byte("```table {columns=a}\n```\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := blks[0].(blocks.DataTableBlock); !ok || got.Error == "" {
+		t.Errorf("Expected table with error, got %#v", blks[0])
+	}
+	if got := i18n.New("en", nil).T(i18n.TableInvalid, "src fehlt"); got != "[Invalid table: src fehlt]" {
+		t.Errorf("table.invalid = %q", got)
+	}
+}
+
+func TestDataTableFromCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.csv")
+	csv := "name;since;status;price\nAlpha;3;stable;1234.5\nBeta;1;beta;12\nGamma;2;stable;0.256\nDelta;10;stable;1000000\n"
+	if err := os.WriteFile(path, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := datatable.Build(blocks.DataTableBlock{
+		Src:     path,
+		Columns: []string{"name", "since", "price"},
+		Headers: map[string]string{"name": "Feld", "price": "Preis"},
+		Sort:    []string{"-since"},
+		Filter:  []string{"status=stable", "since>=2"},
+		Format:  map[string]string{"price": "#,##0.00 €"},
+	}, path, i18n.New("de", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"Feld", "since", "Preis"},
+		{"Delta", "10", "1.000.000,00 €"},
+		{"Alpha", "3", "1.234,50 €"},
+		{"Gamma", "2", "0,26 €"},
+	}
+	if got := tableTexts(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tabelle = %v, want %v", got, want)
+	}
+	if !table.Rows[0][0].Header || table.Rows[1][0].Header {
+		t.Error("Nur die erste Zeile ist Kopfzeile")
+	}
+	if want := []blocks.Align{blocks.AlignLeft, blocks.AlignRight, blocks.AlignRight}; !reflect.DeepEqual(table.Alignments, want) {
+		t.Errorf("Ausrichtung = %v, want %v", table.Alignments, want)
+	}
+
+	// Tippfehler in Spaltennamen fallen auf
+	if _, err := datatable.Build(blocks.DataTableBlock{Src: path, Sort: []string{"nmae"}}, path, i18n.New("de", nil)); err == nil {
+		t.Error("Expected error for unknown column")
+	}
+}
+
+func TestDataTableFromJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api.json")
+	data := `[{"name": "x", "share": 0.125, "tags": ["a", "b"]}, {"name": "y", "share": null, "new": true}]`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := datatable.Build(blocks.DataTableBlock{
+		Src:    path,
+		Format: map[string]string{"share": "0.0%"},
+	}, path, i18n.New("en", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		{"name", "share", "tags", "new"},
+		{"x", "12.5%", `["a","b"]`, ""},
+		{"y", "", "", "true"},
+	}
+	if got := tableTexts(table); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tabelle = %v, want %v", got, want)
+	}
+}
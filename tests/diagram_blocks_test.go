@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"testing"
+)
+
+func TestMermaidInfoAttributes(t *testing.T) {
+	src := "```mermaid {width=120 scale=0.7 theme=neutral title=\"Ablauf\"}\ngraph TD; A-->B;\n```\n\n```mermaid {Nur ein Titel}\ngraph TD; A-->C;\n```\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(blks))
+	}
+
+	withAttrs, ok := blks[0].(blocks.DiagramBlock)
+	if !ok {
+		t.Fatalf("Expected DiagramBlock, got %T", blks[0])
+	}
+	if withAttrs.Width != 120 || withAttrs.Scale != 0.7 || withAttrs.Theme != "neutral" || withAttrs.Title != "Ablauf" {
+		t.Errorf("Attributes not parsed: %+v", withAttrs)
+	}
+
+	titleOnly := blks[1].(blocks.DiagramBlock)
+	if titleOnly.Title != "Nur ein Titel" || titleOnly.Width != 0 || titleOnly.Theme != "" {
+		t.Errorf("Plain title not preserved: %+v", titleOnly)
+	}
+}
+
+func TestDiagramFenceLanguages(t *testing.T) {
+	src := "```dot {layout=neato}\ndigraph { a -> b }\n```\n\n```puml\nAlice -> Bob\n```\n\n```go\nfunc main() {}\n```\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 3 {
+		t.Fatalf("Expected 3 blocks, got %d", len(blks))
+	}
+
+	dot, ok := blks[0].(blocks.DiagramBlock)
+	if !ok || dot.Language != "dot" || dot.Attributes["layout"] != "neato" {
+		t.Errorf("Expected dot DiagramBlock with layout attribute, got %+v", blks[0])
+	}
+	if puml, ok := blks[1].(blocks.DiagramBlock); !ok || puml.Language != "puml" {
+		t.Errorf("Expected puml DiagramBlock, got %+v", blks[1])
+	}
+	if _, ok := blks[2].(blocks.CodeBlock); !ok {
+		t.Errorf("Expected CodeBlock for go, got %T", blks[2])
+	}
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"bytes"
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/pdf"
+	"os"
+	"testing"
+)
+
+// countPages zählt die Seitenobjekte eines von gofpdf erzeugten PDFs.
+func countPages(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Count(data, []byte("/Type /Page\n"))
+}
+
+func TestDuplexChaptersStartOnRightPages(t *testing.T) {
+	content := `
+title: "Handbuch"
+layout:
+  duplex: true
+  margins:
+    inner: 30
+    outer: 15
+`
+	cfg, dir := loadTestConfig(t, content)
+
+	left, right := cfg.Layout.Margins.Horizontal(true, 3)
+	if left != 30 || right != 15 {
+		t.Errorf("Rechte Seite: Ränder %g/%g, erwartet 30/15", left, right)
+	}
+	left, right = cfg.Layout.Margins.Horizontal(true, 4)
+	if left != 15 || right != 30 {
+		t.Errorf("Linke Seite: Ränder %g/%g, erwartet 15/30", left, right)
+	}
+
+	paragraph := blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Inhalt"}}}
+	blks := []blocks.DocBlock{
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel 1"},
+		paragraph,
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel 2"},
+		paragraph,
+	}
+	out := generatePDF(t, pdf.NewGenerator(cfg, blks, dir), dir)
+
+	// Deckblatt, leere Rückseite, Kapitel 1, leere Rückseite, Kapitel 2
+	if n := countPages(t, out); n != 5 {
+		t.Errorf("Expected 5 pages, got %d", n)
+	}
+}
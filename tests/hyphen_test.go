@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/hyphen"
+	"godocgen/internal/engine/markdown"
+	"godocgen/internal/engine/pdf"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// showHyphens macht weiche Trennzeichen für Vergleiche sichtbar.
+func showHyphens(s string) string {
+	return strings.ReplaceAll(s, hyphen.SoftHyphen, "-")
+}
+
+func TestHyphenator(t *testing.T) {
+	de, err := hyphen.ForLanguage("de-AT")
+	if err != nil || de == nil {
+		t.Fatalf("Deutsche Muster fehlen: %v", err)
+	}
+	en, err := hyphen.ForLanguage("en")
+	if err != nil || en == nil {
+		t.Fatalf("Englische Muster fehlen: %v", err)
+	}
+
+	tests := []struct {
+		h    *hyphen.Hyphenator
+		word string
+		want string
+	}{
+		{de, "Silbentrennung", "Sil-ben-tren-nung"},
+		{de, "Rechtschreibreform", "Recht-schreib-re-form"},
+		{en, "hyphenation", "hy-phen-a-tion"},
+		{en, "typesetting", "type-set-ting"},
+		{en, "associate", "as-so-ciate"}, // Ausnahme aus \hyphenation{}
+	}
+	for _, tt := range tests {
+		if got := showHyphens(tt.h.Hyphenate(tt.word)); got != tt.want {
+			t.Errorf("Hyphenate(%q) = %q, want %q", tt.word, got, tt.want)
+		}
+	}
+
+	de.AddException("Ur-in-stinkt")
+	if got := showHyphens(de.Hyphenate("Urinstinkt")); got != "Ur-in-stinkt" {
+		t.Errorf("Ausnahme = %q", got)
+	}
+
+	// Kurze Wörter, Abkürzungen, URLs und manuell getrennte Wörter bleiben unverändert
+	text := "Die API unter https://example.com/silbentrennung nutzt Ge" + hyphen.SoftHyphen + "schäftsprozesse (Silbentrennung)."
+	want := "Die API unter https://example.com/silbentrennung nutzt Ge-schäftsprozesse (Sil-ben-tren-nung)."
+	if got := showHyphens(de.HyphenateText(text, 6)); got != want {
+		t.Errorf("HyphenateText = %q, want %q", got, want)
+	}
+
+	if h, _ := hyphen.ForLanguage("pl"); h != nil {
+		t.Error("Für Polnisch sind keine Muster eingebettet")
+	}
+}
+
+func TestParsePatterns(t *testing.T) {
+	h, err := hyphen.Parse(strings.NewReader(`% Eigene Muster
+\patterns{
+1ba 1ca
+}
+\hyphenation{
+aa-bb-cc
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := showHyphens(h.Hyphenate("abacab")); got != "aba-cab" {
+		t.Errorf("Hyphenate = %q", got)
+	}
+	if got := showHyphens(h.Hyphenate("aabbcc")); got != "aa-bb-cc" {
+		t.Errorf("Ausnahme = %q", got)
+	}
+
+	if _, err := hyphen.Parse(strings.NewReader(`\patterns{ a1+b }`)); err == nil {
+		t.Error("Ungültiges Muster wurde akzeptiert")
+	}
+}
+
+func TestManualSoftHyphen(t *testing.T) {
+	blks, err := markdown.Parse([]byte("Ur&shy;instinkt und `co&shy;de`\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := blks[0].(blocks.ParagraphBlock)
+	if p.Content[0].Text != "Ur"+hyphen.SoftHyphen+"instinkt und " {
+		t.Errorf("Text = %q", p.Content[0].Text)
+	}
+	if p.Content[1].Text != "co&shy;de" {
+		t.Errorf("Code = %q", p.Content[1].Text)
+	}
+}
+
+func TestHyphenationInPDF(t *testing.T) {
+	content := `
+title: "Trennung"
+layout:
+  body: "justify"
+  hyphenation:
+    enabled: true
+`
+	cfg, dir := loadTestConfig(t, content)
+	if cfg.Layout.Hyphenation.MinWord != 5 {
+		t.Errorf("MinWord = %d, want 5", cfg.Layout.Hyphenation.MinWord)
+	}
+
+	text := strings.Repeat("Donaudampfschiffahrtsgesellschaft Versicherungsunternehmen ", 12)
+	blks := []blocks.DocBlock{
+		blocks.PageBreakBlock{},
+		blocks.HeadingBlock{Level: 1, Text: "Donaudampfschiffahrtsgesellschaft"},
+		blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: text}}},
+	}
+	out := generatePDF(t, pdf.NewGenerator(cfg, blks, dir), dir)
+
+	all := strings.Join(pageStreams(t, out), "\n")
+	if !regexp.MustCompile(`[a-z]-\)Tj`).MatchString(all) {
+		t.Error("Keine Zeile endet mit einem Trennstrich")
+	}
+	if strings.Contains(all, "\xad") {
+		t.Error("Weiches Trennzeichen wurde gedruckt")
+	}
+	if !strings.Contains(all, "Donaudampfschiffahrtsgesellschaft)") {
+		t.Error("Überschrift wurde getrennt")
+	}
+}
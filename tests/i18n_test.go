@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/pdf"
+	"godocgen/internal/i18n"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatalogFormats(t *testing.T) {
+	date := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		lang   string
+		layout string
+		want   string
+	}{
+		{"de", "2. January 2006", "14. März 2026"},
+		{"fr-CA", "Monday 2 Jan 2006", "samedi 14 mar 2026"},
+		{"en", "January 2, 2006", "March 14, 2026"},
+		{"", "Mon, 02.01.2006", "Sam, 14.03.2026"},
+	}
+	for _, tt := range tests {
+		if got := i18n.New(tt.lang, nil).FormatDate(date, tt.layout); got != tt.want {
+			t.Errorf("FormatDate(%q, %q) = %q, want %q", tt.lang, tt.layout, got, tt.want)
+		}
+	}
+
+	if got := i18n.New("de", nil).FormatFloat(12.5, 1); got != "12,5" {
+		t.Errorf("FormatFloat(de) = %q, want 12,5", got)
+	}
+	if got := i18n.New("en", nil).FormatFloat(12.5, 1); got != "12.5" {
+		t.Errorf("FormatFloat(en) = %q, want 12.5", got)
+	}
+	if got := i18n.New("de", nil).FormatNumber(-1234567.891, 2, true); got != "-1.234.567,89" {
+		t.Errorf("FormatNumber(de) = %q, want -1.234.567,89", got)
+	}
+	if got := i18n.New("en", nil).FormatNumber(1234, 0, true); got != "1,234" {
+		t.Errorf("FormatNumber(en) = %q, want 1,234", got)
+	}
+
+	// Unbekannte Sprachen verwenden die englischen Texte, eigene Übersetzungen haben Vorrang
+	catalog := i18n.New("pl", config.Messages{i18n.TOCTitle: "Spis treści"})
+	if got := catalog.T(i18n.TOCTitle); got != "Spis treści" {
+		t.Errorf("Override = %q", got)
+	}
+	if got := catalog.T(i18n.CoverAuthor, "Anna"); got != "Author: Anna" {
+		t.Errorf("Fallback = %q", got)
+	}
+}
+
+func TestDocumentLanguage(t *testing.T) {
+	content := `
+title: "Manual"
+author: "Jane Doe"
+language: "en"
+toc:
+  enabled: true
+translations:
+  cover.created_by: "Prepared by %s"
+`
+	cfg, dir := loadTestConfig(t, content)
+	if cfg.DateFormat != "January 2, 2006" {
+		t.Errorf("Expected English date format, got %q", cfg.DateFormat)
+	}
+
+	blks := []blocks.DocBlock{
+		blocks.PageBreakBlock{},
+		blocks.HeadingBlock{Level: 1, Text: "Introduction"},
+		blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Text"}}},
+	}
+	gen := pdf.NewGenerator(cfg, blks, dir)
+	gen.SetBuildInfo(pdf.BuildInfo{Time: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)})
+	out := generatePDF(t, gen, dir)
+
+	streams := pageStreams(t, out)
+	all := strings.Join(streams, "\n")
+	for _, want := range []string{"(Author: Jane Doe)", "(Prepared by Jane Doe)", "(Date: March 14, 2026)", "(Contents)"} {
+		if !strings.Contains(all, want) {
+			t.Errorf("Text %s fehlt", want)
+		}
+	}
+	if strings.Contains(all, "Inhaltsverzeichnis") {
+		t.Error("Deutscher Text in englischem Dokument")
+	}
+}
@@ -0,0 +1,238 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/images"
+	"godocgen/internal/engine/markdown"
+	"godocgen/internal/engine/pdf"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/bmp"
+)
+
+func writeTestImage(t *testing.T, path string, img image.Image, encode func(*os.File, image.Image) error) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNormalizeConvertsUnsupportedImages(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+
+	// 16-Bit-PNG muss auf 8 Bit umgewandelt werden
+	deep := image.NewRGBA64(image.Rect(0, 0, 4, 4))
+	deep.Set(1, 1, color.RGBA64{R: 0xffff, A: 0xffff})
+	deepPath := filepath.Join(dir, "deep.png")
+	writeTestImage(t, deepPath, deep, func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+
+	converted, err := images.Normalize(deepPath, cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted == deepPath || filepath.Dir(converted) != filepath.Join(cacheDir, "images") {
+		t.Fatalf("Expected cached conversion, got %s", converted)
+	}
+	data, _ := os.ReadFile(converted)
+	if data[24] != 8 {
+		t.Errorf("Expected 8-bit PNG, got bit depth %d", data[24])
+	}
+
+	// Gleicher Inhalt liefert dieselbe Cache-Datei
+	again, err := images.Normalize(deepPath, cacheDir)
+	if err != nil || again != converted {
+		t.Errorf("Expected cache hit %s, got %s (%v)", converted, again, err)
+	}
+
+	// BMP wird zu PNG
+	bmpPath := filepath.Join(dir, "icon.bmp")
+	writeTestImage(t, bmpPath, image.NewRGBA(image.Rect(0, 0, 2, 2)), func(f *os.File, img image.Image) error { return bmp.Encode(f, img) })
+	bmpConverted, err := images.Normalize(bmpPath, cacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Ext(bmpConverted) != ".png" {
+		t.Errorf("Expected BMP to become PNG, got %s", bmpConverted)
+	}
+}
+
+func TestNormalizeKeepsSupportedImages(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "plain.png")
+	writeTestImage(t, plain, image.NewNRGBA(image.Rect(0, 0, 2, 2)), func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+
+	path, err := images.Normalize(plain, filepath.Join(dir, "cache"))
+	if err != nil || path != plain {
+		t.Errorf("Expected unchanged path, got %s (%v)", path, err)
+	}
+
+	broken := filepath.Join(dir, "broken.webp")
+	os.WriteFile(broken, []byte("not an image"), 0644)
+	if _, err := images.Normalize(broken, filepath.Join(dir, "cache")); err == nil {
+		t.Error("Expected error for unreadable image")
+	}
+}
+
+func TestStandaloneImageParagraph(t *testing.T) {
+	src := "![Logo](logo.webp \"Firmenlogo\")\n\nText mit ![Icon](icon.png) im Satz.\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(blks))
+	}
+	img, ok := blks[0].(blocks.ImageBlock)
+	if !ok {
+		t.Fatalf("Expected ImageBlock, got %T", blks[0])
+	}
+	if img.Path != "logo.webp" || img.Alt != "Logo" || img.Title != "Firmenlogo" {
+		t.Errorf("Unexpected image block: %+v", img)
+	}
+	if _, ok := blks[1].(blocks.ParagraphBlock); !ok {
+		t.Errorf("Inline image should stay part of the paragraph, got %T", blks[1])
+	}
+}
+
+func TestImageAttributes(t *testing.T) {
+	src := "![Logo](logo.png){width=40% align=right border=false}\n\n![Foto](foto.jpg \"Titel\"){float=left width=50mm height=30}\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Expected 2 blocks, got %d", len(blks))
+	}
+	logo := blks[0].(blocks.ImageBlock)
+	if logo.WidthPercent != 40 || logo.Align != "right" || !logo.NoBorder || logo.Float != "" {
+		t.Errorf("Unexpected logo attributes: %+v", logo)
+	}
+	foto := blks[1].(blocks.ImageBlock)
+	if foto.Float != "left" || foto.Width != 50 || foto.Height != 30 || foto.Title != "Titel" || foto.NoBorder {
+		t.Errorf("Unexpected foto attributes: %+v", foto)
+	}
+
+	// Platzierte Größe: 40% der Textbreite (A4 mit 20 mm Rändern = 170 mm)
+	cfg := &config.Config{}
+	cfg.Layout.Margins.Left, cfg.Layout.Margins.Right = 20, 20
+	cfg.Layout.Margins.Top, cfg.Layout.Margins.Bottom = 20, 20
+	w, h := pdf.PlacedImageSize(cfg, logo, 400, 200, false)
+	if w != 68 || h != 34 {
+		t.Errorf("Placed size = %.1fx%.1f, want 68x34", w, h)
+	}
+	// Nur Höhe: Breite folgt dem Seitenverhältnis
+	w, h = pdf.PlacedImageSize(cfg, blocks.ImageBlock{Height: 30}, 400, 200, false)
+	if w != 60 || h != 30 {
+		t.Errorf("Placed size = %.1fx%.1f, want 60x30", w, h)
+	}
+}
+
+func TestFloatEndsBelowImage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foto.png")
+	writeTestImage(t, path, image.NewRGBA(image.Rect(0, 0, 200, 120)), func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+
+	// Ein Absatz, der weit über das Bild hinaus bis auf die nächste Seite läuft
+	text := strings.Repeat("Dieser Absatz fliesst um das Bild herum und danach weiter. ", 100)
+	pages := renderPages(t, "", 0,
+		blocks.ImageBlock{Path: path, Float: "left", Width: 50, Height: 30},
+		blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: text}}},
+		blocks.ListBlock{Items: []blocks.ListItem{{Content: []blocks.TextSegment{{Text: "Punkt"}}}}})
+	if len(pages) < 2 {
+		t.Fatalf("Absatz sollte auf eine zweite Seite laufen, %d Seite(n)", len(pages))
+	}
+
+	re := regexp.MustCompile(`BT ([\d.]+) ([\d.]+) Td \(([^)]*)\)Tj`)
+	lineX := func(page string) []float64 {
+		var xs []float64
+		for _, m := range re.FindAllStringSubmatch(page, -1) {
+			if strings.Contains(m[3], "Absatz") || strings.Contains(m[3], "Punkt") {
+				x, _ := strconv.ParseFloat(m[1], 64)
+				xs = append(xs, x)
+			}
+		}
+		return xs
+	}
+
+	// Erste Seite: zuerst schmale Zeilen neben dem Bild, darunter volle Zeilen am linken Rand
+	first := lineX(pages[0])
+	margin := first[len(first)-1]
+	narrow := 0
+	for narrow < len(first) && first[narrow] > margin+100 {
+		narrow++
+	}
+	if narrow == 0 || narrow == len(first) {
+		t.Fatalf("Erwartet schmale und volle Zeilen, Zeilenanfänge: %v", first)
+	}
+	for i, x := range first[narrow:] {
+		if x != margin {
+			t.Errorf("Zeile %d unter dem Bild beginnt bei %.2f statt %.2f", narrow+i, x, margin)
+		}
+	}
+
+	// Zweite Seite und folgende Liste: kein verschobener Rand mehr
+	for i, x := range lineX(pages[1]) {
+		if x != margin {
+			t.Errorf("Zeile %d auf Seite 2 beginnt bei %.2f statt %.2f", i, x, margin)
+		}
+	}
+}
+
+func TestOptimizeDownsamplesToTargetDPI(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+
+	// 4000px breites Bild mit Verlauf (damit PNG nicht trivial komprimiert)
+	big := image.NewRGBA(image.Rect(0, 0, 4000, 1000))
+	for y := 0; y < 1000; y++ {
+		for x := 0; x < 4000; x++ {
+			big.Set(x, y, color.RGBA{R: uint8(x * 7), G: uint8(y * 3), B: uint8(x ^ y), A: 255})
+		}
+	}
+	path := filepath.Join(dir, "big.png")
+	writeTestImage(t, path, big, func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+
+	// 127 mm bei 100 DPI = 500 px
+	res, err := images.Optimize(path, cacheDir, 127, images.OptimizeOptions{MaxDPI: 100})
+	if err != nil {
+		t.Fatalf("Optimize: %v", err)
+	}
+	if !res.Changed() {
+		t.Fatalf("Bild wurde nicht optimiert: %+v", res)
+	}
+	if res.NewW != 500 || res.NewH != 125 {
+		t.Errorf("Neue Größe = %dx%d, erwartet 500x125", res.NewW, res.NewH)
+	}
+	if w, h, err := images.Dimensions(res.Path); err != nil || w != 500 || h != 125 {
+		t.Errorf("Datei hat %dx%d (%v), erwartet 500x125", w, h, err)
+	}
+	if res.NewBytes >= res.OrigBytes {
+		t.Errorf("Keine Ersparnis: %d → %d Bytes", res.OrigBytes, res.NewBytes)
+	}
+
+	// Kleine Bilder bleiben unverändert
+	small := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	smallPath := filepath.Join(dir, "small.png")
+	writeTestImage(t, smallPath, small, func(f *os.File, img image.Image) error { return png.Encode(f, img) })
+	res, err = images.Optimize(smallPath, cacheDir, 127, images.OptimizeOptions{MaxDPI: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Changed() {
+		t.Errorf("Kleines Bild sollte unverändert bleiben, erhalten %s", res.Path)
+	}
+}
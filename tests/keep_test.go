@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// renderPages erzeugt ein PDF aus Füllzeilen und den Blöcken und liefert die Inhaltsseiten.
+func renderPages(t *testing.T, layout string, fill int, blks ...blocks.DocBlock) []string {
+	t.Helper()
+	all := []blocks.DocBlock{blocks.PageBreakBlock{}}
+	for i := 0; i < fill; i++ {
+		all = append(all, blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Fuellzeile"}}})
+	}
+	all = append(all, blks...)
+	return pageStreams(t, renderPDF(t, "title: \"Umbruch\"\nlayout:\n  body: \"left\"\n"+layout, all...))[1:]
+}
+
+// linesWith zählt je Seite die Textzeilen, die das Muster enthalten.
+func linesWith(pages []string, pattern string) []int {
+	re := regexp.MustCompile(`BT [\d.]+ ([\d.]+) Td \(([^)]*)\)Tj`)
+	counts := make([]int, len(pages))
+	for i, page := range pages {
+		lines := map[string]bool{}
+		for _, m := range re.FindAllStringSubmatch(page, -1) {
+			if strings.Contains(m[2], pattern) {
+				lines[m[1]] = true
+			}
+		}
+		counts[i] = len(lines)
+	}
+	return counts
+}
+
+// pageOf liefert die erste Seite, auf der der Text steht (-1 = keine).
+func pageOf(pages []string, text string) int {
+	for i, page := range pages {
+		if strings.Contains(page, "("+text) {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestWidowsAndOrphans(t *testing.T) {
+	var words []string
+	for i := 0; i < 40; i++ {
+		words = append(words, fmt.Sprintf("wort%02d", i))
+	}
+	para := blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: strings.Join(words, " ")}}}
+
+	// Ohne Regeln so auffüllen, dass genau eine Zeile am Seitenende stehen bleibt
+	fill := -1
+	for n := 20; n < 60; n++ {
+		counts := linesWith(renderPages(t, "  breaks:\n    widows: 1\n    orphans: 1\n", n, para), "wort")
+		if len(counts) >= 2 && counts[0] == 1 && counts[1] > 0 {
+			fill = n
+			break
+		}
+	}
+	if fill < 0 {
+		t.Fatal("Keine Füllung mit einzelner Zeile am Seitenende gefunden")
+	}
+
+	// Mit den Standardregeln (2/2) beginnt der Absatz auf der neuen Seite
+	counts := linesWith(renderPages(t, "", fill, para), "wort")
+	if counts[0] != 0 {
+		t.Errorf("Schusterjunge: %d Zeile(n) am Seitenende", counts[0])
+	}
+
+	// Eine einzelne letzte Zeile auf der neuen Seite wird ebenfalls vermieden
+	for n := 20; n < 60; n++ {
+		counts := linesWith(renderPages(t, "", n, para), "wort")
+		for i := 1; i < len(counts); i++ {
+			if counts[i-1] > 0 && counts[i] > 0 && (counts[i-1] < 2 || counts[i] < 2) {
+				t.Fatalf("Füllung %d: Absatz geteilt in %d und %d Zeilen", n, counts[i-1], counts[i])
+			}
+		}
+	}
+}
+
+func TestKeepWithNext(t *testing.T) {
+	text := strings.Repeat("Text des Abschnitts mit mehreren Zeilen. ", 8)
+	table := blocks.TableBlock{Rows: [][]blocks.TableRow{
+		{{Content: []blocks.TextSegment{{Text: "Kopf"}}, Header: true}},
+		{{Content: []blocks.TextSegment{{Text: "Zelle"}}}},
+	}}
+	for n := 20; n < 60; n++ {
+		pages := renderPages(t, "", n,
+			blocks.HeadingBlock{Level: 2, Text: "Abschnitt"},
+			blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: text}}},
+			blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Die Tabelle zeigt:"}}},
+			table,
+		)
+		if h, p := pageOf(pages, "Abschnitt"), pageOf(pages, "Text des"); h != p {
+			t.Fatalf("Füllung %d: Überschrift auf Seite %d, Absatz auf Seite %d", n, h, p)
+		}
+		if intro, tbl := pageOf(pages, "Die Tabelle"), pageOf(pages, "Zelle"); intro != tbl {
+			t.Fatalf("Füllung %d: Einleitung auf Seite %d, Tabelle auf Seite %d", n, intro, tbl)
+		}
+	}
+}
+
+func TestKeepTogether(t *testing.T) {
+	blks, err := markdown.Parse([]byte("<!-- keep-together -->\n\nErster\n\nZweiter\n\nDritter\n\n<!-- /keep-together -->\n\n<!-- keep-with-next -->\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keeps []string
+	for _, b := range blks {
+		if k, ok := b.(blocks.KeepBlock); ok {
+			keeps = append(keeps, k.Keep)
+		}
+	}
+	if strings.Join(keeps, ",") != "together,end,next" {
+		t.Fatalf("Direktiven = %v", keeps)
+	}
+
+	for n := 20; n < 60; n++ {
+		pages := renderPages(t, "", n, blks...)
+		if first, last := pageOf(pages, "Erster"), pageOf(pages, "Dritter"); first != last {
+			t.Fatalf("Füllung %d: Gruppe auf den Seiten %d bis %d", n, first, last)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"bytes"
+	"godocgen/internal/engine/mermaid"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadScriptEmbedded(t *testing.T) {
+	if strings.HasSuffix(mermaid.EmbeddedVersion(), "(nicht eingebettet)") {
+		t.Skip("mermaid.min.js fehlt in internal/engine/mermaid/assets (go generate ./internal/engine/mermaid ausführen)")
+	}
+	data, err := mermaid.LoadScript("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) == 0 || !bytes.Contains(data, []byte("mermaid")) {
+		t.Errorf("Eingebettete Mermaid-Bibliothek ist leer oder ungültig (%d Bytes)", len(data))
+	}
+}
+
+func TestLoadScriptOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mermaid.min.js")
+	if err := os.WriteFile(path, []byte("window.mermaid = {};"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := mermaid.LoadScript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "window.mermaid = {};" {
+		t.Errorf("LoadScript = %q", data)
+	}
+	if _, err := mermaid.LoadScript(filepath.Join(t.TempDir(), "fehlt.js")); err == nil {
+		t.Error("Fehlende projektlokale Bibliothek wird nicht gemeldet")
+	}
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/markdown"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPageDimensions(t *testing.T) {
+	cases := []struct {
+		size string
+		w, h float64
+	}{
+		{"A4", 210, 297},
+		{"a5", 148, 210},
+		{"Letter", 215.9, 279.4},
+		{"240x170", 170, 240},
+		{"", 210, 297},
+	}
+	for _, c := range cases {
+		w, h, err := config.Page{Size: c.size}.Dimensions()
+		if err != nil || w != c.w || h != c.h {
+			t.Errorf("%q: got %gx%g (%v), want %gx%g", c.size, w, h, err, c.w, c.h)
+		}
+	}
+	if _, _, err := (config.Page{Size: "B7"}).Dimensions(); err == nil {
+		t.Error("Expected error for unknown page size")
+	}
+}
+
+func TestPageConfigValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docgen.yml")
+	content := `
+title: "Test Doc"
+layout:
+  page:
+    size: "Legal"
+    orientation: "sideways"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.LoadConfig(path); err == nil {
+		t.Error("Expected validation error for invalid orientation")
+	}
+}
+
+func TestOrientationDirectives(t *testing.T) {
+	src := "# Breit\n\n<!-- landscape -->\n\nText\n\n<!-- Kommentar -->\n\n<!--portrait-->\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var orientations []string
+	for _, b := range blks {
+		if o, ok := b.(blocks.OrientationBlock); ok {
+			orientations = append(orientations, o.Orientation)
+		}
+	}
+	if len(orientations) != 2 || orientations[0] != "landscape" || orientations[1] != "portrait" {
+		t.Errorf("Unexpected orientation directives: %v", orientations)
+	}
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"godocgen/internal/engine/pdf"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestMatterDirectives(t *testing.T) {
+	src := "<!-- frontmatter -->\n\n# Vorwort\n\n<!-- mainmatter -->\n\n# Start\n\n<!-- appendix -->\n\n# Glossar\n"
+	blks, err := markdown.Parse([]byte(src), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var matters []string
+	for _, b := range blks {
+		if m, ok := b.(blocks.MatterBlock); ok {
+			matters = append(matters, m.Matter)
+		}
+	}
+	if len(matters) != 3 || matters[0] != "front" || matters[1] != "main" || matters[2] != "appendix" {
+		t.Errorf("Unexpected matter directives: %v", matters)
+	}
+}
+
+func TestPageLabels(t *testing.T) {
+	content := `
+title: "Handbuch"
+toc:
+  enabled: true
+`
+	cfg, dir := loadTestConfig(t, content)
+	if cfg.PageNumbers.Style != "arabic" || cfg.PageNumbers.FrontMatter != "roman" || cfg.PageNumbers.Appendix != "letter" {
+		t.Errorf("Unexpected page number defaults: %+v", cfg.PageNumbers)
+	}
+
+	paragraph := blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Inhalt"}}}
+	blks := []blocks.DocBlock{
+		blocks.MatterBlock{Matter: "front"},
+		blocks.HeadingBlock{Level: 1, Text: "Vorwort"},
+		paragraph,
+		blocks.MatterBlock{Matter: "main"},
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel"},
+		paragraph,
+		blocks.PageBreakBlock{},
+		paragraph,
+		blocks.MatterBlock{Matter: "appendix"},
+		blocks.HeadingBlock{Level: 1, Text: "Glossar"},
+		paragraph,
+	}
+	out := generatePDF(t, pdf.NewGenerator(cfg, blks, dir), dir)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deckblatt ohne Nummer, Inhaltsverzeichnis und Vorwort römisch (i, ii),
+	// Hauptteil arabisch ab Seite 4, Anhang ab Seite 6 als A-1
+	labels := regexp.MustCompile(`/PageLabels << /Nums \[(.*)\] >>`).FindSubmatch(data)
+	if labels == nil {
+		t.Fatal("PDF enthält keine /PageLabels")
+	}
+	want := "0 << >> 1 << /S /r >> 3 << /S /D >> 5 << /S /D /P (A-) >>"
+	if string(labels[1]) != want {
+		t.Errorf("PageLabels = %q, erwartet %q", labels[1], want)
+	}
+	if n := countPages(t, out); n != 6 {
+		t.Errorf("Expected 6 pages, got %d", n)
+	}
+}
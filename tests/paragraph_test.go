@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// renderParagraphs erzeugt ein PDF mit den Absätzen und liefert den Inhalt der Seite nach dem Deckblatt.
+func renderParagraphs(t *testing.T, body string, paragraphs ...blocks.DocBlock) string {
+	t.Helper()
+	blks := append([]blocks.DocBlock{blocks.PageBreakBlock{}}, paragraphs...)
+	streams := pageStreams(t, renderPDF(t, "title: \"Absatz\"\nlayout:\n  body: \""+body+"\"\n", blks...))
+	if len(streams) < 2 {
+		t.Fatalf("Expected content page, got %d streams", len(streams))
+	}
+	return streams[1]
+}
+
+func TestParagraphAlignment(t *testing.T) {
+	page := renderParagraphs(t, "center", blocks.ParagraphBlock{Content: []blocks.TextSegment{
+		{Text: "Mitte "},
+		{Text: "fett", Bold: true},
+	}})
+
+	// Beide Läufe stehen zusammen mittig auf der A4-Seite (gleiche Ränder links und rechts)
+	ref := gofpdf.New("P", "mm", "A4", "")
+	ref.SetFont("Arial", "", 12)
+	width := ref.GetStringWidth("Mitte ")
+	ref.SetFont("Arial", "B", 12)
+	width += ref.GetStringWidth("fett")
+
+	m := regexp.MustCompile(`BT ([\d.]+) [\d.]+ Td \(Mitte\)Tj`).FindStringSubmatch(page)
+	if m == nil {
+		t.Fatalf("Text nicht gefunden:\n%s", page)
+	}
+	x, _ := strconv.ParseFloat(m[1], 64)
+	want := (210 - width) / 2 * 72 / 25.4
+	if math.Abs(x-want) > 0.5 {
+		t.Errorf("Zentrierter Text beginnt bei %.2f pt, erwartet %.2f pt", x, want)
+	}
+}
+
+func TestJustifiedFormattedParagraph(t *testing.T) {
+	text := strings.Repeat("Ein Absatz mit gemischter Formatierung läuft über mehrere Zeilen. ", 4)
+	page := renderParagraphs(t, "justify", blocks.ParagraphBlock{Content: []blocks.TextSegment{
+		{Text: "Dieser "},
+		{Text: "fette", Bold: true},
+		{Text: " Absatz enthält einen "},
+		{Text: "Link", Link: "https://example.com"},
+		{Text: ", "},
+		{Text: "inline code", Code: true},
+		{Text: " und "},
+		{Text: "alten", Strikethrough: true},
+		{Text: " Text. " + text},
+	}})
+
+	// Blocksatz über den Wortabstand (Tw) statt Flattersatz mit Write
+	if !regexp.MustCompile(`[1-9][\d.]* Tw`).MatchString(page) {
+		t.Error("Kein Blocksatz: Wortabstand wird nie gesetzt")
+	}
+	for _, want := range []string{"(fette)Tj", "(inline code)Tj", "(alten)Tj"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Lauf %s fehlt", want)
+		}
+	}
+	// Code-Chip als gefüllte Fläche, Durchstreichung als Linie
+	if !strings.Contains(page, "0.953 0.957 0.965 rg") {
+		t.Error("Hintergrund des Code-Chips fehlt")
+	}
+	if !regexp.MustCompile(`[\d.]+ [\d.]+ m [\d.]+ [\d.]+ l S`).MatchString(page) {
+		t.Error("Durchstreichung fehlt")
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"bytes"
+	"compress/zlib"
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/pdf"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// pageStreams liefert die entpackten Inhaltsströme eines PDFs, die Text enthalten.
+func pageStreams(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streams []string
+	for _, m := range regexp.MustCompile(`(?s)stream\n(.*?)endstream`).FindAllSubmatch(data, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		if err != nil {
+			continue
+		}
+		content, err := io.ReadAll(r)
+		if err != nil || !bytes.Contains(content, []byte("BT")) {
+			continue
+		}
+		streams = append(streams, string(content))
+	}
+	return streams
+}
+
+// loadTestConfig schreibt content als docgen.yml in ein temporäres Projektverzeichnis und lädt sie.
+func loadTestConfig(t *testing.T, content string) (*config.Config, string) {
+	t.Helper()
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "docgen.yml")
+	if err := os.WriteFile(cfgPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(cfgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg, dir
+}
+
+// generatePDF erzeugt mit gen die Datei out.pdf im Projektverzeichnis dir und liefert ihren Pfad.
+func generatePDF(t *testing.T, gen *pdf.Generator, dir string) string {
+	t.Helper()
+	out := filepath.Join(dir, "out.pdf")
+	if err := gen.Generate(out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+// renderPDF erzeugt aus dem Inhalt einer docgen.yml und den Blöcken ein PDF und liefert seinen Pfad.
+func renderPDF(t *testing.T, content string, blks ...blocks.DocBlock) string {
+	t.Helper()
+	cfg, dir := loadTestConfig(t, content)
+	return generatePDF(t, pdf.NewGenerator(cfg, blks, dir), dir)
+}
+
+func TestRunningHeaders(t *testing.T) {
+	content := `
+title: "Handbuch"
+header:
+  text: "{chapter} | {section}"
+footer:
+  center: "{file}"
+`
+
+	paragraph := blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Inhalt"}}}
+	blks := []blocks.DocBlock{
+		blocks.PageBreakBlock{},
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel Eins", File: "eins.md"},
+		paragraph,
+		blocks.HeadingBlock{Level: 2, Text: "Abschnitt A", File: "eins.md"},
+		paragraph,
+		blocks.PageBreakBlock{},
+		paragraph,
+		blocks.HeadingBlock{Level: 1, Text: "Kapitel Zwei", File: "zwei.md"},
+		paragraph,
+	}
+	out := renderPDF(t, content, blks...)
+
+	streams := pageStreams(t, out)
+	if len(streams) != 3 {
+		t.Fatalf("Expected 3 pages with text, got %d", len(streams))
+	}
+	// Kopfzeilen zeigen das erste Kapitel bzw. den ersten Abschnitt, der auf der Seite beginnt
+	want := []string{"(Kapitel Eins | Abschnitt A)", "(Kapitel Zwei | )"}
+	for i, w := range want {
+		if !strings.Contains(streams[i+1], w) {
+			t.Errorf("Seite %d: Kopfzeile %s fehlt", i+2, w)
+		}
+	}
+	if !strings.Contains(streams[1], "(eins.md)") || !strings.Contains(streams[2], "(zwei.md)") {
+		t.Error("Platzhalter {file} wurde nicht durch die Quelldatei ersetzt")
+	}
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"bytes"
+	"godocgen/internal/engine/svg"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// coreFonts nutzt die PDF-Standardschriften für SVG-Texte.
+type coreFonts struct{ pdf *gofpdf.Fpdf }
+
+func (f coreFonts) SetFont(mono, bold, italic bool, sizePt float64) {
+	style := ""
+	if bold {
+		style += "B"
+	}
+	if italic {
+		style += "I"
+	}
+	f.pdf.SetFont("Helvetica", style, sizePt)
+}
+
+func (f coreFonts) PrepareText(text string) string { return text }
+
+const mermaidLikeSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="100%" viewBox="0 0 200 100" style="max-width: 200px;">
+<style>#m .node rect{fill:#ECECFF;stroke:#9370DB;stroke-width:1px;}#m .edgePath .path{stroke:#333333;fill:none;}#m .label{color:#333;font-size:16px;}</style>
+<g id="m">
+  <marker id="arrow" viewBox="0 0 10 10" refX="6" refY="5" markerUnits="userSpaceOnUse" markerWidth="12" markerHeight="12" orient="auto"><path d="M 0 0 L 10 5 L 0 10 z"/></marker>
+  <g class="node" transform="translate(40,50)"><rect x="-30" y="-15" width="60" height="30" rx="5"/>
+    <foreignObject x="-25" y="-12" width="50" height="24"><div xmlns="http://www.w3.org/1999/xhtml"><span class="label">Start</span></div></foreignObject></g>
+  <g class="edgePath"><path class="path" d="M70,50C100,50 110,50 120,50q10,0 20,0" marker-end="url(#arrow)"/></g>
+  <text x="170" y="50" text-anchor="middle" dominant-baseline="central">Ende<tspan x="170" dy="1.2em">Zeile 2</tspan></text>
+  <circle cx="170" cy="20" r="8" fill="rgb(255,0,0)" fill-opacity="0.5"/>
+  <path d="M10 90 a5 5 0 1 0 10 0 h10 v-5 Z" fill="none" stroke="black"/>
+</g>
+</svg>`
+
+func TestSVGParseSize(t *testing.T) {
+	doc, err := svg.Parse(strings.NewReader(mermaidLikeSVG))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, h := doc.Size()
+	if w != 200 || h != 100 {
+		t.Errorf("Expected size 200x100 from viewBox, got %gx%g", w, h)
+	}
+
+	if _, err := svg.Parse(strings.NewReader(`<html></html>`)); err == nil {
+		t.Error("Expected error for non-SVG document")
+	}
+}
+
+func TestSVGDrawsVectorsAndText(t *testing.T) {
+	doc, err := svg.Parse(strings.NewReader(mermaidLikeSVG))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetCompression(false)
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "", 10)
+	doc.Draw(pdf, coreFonts{pdf}, 10, 10, 100, 50)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, text := range []string{"(Start) Tj", "(Ende) Tj", "(Zeile 2) Tj"} {
+		if !strings.Contains(out, text) {
+			t.Errorf("Expected text %q as PDF text", text)
+		}
+	}
+	// Kurven (Rundungen, Kreis, Bogen) und gefüllte Pfade müssen als Vektoren vorliegen
+	if !strings.Contains(out, " c\n") && !strings.Contains(out, " c ") {
+		t.Error("Expected cubic curve operators in output")
+	}
+	// Füllfarbe #ECECFF der Knoten aus dem Stylesheet
+	if !strings.Contains(out, "0.925 0.925 1.000 rg") {
+		t.Error("Expected CSS fill colour of node rect")
+	}
+}
@@ -0,0 +1,331 @@
+package tests
+
+import (
+	"fmt"
+	"godocgen/internal/blocks"
+	"godocgen/internal/engine/markdown"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// tableCell erzeugt eine Tabellenzelle mit Text.
+func tableCell(text string, header bool) blocks.TableRow {
+	return blocks.TableRow{Content: []blocks.TextSegment{{Text: text}}, Header: header}
+}
+
+func TestTableSplitsAcrossPages(t *testing.T) {
+	rows := [][]blocks.TableRow{{tableCell("Kopf", true), tableCell("Nr", true)}}
+	for i := 0; i < 80; i++ {
+		rows = append(rows, []blocks.TableRow{tableCell(fmt.Sprintf("Zeile %02d", i), false), tableCell("x", false)})
+	}
+	// Eine Zeile, die allein höher als eine Seite ist
+	rows = append(rows, []blocks.TableRow{tableCell(strings.Repeat("Langtext ", 1200), false), tableCell("x", false)})
+	rows = append(rows, []blocks.TableRow{tableCell("Ende", false), tableCell("x", false)})
+
+	pages := renderPages(t, "tables:\n  continued: true\n", 0, blocks.TableBlock{Rows: rows})
+	if len(pages) < 4 {
+		t.Fatalf("Expected table over several pages, got %d", len(pages))
+	}
+
+	longPages := 0
+	for i, page := range pages {
+		if !strings.Contains(page, "(Zeile") && !strings.Contains(page, "Langtext") && !strings.Contains(page, "(Ende)") {
+			continue
+		}
+		if !strings.Contains(page, "(Kopf)Tj") {
+			t.Errorf("Seite %d: Kopfzeile fehlt", i)
+		}
+		if i > 0 && !strings.Contains(page, "Fortsetzung") {
+			t.Errorf("Seite %d: Fortsetzungshinweis fehlt", i)
+		}
+		if strings.Contains(page, "Langtext") {
+			longPages++
+		}
+	}
+	if longPages < 2 {
+		t.Errorf("Überlange Zeile steht auf %d Seite(n), erwartet eine Teilung", longPages)
+	}
+	if strings.Contains(pages[0], "Fortsetzung") {
+		t.Error("Fortsetzungshinweis auf der ersten Tabellenseite")
+	}
+	if pageOf(pages, "Ende") < 0 {
+		t.Error("Letzte Zeile fehlt")
+	}
+}
+
+func TestGridTable(t *testing.T) {
+	md := "+------+------------------+-----:+\n" +
+		"| Feld | Beschreibung     |   Nr |\n" +
+		"+======+==================+=====:+\n" +
+		"| name | Erster Absatz.   | 1    |\n" +
+		"|      |                  |      |\n" +
+		"|      | - Pflichtfeld    |      |\n" +
+		"|      | - eindeutig      |      |\n" +
+		"+------+------------------+------+\n" +
+		"| Hinweis ueber Spalten   | 2    |\n" +
+		"+-------------------------+      +\n" +
+		"| Zweite Zeile            |      |\n" +
+		"+-------------------------+------+\n"
+	blks, err := markdown.Parse([]byte(md), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, ok := blks[0].(blocks.TableBlock)
+	if !ok || len(blks) != 1 {
+		t.Fatalf("Expected one table, got %#v", blks)
+	}
+	if len(table.Rows) != 4 || len(table.Rows[0]) != 3 || !table.Rows[0][0].Header || table.Rows[1][0].Header {
+		t.Fatalf("Zeilen = %#v", table.Rows)
+	}
+	if table.Alignments[2] != blocks.AlignRight {
+		t.Errorf("Ausrichtung = %v", table.Alignments)
+	}
+	if cell := table.Rows[1][1]; len(cell.Blocks) != 2 {
+		t.Errorf("Blockinhalt = %#v", cell)
+	} else if list, ok := cell.Blocks[1].(blocks.ListBlock); !ok || len(list.Items) != 2 {
+		t.Errorf("Liste in Zelle = %#v", cell.Blocks[1])
+	}
+	if span := table.Rows[2][0].ColSpan; span != 2 {
+		t.Errorf("ColSpan = %d", span)
+	}
+	if span := table.Rows[2][1].RowSpan; span != 2 {
+		t.Errorf("RowSpan = %d", span)
+	}
+	if len(table.Rows[3]) != 1 {
+		t.Errorf("Letzte Zeile = %#v", table.Rows[3])
+	}
+
+	// Die überspannenden Zellen sind so breit bzw. hoch wie ihre Spalten bzw. Zeilen
+	pages := renderPages(t, "", 0, table)
+	page := pages[0]
+	for _, text := range []string{"Pflichtfeld", "eindeutig", "Hinweis", "Zweite Zeile"} {
+		if !strings.Contains(page, text) {
+			t.Errorf("%q fehlt", text)
+		}
+	}
+	rects := cellRects(page)
+	if len(rects) != 9 {
+		t.Fatalf("Expected 9 cell borders, got %d", len(rects))
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.05 }
+	if !near(rects[6][2], rects[0][2]+rects[1][2]) {
+		t.Errorf("ColSpan-Breite %.2f, erwartet %.2f", rects[6][2], rects[0][2]+rects[1][2])
+	}
+	if !near(rects[7][3], rects[6][3]+rects[8][3]) {
+		t.Errorf("RowSpan-Höhe %.2f, erwartet %.2f", rects[7][3], rects[6][3]+rects[8][3])
+	}
+	if rects[4][3] <= rects[0][3] {
+		t.Error("Zeile mit Blockinhalt ist nicht höher als die Kopfzeile")
+	}
+}
+
+// cellRects liefert die Zellrahmen (x, y, Breite, Höhe in pt) einer Seite.
+func cellRects(page string) [][4]float64 {
+	re := regexp.MustCompile(`([\d.]+) ([\d.]+) ([\d.]+) -([\d.]+) re S`)
+	var rects [][4]float64
+	for _, m := range re.FindAllStringSubmatch(page, -1) {
+		var r [4]float64
+		for i := range r {
+			r[i], _ = strconv.ParseFloat(m[i+1], 64)
+		}
+		rects = append(rects, r)
+	}
+	return rects
+}
+
+// textX liefert die x-Position eines Textes auf der Seite (-1 = nicht gefunden).
+func textX(page, text string) float64 {
+	m := regexp.MustCompile(`BT ([\d.]+) [\d.]+ Td \(` + regexp.QuoteMeta(text) + `\)Tj`).FindStringSubmatch(page)
+	if m == nil {
+		return -1
+	}
+	x, _ := strconv.ParseFloat(m[1], 64)
+	return x
+}
+
+func TestTableOptions(t *testing.T) {
+	md := "<!-- table widths=\"50%,*,30\" align=\"l,c,d\" zebra=false -->\n\n" +
+		"| Name | Art | Wert |\n|---|---|---|\n| a | x | 1,5 |\n| b | y | 123,25 |\n| c | z | 3 |\n| d | w | 4 |\n"
+	blks, err := markdown.Parse([]byte(md), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 1 {
+		t.Fatalf("Erwartet nur die Tabelle, erhalten: %#v", blks)
+	}
+	table, ok := blks[0].(blocks.TableBlock)
+	if !ok {
+		t.Fatalf("Erwartet TableBlock, erhalten %T", blks[0])
+	}
+	opts := table.Options
+	if len(opts.Widths) != 3 || opts.Widths[0] != (blocks.TableWidth{Value: 50, Percent: true}) ||
+		opts.Widths[1].Value != 0 || opts.Widths[2] != (blocks.TableWidth{Value: 30}) {
+		t.Errorf("Widths = %#v", opts.Widths)
+	}
+	if len(opts.Align) != 3 || opts.Align[1] != blocks.AlignCenter || opts.Align[2] != blocks.AlignDecimal {
+		t.Errorf("Align = %#v", opts.Align)
+	}
+	if opts.Zebra == nil || *opts.Zebra {
+		t.Errorf("Zebra = %v", opts.Zebra)
+	}
+
+	page := renderPages(t, "", 0, table)[0]
+	rects := cellRects(page)
+	if len(rects) != 15 {
+		t.Fatalf("Expected 15 cell borders, got %d", len(rects))
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.05 }
+	total := rects[0][2] + rects[1][2] + rects[2][2]
+	if !near(rects[0][2], total/2) {
+		t.Errorf("50%%-Spalte ist %.2f von %.2f pt breit", rects[0][2], total)
+	}
+	if !near(rects[2][2], 30*72/25.4) {
+		t.Errorf("30-mm-Spalte ist %.2f pt breit", rects[2][2])
+	}
+	// Ohne Zebra haben alle Datenzeilen denselben Hintergrund
+	if strings.Contains(page, "0.961 0.969 0.980 rg") {
+		t.Error("Zebra-Streifen trotz zebra=false")
+	}
+
+	// Dezimale Ausrichtung: Die Trennzeichen stehen untereinander, Zahlen mit gleich vielen
+	// Vorkommastellen beginnen also an derselben Stelle
+	if x := textX(page, "1,5"); x <= textX(page, "123,25") {
+		t.Errorf("1,5 beginnt nicht rechts von 123,25 (%.2f)", x)
+	}
+	for _, text := range []string{"3", "4"} {
+		if !near(textX(page, text), textX(page, "1,5")) {
+			t.Errorf("%s beginnt bei %.2f pt, 1,5 bei %.2f pt", text, textX(page, text), textX(page, "1,5"))
+		}
+	}
+}
+
+func TestTableDecimalSeparatorPerValue(t *testing.T) {
+	// Deutsches Dokument mit Werten in beiden Schreibweisen: Das Trennzeichen wird je Wert erkannt
+	md := "<!-- table align=\"l,d\" -->\n\n" +
+		"| Name | Wert |\n|---|---|\n| a | 3.14 |\n| b | 1,5 |\n| c | 12.75 |\n| d | 1 |\n| e | 12 |\n| f | 2,5 % |\n"
+	blks, err := markdown.Parse([]byte(md), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := renderPages(t, "language: \"de\"\n", 0, blks...)[0]
+	x := func(text string) float64 {
+		v := textX(page, text)
+		if v < 0 {
+			t.Fatalf("%q fehlt", text)
+		}
+		return v
+	}
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.05 }
+
+	// Gleich viele Vorkommastellen: Die Werte beginnen an derselben Stelle, ihre Trennzeichen stehen untereinander
+	for _, text := range []string{"1,5", "1", "2,5 %"} {
+		if !near(x(text), x("3.14")) {
+			t.Errorf("%s beginnt bei %.2f pt, 3.14 bei %.2f pt", text, x(text), x("3.14"))
+		}
+	}
+	// Eine Vorkommastelle mehr rückt um genau eine Ziffernbreite nach links
+	if digit := x("1") - x("12"); digit <= 0 || !near(x("3.14")-x("12.75"), digit) {
+		t.Errorf("12.75 beginnt bei %.2f pt, erwartet %.2f pt", x("12.75"), x("3.14")-digit)
+	}
+}
+
+func TestTableStyleDefaults(t *testing.T) {
+	rows := [][]blocks.TableRow{
+		{tableCell("Kopf", true), tableCell("Nr", true)},
+		{tableCell("eins", false), tableCell("1", false)},
+		{tableCell("zwei", false), tableCell("2", false)},
+	}
+
+	page := renderPages(t, "", 0, blocks.TableBlock{Rows: rows})[0]
+	standard := cellRects(page)
+	if !strings.Contains(page, "0.961 0.969 0.980 rg") {
+		t.Error("Zebra-Streifen fehlen im Standardstil")
+	}
+
+	// Vorgaben aus docgen.yml
+	page = renderPages(t, "tables:\n  style: compact\n  zebra: false\n  stretch: false\n", 0, blocks.TableBlock{Rows: rows})[0]
+	compact := cellRects(page)
+	if compact[0][3] >= standard[0][3] {
+		t.Errorf("Kompakte Zeile %.2f pt ist nicht niedriger als %.2f pt", compact[0][3], standard[0][3])
+	}
+	if compact[0][2]+compact[1][2] >= standard[0][2]+standard[1][2] {
+		t.Error("stretch=false streckt die Tabelle trotzdem")
+	}
+	if strings.Contains(page, "0.961 0.969 0.980 rg") {
+		t.Error("Zebra-Streifen trotz tables.zebra=false")
+	}
+
+	// Die Direktive hat Vorrang vor docgen.yml
+	table := blocks.TableBlock{Rows: rows, Options: blocks.TableOptions{Style: "bordered", FontSize: 8}}
+	page = renderPages(t, "tables:\n  style: compact\n", 0, table)[0]
+	if !strings.Contains(page, "8.00 Tf") {
+		t.Error("font-size der Tabelle wird nicht verwendet")
+	}
+	if bordered := cellRects(page); bordered[0][3] <= compact[0][3] {
+		t.Error("style=bordered der Tabelle wird durch den Stil aus docgen.yml ersetzt")
+	}
+}
+
+func TestTableDirectiveWithoutTable(t *testing.T) {
+	blks, err := markdown.Parse([]byte("<!-- table style=compact -->\n\nText\n\n| A |\n|---|\n| b |\n"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blks) != 2 {
+		t.Fatalf("Erwartet Absatz und Tabelle, erhalten: %#v", blks)
+	}
+	if table, ok := blks[1].(blocks.TableBlock); !ok || table.Options.Style != "" {
+		t.Errorf("Direktive wurde auf eine entfernte Tabelle übertragen: %#v", blks[1])
+	}
+}
+
+func TestTableBlockRowTallerThanPage(t *testing.T) {
+	var list blocks.ListBlock
+	for i := 0; i < 120; i++ {
+		list.Items = append(list.Items, blocks.ListItem{Content: []blocks.TextSegment{{Text: fmt.Sprintf("Punkt %03d", i)}}})
+	}
+	table := blocks.TableBlock{Rows: [][]blocks.TableRow{
+		{tableCell("Feld", true), tableCell("Werte", true)},
+		{tableCell("lang", false), {Blocks: []blocks.DocBlock{list}}},
+		{tableCell("Ende", false), tableCell("x", false)},
+	}}
+	pages := renderPages(t, "", 0, table, blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Danach"}}})
+
+	// Die Zeile bleibt auf einer Seite und wird am unteren Rand abgeschnitten statt darüber hinaus zu laufen
+	var listPages []int
+	for i, page := range pages {
+		if strings.Contains(page, "Punkt 000") {
+			listPages = append(listPages, i)
+		}
+	}
+	if len(listPages) != 1 {
+		t.Fatalf("Zeile mit Blockinhalt steht auf den Seiten %v", listPages)
+	}
+	page := pages[listPages[0]]
+	// Der Rahmen der Zeile schließt mit dem Ausschnitt ab, ihre Beschriftung liegt darin
+	clip := regexp.MustCompile(`q [\d.]+ ([\d.]+) [\d.]+ -([\d.]+) re W n`).FindStringSubmatch(page)
+	if clip == nil {
+		t.Fatal("Überhohe Zeile wird nicht abgeschnitten")
+	}
+	top, _ := strconv.ParseFloat(clip[1], 64)
+	height, _ := strconv.ParseFloat(clip[2], 64)
+	if rects := cellRects(page); len(rects) < 4 || math.Abs(rects[2][3]-height) > 0.05 {
+		t.Errorf("Zellrahmen %v passen nicht zum Ausschnitt (Höhe %.2f)", rects, height)
+	}
+	m := regexp.MustCompile(`BT [\d.]+ (-?[\d.]+) Td \(lang\)Tj`).FindStringSubmatch(page)
+	if m == nil {
+		t.Fatal("Beschriftung der Zeile fehlt")
+	}
+	if y, _ := strconv.ParseFloat(m[1], 64); y > top || y < top-height {
+		t.Errorf("Beschriftung bei y = %.2f außerhalb des Ausschnitts %.2f-%.2f", y, top-height, top)
+	}
+	if p := pageOf(pages, "Ende"); p <= listPages[0] {
+		t.Errorf("Folgezeile auf Seite %d, erwartet nach Seite %d", p, listPages[0])
+	}
+	if pageOf(pages, "Danach") < pageOf(pages, "Ende") {
+		t.Error("Text nach der Tabelle fehlt oder steht zu früh")
+	}
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"godocgen/internal/blocks"
+	"godocgen/internal/config"
+	"godocgen/internal/engine/pdf"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeaderFooterTemplates(t *testing.T) {
+	content := `
+title: "Handbuch"
+date_format: "2006-01-02"
+vars:
+  dept: "Entwicklung"
+header:
+  left: "{{.Chapter}}"
+  right: "{{upper .Vars.dept}}"
+  rule: true
+footer:
+  left: "{{.Build.Date}} v{{.Build.Version}}"
+  right: "{page}/{total}"
+`
+	cfg, dir := loadTestConfig(t, content)
+	if cfg.Header.Height != 10 || cfg.Footer.FontSize != 8 {
+		t.Errorf("Standardwerte für Höhe und Schriftgröße fehlen: %+v", cfg.Header.PageZones)
+	}
+
+	blks := []blocks.DocBlock{
+		blocks.PageBreakBlock{},
+		blocks.HeadingBlock{Level: 1, Text: "Einleitung"},
+		blocks.ParagraphBlock{Content: []blocks.TextSegment{{Text: "Inhalt"}}},
+	}
+	gen := pdf.NewGenerator(cfg, blks, dir)
+	gen.SetBuildInfo(pdf.BuildInfo{Time: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), Version: 7})
+	out := generatePDF(t, gen, dir)
+
+	streams := pageStreams(t, out)
+	if len(streams) != 2 {
+		t.Fatalf("Expected 2 pages with text, got %d", len(streams))
+	}
+	for _, want := range []string{"(Einleitung)", "(ENTWICKLUNG)", "(2026-03-14 v7)", "(1/1)"} {
+		if !strings.Contains(streams[1], want) {
+			t.Errorf("Inhalt %s fehlt in Header oder Footer", want)
+		}
+	}
+}
+
+func TestHeaderFooterTemplateError(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Footer.Center = "{{.Page"
+	gen := pdf.NewGenerator(cfg, nil, t.TempDir())
+	err := gen.Generate(filepath.Join(t.TempDir(), "out.pdf"))
+	if err == nil || !strings.Contains(err.Error(), "footer.center") {
+		t.Errorf("Expected template error for footer.center, got %v", err)
+	}
+}